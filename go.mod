@@ -3,19 +3,21 @@ module kalimah
 go 1.17
 
 require (
+	github.com/BurntSushi/toml v1.2.1
 	github.com/NYTimes/gziphandler v1.1.1
 	github.com/jmoiron/sqlx v1.3.4
 	github.com/julienschmidt/httprouter v1.3.0
+	github.com/lib/pq v1.10.9
 	github.com/mattn/go-sqlite3 v1.14.16
 	github.com/muesli/go-app-paths v0.2.2
 	github.com/sirupsen/logrus v1.8.1
 	github.com/spf13/cobra v1.4.0
+	github.com/spf13/pflag v1.0.5
 	github.com/yuin/goldmark v1.2.1
 )
 
 require (
 	github.com/inconshreveable/mousetrap v1.0.0 // indirect
 	github.com/mitchellh/go-homedir v1.1.0 // indirect
-	github.com/spf13/pflag v1.0.5 // indirect
 	golang.org/x/sys v0.0.0-20211007075335-d3039528d8ac // indirect
 )
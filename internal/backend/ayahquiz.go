@@ -0,0 +1,390 @@
+package backend
+
+import (
+	"encoding/json"
+	"kalimah/internal/store"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// ayahChoiceCount is the number of choices for each question in ayah quiz.
+// It's fewer than in word quiz since the ayah translation is way longer.
+const ayahChoiceCount = 4
+
+// GetAyahQuiz returns a page of ayahs within the surah, each with the translation
+// choices. The incorrect choices are taken from the neighbouring ayahs in the same
+// surah, so they are not trivially distinguishable from the correct one.
+func (s *Server) GetAyahQuiz(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	var err error
+	defer func() {
+		if err != nil {
+			writeError(w, r, err)
+		}
+	}()
+
+	// Parse surah from URL params
+	surah, err := parseSurah(ps.ByName("surah"))
+	if err != nil {
+		return
+	}
+
+	// Decide the seed of choices
+	seed, err := s.choiceSeed(r, time.Now())
+	if err != nil {
+		return
+	}
+
+	// Fetch the surah and its ayah count
+	surahData, err := fetchSurah(s.Store, surah)
+	if err != nil {
+		return
+	}
+
+	nAyah := surahData.End - surahData.Start + 1
+
+	// Fetch the page of the next unanswered ayah within this surah
+	nAyahPerPage := s.AyahPerPage
+	if nAyahPerPage <= 0 {
+		nAyahPerPage = 30
+	}
+
+	progress, err := s.Store.AyahProgress()
+	if err != nil {
+		return
+	}
+
+	nextAyah := progress.LastAyah + 1
+	if nextAyah < surahData.Start {
+		nextAyah = surahData.Start
+	} else if nextAyah > surahData.End {
+		nextAyah = surahData.End
+	}
+	lastAnsweredPage := (nextAyah-surahData.Start)/nAyahPerPage + 1
+
+	// Parse and adjust pagination
+	maxPage := int(math.Ceil(float64(nAyah) / float64(nAyahPerPage)))
+	pageParam := r.URL.Query().Get("page")
+	if pageParam == "" {
+		pageParam = "0"
+	}
+
+	page, err := parsePage(pageParam, maxPage)
+	if err != nil {
+		return
+	}
+
+	if page == 0 {
+		page = lastAnsweredPage
+	}
+
+	// Fetch ayahs for this page
+	queryStart := time.Now()
+	ayahs, err := fetchAyahQuestions(s.Store, surahData, progress.LastAyah,
+		surahData.Start+nAyahPerPage*(page-1), surahData.Start+nAyahPerPage*page-1)
+	s.observeQuery("page_ayahs", queryStart)
+	if err != nil {
+		return
+	}
+
+	// Fetch the translation of every ayah in this surah as choice candidates. When the
+	// surah is too short to provide enough distractors, use random ayahs as well.
+	queryStart = time.Now()
+	candidates, err := fetchAyahChoiceCandidates(s.Store, surahData, seed)
+	s.observeQuery("ayah_choice_candidates", queryStart)
+	if err != nil {
+		return
+	}
+
+	// Apply choice to each ayah
+	applyAyahChoices(ayahs, candidates, ayahChoiceCount, seed)
+
+	// Check if this page is disabled
+	pageDisabled := true
+	for i := range ayahs {
+		if !ayahs[i].Disabled {
+			pageDisabled = false
+			break
+		}
+	}
+
+	// Create return data
+	data := AyahQuizPage{
+		CurrentPage: page,
+		MaxPage:     maxPage,
+		Ayahs:       ayahs,
+		Disabled:    pageDisabled,
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(&data)
+}
+
+// GetAyahProgress returns the progress of ayah quiz.
+func (s *Server) GetAyahProgress(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	var err error
+	defer func() {
+		if err != nil {
+			writeError(w, r, err)
+		}
+	}()
+
+	storeProgress, err := s.Store.AyahProgress()
+	if err != nil {
+		return
+	}
+
+	progress := AyahProgress{
+		LastAyah: storeProgress.LastAyah,
+		Surah:    storeProgress.Surah,
+		Ayah:     storeProgress.Ayah,
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(&progress)
+}
+
+// TrackAyah marks the ayah as the last answered ayah in ayah quiz.
+func (s *Server) TrackAyah(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	var err error
+	defer func() {
+		if err != nil {
+			writeError(w, r, err)
+		}
+	}()
+
+	// Decode request
+	var currentAyah TrackInput
+	err = json.NewDecoder(r.Body).Decode(&currentAyah)
+	if err != nil {
+		err = badRequest("invalid ayah: %v", err)
+		return
+	}
+
+	// Validate and update inside transaction, so the progress is not changed in between
+	allowRewind := r.URL.Query().Get("rewind") == "true"
+	err = s.Store.Tx(func(tx store.Store) error {
+		if err := validateTrackedAyah(tx, currentAyah.ID, allowRewind); err != nil {
+			return err
+		}
+		return tx.SetLastAyah(currentAyah.ID)
+	})
+}
+
+// fetchAyahQuestions fetches the ayahs between first and last within the surah, along
+// with its Arabic text. Ayahs up to lastAyah are answered, while ayahs beyond the next
+// one or whose words haven't been learned in word quiz are disabled.
+func fetchAyahQuestions(st store.Store, surah store.Surah, lastAyah, first, last int) ([]AyahQuestion, error) {
+	if last > surah.End {
+		last = surah.End
+	}
+
+	ayahs, err := st.Ayahs(first, last)
+	if err != nil {
+		return nil, err
+	}
+
+	words, err := st.Words(first, last)
+	if err != nil {
+		return nil, err
+	}
+
+	learned, err := learnedAyah(st)
+	if err != nil {
+		return nil, err
+	}
+
+	// Join the words of each ayah into its Arabic text
+	arabic := make(map[int][]string)
+	for _, word := range words {
+		arabic[word.Ayah] = append(arabic[word.Ayah], word.Arabic)
+	}
+
+	enabledAyah := lastAyah + 1
+	if learned < enabledAyah {
+		enabledAyah = learned
+	}
+
+	questions := make([]AyahQuestion, len(ayahs))
+	for i, ayah := range ayahs {
+		questions[i] = AyahQuestion{
+			ID:          ayah.ID,
+			Ayah:        ayah.ID - surah.Start + 1,
+			Arabic:      strings.Join(arabic[ayah.ID], " "),
+			Translation: ayah.Translation,
+			Answered:    ayah.ID <= lastAyah,
+			Disabled:    ayah.ID > enabledAyah,
+		}
+	}
+	return questions, nil
+}
+
+// learnedAyah returns the last ayah whose words have all been answered in word quiz,
+// i.e. the ayah before the next unanswered word.
+func learnedAyah(st store.Store) (int, error) {
+	progress, err := st.Progress()
+	if err != nil {
+		return 0, err
+	}
+
+	lastWordID, err := st.LastWordID()
+	if err != nil {
+		return 0, err
+	}
+
+	if progress.LastWord < lastWordID {
+		return progress.NextAyah - 1, nil
+	}
+	return lastAyahID(st)
+}
+
+// lastAyahID returns the ID of the last ayah, or zero when there are no ayahs.
+func lastAyahID(st store.SurahStore) (int, error) {
+	surahs, err := st.Surahs()
+	if err != nil || len(surahs) == 0 {
+		return 0, err
+	}
+	return surahs[len(surahs)-1].End, nil
+}
+
+type ayahCandidate struct {
+	ID          int    `db:"id"`
+	Translation string `db:"translation"`
+}
+
+// fetchAyahChoiceCandidates fetches the translation of ayahs within the surah, followed
+// by ayahs from elsewhere when the surah has fewer ayahs than the choices. The other
+// ayahs are sampled using the seed, so the same seed always gives the same candidates.
+func fetchAyahChoiceCandidates(st store.SurahStore, surah store.Surah, seed uint64) ([]ayahCandidate, error) {
+	ayahs, err := st.Ayahs(surah.Start, surah.End)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]ayahCandidate, len(ayahs))
+	for i, ayah := range ayahs {
+		candidates[i] = ayahCandidate{ID: ayah.ID, Translation: ayah.Translation}
+	}
+
+	if len(candidates) >= ayahChoiceCount*2 || len(candidates) == 0 {
+		return candidates, nil
+	}
+
+	maxAyahID, err := lastAyahID(st)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sample the ayahs outside of this surah. The surah is negated for the random
+	// generator, so it doesn't share the sequence with any ayah.
+	start, end := candidates[0].ID, candidates[len(candidates)-1].ID
+	rng := ayahRand(seed, -surah.ID)
+	var sampledIDs []int
+	for i := 0; i < ayahChoiceCount*2*3 && maxAyahID > end-start+1; i++ {
+		id := rng.Intn(maxAyahID) + 1
+		if id < start || id > end {
+			sampledIDs = append(sampledIDs, id)
+		}
+	}
+
+	if len(sampledIDs) == 0 {
+		return candidates, nil
+	}
+
+	sampled, err := st.AyahsByID(sampledIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	translations := make(map[int]string, len(sampled))
+	for _, c := range sampled {
+		translations[c.ID] = c.Translation
+	}
+
+	// Other ayahs are given zero ID, so they are considered as the farthest neighbour
+	nExtras := 0
+	for _, id := range sampledIDs {
+		translation, exist := translations[id]
+		if !exist || nExtras >= ayahChoiceCount*2 {
+			continue
+		}
+
+		delete(translations, id)
+		candidates = append(candidates, ayahCandidate{ID: 0, Translation: translation})
+		nExtras++
+	}
+
+	return candidates, nil
+}
+
+// applyAyahChoices generates the choices for each ayah, using its translation as the
+// correct choice and the translation of its nearest neighbours as the incorrect ones.
+// The neighbours are picked using the seed, so the same seed gives the same choices.
+func applyAyahChoices(ayahs []AyahQuestion, candidates []ayahCandidate, nChoices int, seed uint64) {
+	for i, ayah := range ayahs {
+		// Sort candidates by its distance to this ayah
+		neighbours := make([]ayahCandidate, len(candidates))
+		copy(neighbours, candidates)
+		sort.SliceStable(neighbours, func(a, b int) bool {
+			return ayahDistance(ayah.ID, neighbours[a].ID) < ayahDistance(ayah.ID, neighbours[b].ID)
+		})
+
+		// Pick the distinct translations among the nearest neighbours within the surah,
+		// with twice the needed amount so the distractors vary between sessions
+		var pool, extras []string
+		used := map[string]struct{}{ayah.Translation: {}}
+		for _, n := range neighbours {
+			if _, exists := used[n.Translation]; exists {
+				continue
+			}
+
+			used[n.Translation] = struct{}{}
+			if n.ID == 0 {
+				extras = append(extras, n.Translation)
+			} else if len(pool) < (nChoices-1)*2 {
+				pool = append(pool, n.Translation)
+			}
+		}
+
+		ayahRand(seed, ayah.ID).Shuffle(len(pool), func(a, b int) {
+			pool[a], pool[b] = pool[b], pool[a]
+		})
+
+		// Fill the rest with random ayahs when the surah is too short
+		pool = append(pool, extras...)
+		if len(pool) > nChoices-1 {
+			pool = pool[:nChoices-1]
+		}
+
+		// Prepare choices for this ayah
+		choices := []Choice{{Text: ayah.Translation, IsCorrect: true}}
+		for _, text := range pool {
+			choices = append(choices, Choice{Text: text, IsCorrect: false})
+		}
+
+		// Sort the choices
+		sort.Slice(choices, func(i, j int) bool {
+			return choices[i].Text < choices[j].Text
+		})
+
+		// Apply choices to ayah
+		ayahs[i].Choices = choices
+	}
+}
+
+// ayahDistance returns how far the candidate is from the ayah. Candidate with
+// zero ID comes from another surah, so it's always the farthest.
+func ayahDistance(ayahID, candidateID int) int {
+	if candidateID == 0 {
+		return math.MaxInt32
+	}
+
+	if candidateID > ayahID {
+		return candidateID - ayahID
+	}
+	return ayahID - candidateID
+}
@@ -0,0 +1,53 @@
+package backend
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestAyahQuiz(t *testing.T) {
+	s, h := newTestServer(t)
+
+	// Words of ayah 1 and 2 have been learned
+	if err := s.Store.SetLastWord(4); err != nil {
+		t.Fatal(err)
+	}
+
+	var page AyahQuizPage
+	rec := doRequest(t, h, http.MethodGet, apiPrefix+"/surahs/1/ayah-quiz?seed=1", nil, &page)
+	expectStatus(t, rec, http.StatusOK)
+
+	if page.CurrentPage != 1 || page.MaxPage != 2 || len(page.Ayahs) != 2 {
+		t.Fatalf("unexpected page: %+v", page)
+	}
+
+	if page.Ayahs[1].Arabic != "الْحَمْدُ لِلَّهِ" || len(page.Ayahs[1].Choices) != ayahChoiceCount {
+		t.Fatalf("unexpected ayah: %+v", page.Ayahs[1])
+	}
+
+	// Ayah can only be tracked once its words have been learned, one at a time
+	rec = doRequest(t, h, http.MethodPut, apiPrefix+"/progress/ayahs", TrackInput{ID: 2}, nil)
+	expectStatus(t, rec, http.StatusConflict)
+
+	for _, id := range []int{1, 2} {
+		rec = doRequest(t, h, http.MethodPut, apiPrefix+"/progress/ayahs", TrackInput{ID: id}, nil)
+		expectStatus(t, rec, http.StatusOK)
+	}
+
+	rec = doRequest(t, h, http.MethodPut, apiPrefix+"/progress/ayahs", TrackInput{ID: 3}, nil)
+	expectStatus(t, rec, http.StatusConflict)
+
+	var progress AyahProgress
+	doRequest(t, h, http.MethodGet, apiPrefix+"/progress/ayahs", nil, &progress)
+	if progress != (AyahProgress{LastAyah: 2, Surah: 1, Ayah: 2}) {
+		t.Fatalf("unexpected progress: %+v", progress)
+	}
+
+	// Without page, the page of the next ayah is served
+	rec = doRequest(t, h, http.MethodGet, apiPrefix+"/surahs/1/ayah-quiz", nil, &page)
+	expectStatus(t, rec, http.StatusOK)
+
+	if page.CurrentPage != 2 || len(page.Ayahs) != 1 || page.Ayahs[0].ID != 3 || !page.Disabled {
+		t.Fatalf("unexpected page: %+v", page)
+	}
+}
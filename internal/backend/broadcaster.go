@@ -0,0 +1,69 @@
+package backend
+
+import "sync"
+
+// progressBroadcaster sends the latest progress to every subscriber, so all
+// sessions that opened in different devices stay consistent.
+type progressBroadcaster struct {
+	sync.Mutex
+	closed      bool
+	subscribers map[chan Progress]struct{}
+}
+
+func newProgressBroadcaster() *progressBroadcaster {
+	return &progressBroadcaster{subscribers: map[chan Progress]struct{}{}}
+}
+
+// Subscribe registers a new subscriber. The returned channel will be closed
+// when subscriber unsubscribed or broadcaster is closed.
+func (b *progressBroadcaster) Subscribe() chan Progress {
+	b.Lock()
+	defer b.Unlock()
+
+	ch := make(chan Progress, 1)
+	if b.closed {
+		close(ch)
+		return ch
+	}
+
+	b.subscribers[ch] = struct{}{}
+	return ch
+}
+
+// Unsubscribe removes the subscriber and closes its channel.
+func (b *progressBroadcaster) Unsubscribe(ch chan Progress) {
+	b.Lock()
+	defer b.Unlock()
+
+	if _, exist := b.subscribers[ch]; exist {
+		delete(b.subscribers, ch)
+		close(ch)
+	}
+}
+
+// Publish sends the progress to all subscribers. Slow subscriber that hasn't
+// received the previous progress will only get the latest one.
+func (b *progressBroadcaster) Publish(progress Progress) {
+	b.Lock()
+	defer b.Unlock()
+
+	for ch := range b.subscribers {
+		select {
+		case <-ch:
+		default:
+		}
+		ch <- progress
+	}
+}
+
+// Close closes all subscribers, e.g. when server is shutting down.
+func (b *progressBroadcaster) Close() {
+	b.Lock()
+	defer b.Unlock()
+
+	b.closed = true
+	for ch := range b.subscribers {
+		delete(b.subscribers, ch)
+		close(ch)
+	}
+}
@@ -0,0 +1,217 @@
+package backend
+
+import (
+	"kalimah/internal/store"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// Source of the choice texts, i.e. the text of word that used as the choices.
+const (
+	choiceTranslation = "translation"
+	choiceArabic      = "arabic"
+)
+
+const (
+	// DifficultyEasy is for new words or words that often answered wrongly.
+	DifficultyEasy = "easy"
+
+	// DifficultyNormal is for words that still being learned, or every
+	// word when the choice policy is fixed.
+	DifficultyNormal = "normal"
+
+	// DifficultyHard is for well-known words, which given more choices.
+	DifficultyHard = "hard"
+)
+
+const (
+	// masteredAttempts and masteredAccuracy are the minimum attempts and
+	// accuracy for a word to be considered as well-known.
+	masteredAttempts = 5
+	masteredAccuracy = 0.9
+
+	// strugglingAccuracy is the accuracy below which a word is considered
+	// as hard for learner, so it's given fewer choices.
+	strugglingAccuracy = 0.6
+)
+
+// seedSamplingRounds is the max rounds of sampling the seeded choice candidates.
+const seedSamplingRounds = 5
+
+// ChoicePolicy decides the number of choices for each word. When it's not adaptive,
+// every word uses the server's ChoiceCount. Otherwise the count depends on the
+// past accuracy of the word: NewCount for new or struggling words, ChoiceCount for
+// words still being learned, and MasteredCount for well-known words.
+type ChoicePolicy struct {
+	Adaptive      bool
+	NewCount      int
+	MasteredCount int
+}
+
+// applyWordChoices generates the choices for each word, with the number of
+// choices and its difficulty decided by the choice policy. The choices are
+// derived from seed, so the same seed always gives the same choices.
+func (s *Server) applyWordChoices(st store.Store, words []Word, seed uint64) error {
+	if len(words) == 0 {
+		return nil
+	}
+
+	nChoices := s.ChoiceCount
+	if nChoices <= 0 {
+		nChoices = 8
+	}
+
+	// Decide the difficulty of each word
+	policy := s.ChoicePolicy
+	counts := make([]int, len(words))
+	for i := range words {
+		words[i].Difficulty = DifficultyNormal
+		counts[i] = nChoices
+	}
+
+	if policy.Adaptive {
+		ids := make([]int, len(words))
+		for i, word := range words {
+			ids[i] = word.ID
+		}
+
+		accuracies, err := st.AnswerStats(ids)
+		if err != nil {
+			return err
+		}
+
+		for i, word := range words {
+			words[i].Difficulty = wordDifficulty(accuracies[word.ID])
+			switch words[i].Difficulty {
+			case DifficultyEasy:
+				counts[i] = policy.NewCount
+			case DifficultyHard:
+				counts[i] = policy.MasteredCount
+			}
+		}
+	}
+
+	queryStart := time.Now()
+	err := applySeededChoices(st, words, counts, seed, choiceTranslation)
+	s.observeQuery("choice_candidates", queryStart)
+	return err
+}
+
+// applySeededChoices generates the choices for each word using the random generator
+// derived from seed and the word ID, so the same seed always gives the same choices.
+// The choices are taken from the source text, where the incorrect ones are drawn
+// from words at random IDs, which sampled in few rounds until each word has enough
+// distinct candidates. When there are not enough candidates, the word simply gets
+// fewer choices.
+func applySeededChoices(st store.WordStore, words []Word, counts []int, seed uint64, source string) error {
+	maxWordID, err := st.LastWordID()
+	if err != nil {
+		return err
+	}
+
+	// Prepare the state of each word
+	rands := make([]*rand.Rand, len(words))
+	distractors := make([][]string, len(words))
+	used := make([]map[string]struct{}, len(words))
+	answers := make([]string, len(words))
+	for i, word := range words {
+		answers[i] = word.Translation
+		if source == choiceArabic {
+			answers[i] = word.Arabic
+		}
+
+		rands[i] = wordRand(seed, word.ID)
+		distractors[i] = []string{}
+		used[i] = map[string]struct{}{answers[i]: {}}
+	}
+
+	for round := 0; round < seedSamplingRounds && maxWordID > 0; round++ {
+		// Sample the candidate IDs for words that still lacking of choices
+		samples := make([][]int, len(words))
+		var sampledIDs []int
+		for i := range words {
+			needed := counts[i] - 1 - len(distractors[i])
+			for j := 0; j < needed*3; j++ {
+				id := rands[i].Intn(maxWordID) + 1
+				samples[i] = append(samples[i], id)
+				sampledIDs = append(sampledIDs, id)
+			}
+		}
+
+		if len(sampledIDs) == 0 {
+			break
+		}
+
+		texts, err := fetchChoiceTexts(st, sampledIDs, source)
+		if err != nil {
+			return err
+		}
+
+		// Pick the distinct candidates in the sampled order
+		for i := range words {
+			for _, id := range samples[i] {
+				if len(distractors[i]) >= counts[i]-1 {
+					break
+				}
+
+				text, exist := texts[id]
+				if _, isUsed := used[i][text]; !exist || isUsed {
+					continue
+				}
+
+				used[i][text] = struct{}{}
+				distractors[i] = append(distractors[i], text)
+			}
+		}
+	}
+
+	for i := range words {
+		choices := []Choice{{Text: answers[i], IsCorrect: true}}
+		for _, distractor := range distractors[i] {
+			choices = append(choices, Choice{Text: distractor, IsCorrect: false})
+		}
+
+		sort.Slice(choices, func(a, b int) bool {
+			return choices[a].Text < choices[b].Text
+		})
+		words[i].Choices = choices
+	}
+
+	return nil
+}
+
+// fetchChoiceTexts fetches the source text of words, mapped by its ID.
+func fetchChoiceTexts(st store.WordStore, ids []int, source string) (map[int]string, error) {
+	words, err := st.WordsByID(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	texts := make(map[int]string, len(words))
+	for _, word := range words {
+		texts[word.ID] = word.Translation
+		if source == choiceArabic {
+			texts[word.ID] = word.Arabic
+		}
+	}
+
+	return texts, nil
+}
+
+// wordDifficulty decides the difficulty of a word from its answer history.
+func wordDifficulty(accuracy store.AnswerStats) string {
+	if accuracy.Attempts == 0 {
+		return DifficultyEasy
+	}
+
+	ratio := float64(accuracy.Correct) / float64(accuracy.Attempts)
+	switch {
+	case ratio < strugglingAccuracy:
+		return DifficultyEasy
+	case accuracy.Attempts >= masteredAttempts && ratio >= masteredAccuracy:
+		return DifficultyHard
+	default:
+		return DifficultyNormal
+	}
+}
@@ -0,0 +1,220 @@
+package backend
+
+import (
+	"encoding/json"
+	"kalimah/internal/store"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// maxClozeBlanks is the max number of blanks within a cloze question.
+const maxClozeBlanks = 5
+
+// GetCloze returns the translation of an ayah with the gloss of one or more of its
+// words blanked out, along with the choices to fill each blank. The choices use the
+// same distractors as in GetWords, so the words are practiced in sentence context.
+func (s *Server) GetCloze(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	var err error
+	defer func() {
+		if err != nil {
+			writeError(w, r, err)
+		}
+	}()
+
+	// Parse parameter
+	surah, err := parseSurah(ps.ByName("surah"))
+	if err != nil {
+		return
+	}
+
+	ayah, err := parseAyah(s.Store, surah, ps.ByName("ayah"))
+	if err != nil {
+		return
+	}
+
+	nBlanks := 1
+	if blanksParam := r.URL.Query().Get("blanks"); blanksParam != "" {
+		nBlanks, err = strconv.Atoi(blanksParam)
+		if err != nil || nBlanks < 1 || nBlanks > maxClozeBlanks {
+			err = badRequest("blanks must be a number between 1 and %d, got %q", maxClozeBlanks, blanksParam)
+			return
+		}
+	}
+
+	// Decide the seed of blanks and choices
+	seed, err := s.choiceSeed(r, time.Now())
+	if err != nil {
+		return
+	}
+
+	// Fetch the ayah and its words
+	surahData, err := s.Store.Surah(surah)
+	if err != nil {
+		return
+	}
+
+	ayahData, err := s.Store.Ayah(surahData.Start + ayah - 1)
+	if err != nil {
+		return
+	}
+
+	ayahWords, err := s.Store.AyahWords(ayahData.ID)
+	if err != nil {
+		return
+	}
+
+	translation := ayahData.Translation
+	words := locateWords([]store.Surah{surahData}, ayahWords)
+	question := ClozeQuestion{AyahID: ayahData.ID, Surah: surah, Ayah: ayah}
+	for i, word := range words {
+		if i > 0 {
+			question.Arabic += " "
+		}
+		question.Arabic += word.Arabic
+	}
+
+	// Find the gloss of each word within the ayah translation, then pick the blanks
+	spans := alignGlosses(translation, words)
+	if len(spans) == 0 {
+		err = unprocessable("translation of ayah %d:%d can't be aligned with its words", surah, ayah)
+		return
+	}
+
+	ayahRand(seed, question.AyahID).Shuffle(len(spans), func(i, j int) {
+		spans[i], spans[j] = spans[j], spans[i]
+	})
+
+	if len(spans) > nBlanks {
+		spans = spans[:nBlanks]
+	}
+
+	sort.Slice(spans, func(i, j int) bool {
+		return spans[i].Start < spans[j].Start
+	})
+
+	// Split the translation into text and blank segments
+	runes := []rune(translation)
+	cursor := 0
+	question.Blanks = make([]Word, len(spans))
+	for i, span := range spans {
+		if span.Start > cursor {
+			question.Segments = append(question.Segments, ClozeSegment{Text: string(runes[cursor:span.Start])})
+		}
+
+		blank := i
+		question.Segments = append(question.Segments, ClozeSegment{Blank: &blank})
+		question.Blanks[i] = words[span.Word]
+		question.Blanks[i].Ayah = question.Ayah
+		cursor = span.End
+	}
+
+	if cursor < len(runes) {
+		question.Segments = append(question.Segments, ClozeSegment{Text: string(runes[cursor:])})
+	}
+
+	// Generate the choices for each blank
+	nChoices := s.ChoiceCount
+	if nChoices <= 0 {
+		nChoices = 8
+	}
+
+	counts := make([]int, len(question.Blanks))
+	for i := range counts {
+		counts[i] = nChoices
+	}
+
+	queryStart := time.Now()
+	err = applySeededChoices(s.Store, question.Blanks, counts, seed, choiceTranslation)
+	s.observeQuery("choice_candidates", queryStart)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(&question)
+}
+
+// glossSpan is the location of a word gloss within the ayah translation,
+// in rune offset.
+type glossSpan struct {
+	Word  int
+	Start int
+	End   int
+}
+
+// alignGlosses finds the gloss of each word within the ayah translation, in the
+// order of its position. Gloss only matches a whole phrase, case insensitively,
+// and gloss with alternatives like "khusyuk/merendah" matches either of them.
+func alignGlosses(translation string, words []Word) []glossSpan {
+	text := []rune(translation)
+	for i := range text {
+		text[i] = unicode.ToLower(text[i])
+	}
+
+	var spans []glossSpan
+	cursor := 0
+	for i, word := range words {
+		alternatives := []string{word.Translation}
+		if strings.Contains(word.Translation, "/") {
+			alternatives = append(alternatives, strings.Split(word.Translation, "/")...)
+		}
+
+		for _, alternative := range alternatives {
+			phrase := []rune(strings.TrimSpace(alternative))
+			for j := range phrase {
+				phrase[j] = unicode.ToLower(phrase[j])
+			}
+
+			if !containsLetter(phrase) {
+				continue
+			}
+
+			if start := indexPhrase(text, phrase, cursor); start >= 0 {
+				spans = append(spans, glossSpan{Word: i, Start: start, End: start + len(phrase)})
+				cursor = start + len(phrase)
+				break
+			}
+		}
+	}
+
+	return spans
+}
+
+// indexPhrase returns the index of the first whole phrase within text, starting
+// from the specified offset, or -1 if it's not found.
+func indexPhrase(text, phrase []rune, offset int) int {
+	for start := offset; start+len(phrase) <= len(text); start++ {
+		end := start + len(phrase)
+		if start > 0 && isWordRune(text[start-1]) && isWordRune(phrase[0]) {
+			continue
+		}
+
+		if end < len(text) && isWordRune(text[end]) && isWordRune(phrase[len(phrase)-1]) {
+			continue
+		}
+
+		if string(text[start:end]) == string(phrase) {
+			return start
+		}
+	}
+	return -1
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func containsLetter(phrase []rune) bool {
+	for _, r := range phrase {
+		if unicode.IsLetter(r) {
+			return true
+		}
+	}
+	return false
+}
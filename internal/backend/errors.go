@@ -0,0 +1,36 @@
+package backend
+
+import (
+	"fmt"
+	"kalimah/internal/backend/middleware"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+)
+
+// writeError logs the error then sends it to client along with the request ID,
+// so user can refer to the related log entry when reporting a problem.
+func writeError(w http.ResponseWriter, r *http.Request, err error) {
+	status := errorStatus(err)
+	requestID := middleware.RequestID(r.Context())
+
+	entry := logrus.WithFields(logrus.Fields{
+		"method":     r.Method,
+		"path":       r.URL.Path,
+		"status":     status,
+		"request_id": requestID,
+	})
+
+	if status >= 500 {
+		entry.Errorln(err)
+	} else {
+		entry.Warnln(err)
+	}
+
+	message := err.Error()
+	if requestID != "" {
+		message = fmt.Sprintf("%s (request id: %s)", message, requestID)
+	}
+
+	http.Error(w, message, status)
+}
@@ -0,0 +1,87 @@
+package backend
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+const (
+	// eventKeepAlive is the interval for sending comment to keep the connection open.
+	eventKeepAlive = 15 * time.Second
+
+	// eventStreamDuration is how long an event stream is kept before client asked to
+	// reconnect. It must be shorter than the write timeout of server.
+	eventStreamDuration = 50 * time.Second
+)
+
+// StreamProgress sends the learning progress as Server-Sent Events, once when
+// connected and then each time the progress is changed.
+func (s *Server) StreamProgress(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	flusher, ok := w.(http.Flusher)
+	if !ok || s.progress == nil {
+		writeError(w, r, fmt.Errorf("streaming is not supported"))
+		return
+	}
+
+	// Subscribe before fetching the current progress, so no change is missed
+	ch := s.progress.Subscribe()
+	defer s.progress.Unsubscribe(ch)
+
+	progress, err := s.fetchProgress()
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	// Start the stream
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	fmt.Fprintf(w, "retry: %d\n\n", time.Second.Milliseconds())
+
+	writeEvent := func(p Progress) error {
+		bt, err := json.Marshal(&p)
+		if err != nil {
+			return err
+		}
+
+		_, err = fmt.Fprintf(w, "event: progress\ndata: %s\n\n", bt)
+		flusher.Flush()
+		return err
+	}
+
+	if err = writeEvent(progress); err != nil {
+		return
+	}
+
+	keepAlive := time.NewTicker(eventKeepAlive)
+	defer keepAlive.Stop()
+
+	streamEnd := time.NewTimer(eventStreamDuration)
+	defer streamEnd.Stop()
+
+	for {
+		select {
+		case p, open := <-ch:
+			if !open {
+				return
+			}
+			if err = writeEvent(p); err != nil {
+				return
+			}
+		case <-keepAlive.C:
+			if _, err = fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		case <-streamEnd.C:
+			return
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
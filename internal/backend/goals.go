@@ -0,0 +1,108 @@
+package backend
+
+import (
+	"encoding/json"
+	"kalimah/internal/store"
+	"net/http"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+const (
+	// maxWordsPerDay is the max daily goal of answered words.
+	maxWordsPerDay = 10000
+
+	// maxMinutesPerDay is the max daily goal of learning time.
+	maxMinutesPerDay = 24 * 60
+)
+
+// GetGoals returns the daily goals along with today's progress toward it.
+func (s *Server) GetGoals(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	var err error
+	defer func() {
+		if err != nil {
+			writeError(w, r, err)
+		}
+	}()
+
+	progress, err := s.fetchGoalProgress(time.Now())
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(&progress)
+}
+
+// UpdateGoals replaces the daily goals. Zero disables the goal.
+func (s *Server) UpdateGoals(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	var err error
+	defer func() {
+		if err != nil {
+			writeError(w, r, err)
+		}
+	}()
+
+	// Decode and validate request
+	var goal Goal
+	err = json.NewDecoder(r.Body).Decode(&goal)
+	if err != nil {
+		err = badRequest("invalid goal: %v", err)
+		return
+	}
+
+	if goal.WordsPerDay < 0 || goal.WordsPerDay > maxWordsPerDay {
+		err = badRequest("words per day must be between 0 and %d, got %d", maxWordsPerDay, goal.WordsPerDay)
+		return
+	}
+
+	if goal.MinutesPerDay < 0 || goal.MinutesPerDay > maxMinutesPerDay {
+		err = badRequest("minutes per day must be between 0 and %d, got %d", maxMinutesPerDay, goal.MinutesPerDay)
+		return
+	}
+
+	// Save the goal
+	err = s.Store.SetGoal(store.Goal{
+		WordsPerDay:   goal.WordsPerDay,
+		MinutesPerDay: goal.MinutesPerDay,
+	})
+	if err != nil {
+		return
+	}
+
+	progress, err := s.fetchGoalProgress(time.Now())
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(&progress)
+}
+
+// fetchGoalProgress fetches the daily goals and the progress within the day of t.
+func (s *Server) fetchGoalProgress(t time.Time) (GoalProgress, error) {
+	goal, err := s.Store.Goal()
+	if err != nil {
+		return GoalProgress{}, err
+	}
+
+	nWords, active, err := s.Store.DailyActivity(t)
+	if err != nil {
+		return GoalProgress{}, err
+	}
+
+	progress := GoalProgress{
+		Goal: Goal{
+			WordsPerDay:   goal.WordsPerDay,
+			MinutesPerDay: goal.MinutesPerDay,
+		},
+		Date:         t.Format("2006-01-02"),
+		WordsToday:   nWords,
+		MinutesToday: int(active / time.Minute),
+	}
+
+	progress.WordsCompleted = goal.WordsPerDay > 0 && progress.WordsToday >= goal.WordsPerDay
+	progress.MinutesCompleted = goal.MinutesPerDay > 0 && progress.MinutesToday >= goal.MinutesPerDay
+	return progress, nil
+}
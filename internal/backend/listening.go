@@ -0,0 +1,216 @@
+package backend
+
+import (
+	"encoding/json"
+	"kalimah/internal/store"
+	"net/http"
+	"os"
+	fp "path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+const (
+	// ListeningMeaning asks learner to choose the meaning of the recited word.
+	ListeningMeaning = "meaning"
+
+	// ListeningArabic asks learner to choose the written form of the recited word.
+	ListeningArabic = "arabic"
+)
+
+// GetListening returns a random word that has recitation audio, along with the choices
+// for either its meaning or its written form. The Arabic text is not included, so the
+// word can only be identified by listening to it. Learned words are picked first.
+func (s *Server) GetListening(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	var err error
+	defer func() {
+		if err != nil {
+			writeError(w, r, err)
+		}
+	}()
+
+	// Parse query
+	query := r.URL.Query()
+	answer := query.Get("answer")
+	switch answer {
+	case "":
+		answer = ListeningMeaning
+	case ListeningMeaning, ListeningArabic:
+	default:
+		err = badRequest("answer must be either %s or %s, got %q", ListeningMeaning, ListeningArabic, answer)
+		return
+	}
+
+	var surah int
+	if surahParam := query.Get("surah"); surahParam != "" {
+		if surah, err = parseSurah(surahParam); err != nil {
+			return
+		}
+	}
+
+	reciter, err := s.resolveReciter(query.Get("reciter"))
+	if err != nil {
+		return
+	}
+
+	// Decide the seed of choices
+	seed, err := s.choiceSeed(r, time.Now())
+	if err != nil {
+		return
+	}
+
+	// Pick the word
+	recited, err := s.Store.RecitedWord(reciter, surah)
+	if err == store.ErrNotFound {
+		err = notFound("reciter %q has no audio for the requested words", reciter)
+		return
+	} else if err != nil {
+		return
+	}
+
+	surahs, err := s.Store.Surahs()
+	if err != nil {
+		return
+	}
+
+	words := locateWords(surahs, []store.Word{recited})
+	question := ListeningQuestion{
+		WordID:   words[0].ID,
+		Surah:    words[0].Surah,
+		Ayah:     words[0].Ayah,
+		Position: words[0].Position,
+	}
+
+	// Prepare the choices, using the answer as the correct one
+	nChoices := s.ChoiceCount
+	if nChoices <= 0 {
+		nChoices = 8
+	}
+
+	source := choiceTranslation
+	if answer == ListeningArabic {
+		source = choiceArabic
+	}
+
+	queryStart := time.Now()
+	err = applySeededChoices(s.Store, words, []int{nChoices}, seed, source)
+	s.observeQuery("choice_candidates", queryStart)
+	if err != nil {
+		return
+	}
+
+	question.Reciter = reciter
+	question.Answer = answer
+	question.Choices = words[0].Choices
+	question.AudioURL = s.BasePath + apiPrefix + "/audio/" + reciter + "/" + strconv.Itoa(question.WordID)
+
+	w.Header().Add("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(&question)
+}
+
+// GetReciters lists the reciters whose audio has been imported.
+func (s *Server) GetReciters(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	var err error
+	defer func() {
+		if err != nil {
+			writeError(w, r, err)
+		}
+	}()
+
+	storeReciters, err := s.Store.Reciters()
+	if err != nil {
+		return
+	}
+
+	reciters := make([]Reciter, len(storeReciters))
+	for i, reciter := range storeReciters {
+		reciters[i] = Reciter(reciter)
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(&reciters)
+}
+
+// ServeAudio serves the recitation audio of a word.
+func (s *Server) ServeAudio(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	var err error
+	defer func() {
+		if err != nil {
+			writeError(w, r, err)
+		}
+	}()
+
+	wordParam := ps.ByName("word")
+	wordID, err := strconv.Atoi(wordParam)
+	if err != nil {
+		err = badRequest("word id must be a number, got %q", wordParam)
+		return
+	}
+
+	reciter, err := s.resolveReciter(ps.ByName("reciter"))
+	if err != nil {
+		return
+	}
+
+	path, err := s.Store.AudioPath(reciter, wordID)
+	if err == store.ErrNotFound {
+		err = notFound("reciter %q has no audio for word %d", reciter, wordID)
+		return
+	} else if err != nil {
+		return
+	}
+
+	f, err := os.Open(fp.Join(s.AudioDir, fp.FromSlash(path)))
+	if os.IsNotExist(err) {
+		err = notFound("audio file for word %d by reciter %q is missing, "+
+			"run `kalimah audio import` again to restore it", wordID, reciter)
+		return
+	} else if err != nil {
+		return
+	}
+	defer f.Close()
+
+	stat, err := f.Stat()
+	if err != nil {
+		return
+	}
+
+	w.Header().Set("Cache-Control", "public, max-age=86400")
+	http.ServeContent(w, r, stat.Name(), stat.ModTime(), f)
+}
+
+// resolveReciter makes sure the reciter has imported audio. If the reciter is
+// not specified, the one with the most words is used.
+func (s *Server) resolveReciter(reciter string) (string, error) {
+	reciters, err := s.Store.Reciters()
+	if err != nil {
+		return "", err
+	}
+
+	if reciter == "" {
+		if len(reciters) == 0 {
+			return "", notFound("no recitation audio has been imported, " +
+				"run `kalimah audio import` to add it")
+		}
+
+		// Reciters are ordered by name, so the first one wins on tie
+		best := reciters[0]
+		for _, r := range reciters[1:] {
+			if r.WordCount > best.WordCount {
+				best = r
+			}
+		}
+		return best.Name, nil
+	}
+
+	for _, r := range reciters {
+		if r.Name == reciter {
+			return reciter, nil
+		}
+	}
+
+	return "", notFound("no audio has been imported for reciter %q, "+
+		"run `kalimah audio import --reciter %s` to add it", reciter, reciter)
+}
@@ -0,0 +1,444 @@
+package backend
+
+import (
+	"encoding/json"
+	"kalimah/internal/store"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// maxListNameLength is the max length of list name in bytes.
+const maxListNameLength = 100
+
+// GetLists lists all word lists along with the number of its bookmarks.
+func (s *Server) GetLists(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	var err error
+	defer func() {
+		if err != nil {
+			writeError(w, r, err)
+		}
+	}()
+
+	storeLists, err := s.Store.Lists()
+	if err != nil {
+		return
+	}
+
+	lists := make([]WordList, len(storeLists))
+	for i, list := range storeLists {
+		lists[i] = newWordList(list)
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(&lists)
+}
+
+// GetList returns a word list along with its bookmarks.
+func (s *Server) GetList(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	var err error
+	defer func() {
+		if err != nil {
+			writeError(w, r, err)
+		}
+	}()
+
+	list, err := fetchList(s.Store, ps.ByName("list"))
+	if err != nil {
+		return
+	}
+
+	list.Bookmarks, err = fetchBookmarks(s.Store, list.ID)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(&list)
+}
+
+// CreateList creates a new, empty word list.
+func (s *Server) CreateList(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	var err error
+	defer func() {
+		if err != nil {
+			writeError(w, r, err)
+		}
+	}()
+
+	// Decode and validate request
+	var input WordListInput
+	err = json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		err = badRequest("invalid list: %v", err)
+		return
+	}
+
+	name, err := validateListName(s.Store, input.Name, 0)
+	if err != nil {
+		return
+	}
+
+	// Save the list
+	id, err := s.Store.CreateList(name, time.Now())
+	if err != nil {
+		return
+	}
+
+	list, err := fetchList(s.Store, strconv.Itoa(id))
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	err = json.NewEncoder(w).Encode(&list)
+}
+
+// RenameList changes the name of a word list.
+func (s *Server) RenameList(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	var err error
+	defer func() {
+		if err != nil {
+			writeError(w, r, err)
+		}
+	}()
+
+	// Decode and validate request
+	var input WordListInput
+	err = json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		err = badRequest("invalid list: %v", err)
+		return
+	}
+
+	list, err := fetchList(s.Store, ps.ByName("list"))
+	if err != nil {
+		return
+	}
+
+	name, err := validateListName(s.Store, input.Name, list.ID)
+	if err != nil {
+		return
+	}
+
+	// Save the changes
+	err = s.Store.RenameList(list.ID, name)
+	if err != nil {
+		return
+	}
+
+	list.Name = name
+	w.Header().Add("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(&list)
+}
+
+// DeleteList removes a word list along with its bookmarks.
+func (s *Server) DeleteList(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	var err error
+	defer func() {
+		if err != nil {
+			writeError(w, r, err)
+		}
+	}()
+
+	list, err := fetchList(s.Store, ps.ByName("list"))
+	if err != nil {
+		return
+	}
+
+	err = s.Store.DeleteList(list.ID)
+	if err != nil {
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// AddBookmark adds a word or an ayah into a word list.
+func (s *Server) AddBookmark(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	var err error
+	defer func() {
+		if err != nil {
+			writeError(w, r, err)
+		}
+	}()
+
+	// Decode and validate request
+	var input BookmarkInput
+	err = json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		err = badRequest("invalid bookmark: %v", err)
+		return
+	}
+
+	list, err := fetchList(s.Store, ps.ByName("list"))
+	if err != nil {
+		return
+	}
+
+	if err = validateTarget(s.Store, "bookmark", input.WordID, input.AyahID); err != nil {
+		return
+	}
+
+	// Make sure it's not bookmarked yet
+	bookmarks, err := s.Store.Bookmarks(list.ID)
+	if err != nil {
+		return
+	}
+
+	for _, bookmark := range bookmarks {
+		if sameID(bookmark.Word, input.WordID) || sameID(bookmark.Ayah, input.AyahID) {
+			err = conflict("it's already bookmarked in list %q", list.Name)
+			return
+		}
+	}
+
+	// Save the bookmark
+	id, err := s.Store.AddBookmark(list.ID, input.WordID, input.AyahID, time.Now())
+	if err != nil {
+		return
+	}
+
+	bookmark, err := s.Store.Bookmark(list.ID, id)
+	if err != nil {
+		return
+	}
+
+	data := newBookmark(bookmark)
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	err = json.NewEncoder(w).Encode(&data)
+}
+
+// RemoveBookmark removes a bookmark from its word list.
+func (s *Server) RemoveBookmark(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	var err error
+	defer func() {
+		if err != nil {
+			writeError(w, r, err)
+		}
+	}()
+
+	list, err := fetchList(s.Store, ps.ByName("list"))
+	if err != nil {
+		return
+	}
+
+	bookmarkParam := ps.ByName("bookmark")
+	bookmarkID, err := strconv.Atoi(bookmarkParam)
+	if err != nil {
+		err = badRequest("bookmark id must be a number, got %q", bookmarkParam)
+		return
+	}
+
+	err = s.Store.RemoveBookmark(list.ID, bookmarkID)
+	if err == store.ErrNotFound {
+		err = notFound("bookmark %d not exist in list %q", bookmarkID, list.Name)
+		return
+	} else if err != nil {
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// getListWords serves a page of words for quiz that drawn from the word list
+// instead of the surah. Bookmarked ayah is expanded into all of its words, and
+// the answers are not tracked so all words are always enabled. If surah is not
+// zero, only the words within the surah are used.
+func (s *Server) getListWords(w http.ResponseWriter, r *http.Request, listParam string, surah int) {
+	var err error
+	defer func() {
+		if err != nil {
+			writeError(w, r, err)
+		}
+	}()
+
+	// Decide the seed of choices
+	seed, err := s.choiceSeed(r, time.Now())
+	if err != nil {
+		return
+	}
+
+	list, err := fetchList(s.Store, listParam)
+	if err != nil {
+		return
+	}
+
+	// Fetch all words within the list
+	queryStart := time.Now()
+	listWords, err := s.Store.ListWords(list.ID)
+	s.observeQuery("list_words", queryStart)
+	if err != nil {
+		return
+	}
+
+	surahs, err := s.Store.Surahs()
+	if err != nil {
+		return
+	}
+
+	words := []Word{}
+	for _, word := range locateWords(surahs, listWords) {
+		if surah == 0 || word.Surah == surah {
+			words = append(words, word)
+		}
+	}
+
+	// Paginate by ayah, the same way as the words in surah
+	nAyahPerPage := s.AyahPerPage
+	if nAyahPerPage <= 0 {
+		nAyahPerPage = 30
+	}
+
+	var ayahStarts []int
+	for i, word := range words {
+		if i == 0 || word.Surah != words[i-1].Surah || word.Ayah != words[i-1].Ayah {
+			ayahStarts = append(ayahStarts, i)
+		}
+	}
+
+	maxPage := int(math.Ceil(float64(len(ayahStarts)) / float64(nAyahPerPage)))
+	if maxPage == 0 {
+		maxPage = 1
+	}
+
+	pageParam := r.URL.Query().Get("page")
+	if pageParam == "" {
+		pageParam = "0"
+	}
+
+	page, err := parsePage(pageParam, maxPage)
+	if err != nil {
+		return
+	}
+
+	if page == 0 {
+		page = 1
+	}
+
+	firstAyah := (page - 1) * nAyahPerPage
+	lastAyah := firstAyah + nAyahPerPage
+	if firstAyah < len(ayahStarts) {
+		start, end := ayahStarts[firstAyah], len(words)
+		if lastAyah < len(ayahStarts) {
+			end = ayahStarts[lastAyah]
+		}
+		words = words[start:end]
+	}
+
+	// Mark the end of each ayah
+	for i := range words {
+		last := i == len(words)-1
+		words[i].IsSeparator = last || words[i].Ayah != words[i+1].Ayah || words[i].Surah != words[i+1].Surah
+	}
+
+	// Apply choice to each word
+	if err = s.applyWordChoices(s.Store, words, seed); err != nil {
+		return
+	}
+
+	// Create return data
+	data := WordPage{
+		CurrentPage: page,
+		MaxPage:     maxPage,
+		Words:       words,
+		Disabled:    len(words) == 0,
+		Seed:        seed,
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(&data)
+}
+
+// fetchList fetches a word list by its ID, along with the number of its bookmarks.
+func fetchList(st store.ListStore, param string) (WordList, error) {
+	id, err := strconv.Atoi(param)
+	if err != nil {
+		return WordList{}, badRequest("list id must be a number, got %q", param)
+	}
+
+	list, err := st.List(id)
+	if err == store.ErrNotFound {
+		return WordList{}, notFound("list %d not exist", id)
+	} else if err != nil {
+		return WordList{}, err
+	}
+
+	return newWordList(list), nil
+}
+
+// fetchBookmarks fetches bookmarks within the word list along with the location
+// and Arabic text of the bookmarked word or ayah.
+func fetchBookmarks(st store.ListStore, listID int) ([]Bookmark, error) {
+	storeBookmarks, err := st.Bookmarks(listID)
+	if err != nil {
+		return nil, err
+	}
+
+	bookmarks := make([]Bookmark, len(storeBookmarks))
+	for i, bookmark := range storeBookmarks {
+		bookmarks[i] = newBookmark(bookmark)
+	}
+	return bookmarks, nil
+}
+
+// validateListName makes sure the list name is not empty and not used by the other list.
+func validateListName(st store.ListStore, name string, listID int) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", badRequest("list name must not be empty")
+	}
+
+	if len(name) > maxListNameLength {
+		return "", badRequest("list name must not exceed %d bytes", maxListNameLength)
+	}
+
+	lists, err := st.Lists()
+	if err != nil {
+		return "", err
+	}
+
+	for _, list := range lists {
+		if list.Name == name && list.ID != listID {
+			return "", conflict("list %q already exists", name)
+		}
+	}
+
+	return name, nil
+}
+
+// newWordList converts the word list into API word list.
+func newWordList(list store.List) WordList {
+	return WordList{
+		ID:        list.ID,
+		Name:      list.Name,
+		WordCount: list.WordCount,
+		AyahCount: list.AyahCount,
+		CreatedAt: list.CreatedAt,
+	}
+}
+
+// newBookmark converts the bookmark into API bookmark.
+func newBookmark(bookmark store.Bookmark) Bookmark {
+	return Bookmark{
+		ID:        bookmark.ID,
+		ListID:    bookmark.List,
+		WordID:    bookmark.Word,
+		AyahID:    bookmark.Ayah,
+		Surah:     bookmark.Surah,
+		Ayah:      bookmark.SurahAyah,
+		Arabic:    bookmark.Arabic,
+		CreatedAt: bookmark.CreatedAt,
+	}
+}
+
+// sameID checks whether both optional IDs exist and have the same value.
+func sameID(a, b *int) bool {
+	return a != nil && b != nil && *a == *b
+}
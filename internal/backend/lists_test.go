@@ -0,0 +1,106 @@
+package backend
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestLists(t *testing.T) {
+	_, h := newTestServer(t)
+
+	// Create the lists, where the name must be unique
+	var list WordList
+	rec := doRequest(t, h, http.MethodPost, apiPrefix+"/lists", WordListInput{Name: " Hard "}, &list)
+	expectStatus(t, rec, http.StatusCreated)
+
+	if list.ID == 0 || list.Name != "Hard" {
+		t.Fatalf("unexpected list: %+v", list)
+	}
+
+	rec = doRequest(t, h, http.MethodPost, apiPrefix+"/lists", WordListInput{Name: "Hard"}, nil)
+	expectStatus(t, rec, http.StatusConflict)
+
+	var other WordList
+	rec = doRequest(t, h, http.MethodPost, apiPrefix+"/lists", WordListInput{Name: "Easy"}, &other)
+	expectStatus(t, rec, http.StatusCreated)
+
+	otherURL := fmt.Sprintf("%s/lists/%d", apiPrefix, other.ID)
+	rec = doRequest(t, h, http.MethodPut, otherURL, WordListInput{Name: "Hard"}, nil)
+	expectStatus(t, rec, http.StatusConflict)
+
+	rec = doRequest(t, h, http.MethodPut, otherURL, WordListInput{Name: "Later"}, &other)
+	expectStatus(t, rec, http.StatusOK)
+
+	if other.Name != "Later" {
+		t.Fatalf("list is not renamed: %+v", other)
+	}
+
+	// Bookmark a word and an ayah, each only once
+	listURL := fmt.Sprintf("%s/lists/%d", apiPrefix, list.ID)
+	wordID, ayahID, missingID := 7, 2, 99
+
+	var bookmark Bookmark
+	rec = doRequest(t, h, http.MethodPost, listURL+"/bookmarks", BookmarkInput{WordID: &wordID}, &bookmark)
+	expectStatus(t, rec, http.StatusCreated)
+
+	if bookmark.Surah != 2 || bookmark.Ayah != 2 || bookmark.Arabic != "ذَٰلِكَ" {
+		t.Fatalf("unexpected bookmark: %+v", bookmark)
+	}
+
+	rec = doRequest(t, h, http.MethodPost, listURL+"/bookmarks", BookmarkInput{WordID: &wordID}, nil)
+	expectStatus(t, rec, http.StatusConflict)
+
+	rec = doRequest(t, h, http.MethodPost, listURL+"/bookmarks", BookmarkInput{AyahID: &ayahID}, nil)
+	expectStatus(t, rec, http.StatusCreated)
+
+	rec = doRequest(t, h, http.MethodPost, listURL+"/bookmarks", BookmarkInput{WordID: &missingID}, nil)
+	expectStatus(t, rec, http.StatusBadRequest)
+
+	rec = doRequest(t, h, http.MethodGet, listURL, nil, &list)
+	expectStatus(t, rec, http.StatusOK)
+
+	if list.WordCount != 1 || list.AyahCount != 1 || len(list.Bookmarks) != 2 {
+		t.Fatalf("unexpected list: %+v", list)
+	}
+
+	// Words of the list include the words of the bookmarked ayah
+	var page WordPage
+	rec = doRequest(t, h, http.MethodGet, listURL+"/words", nil, &page)
+	expectStatus(t, rec, http.StatusOK)
+
+	var ids []int
+	for _, word := range page.Words {
+		ids = append(ids, word.ID)
+	}
+
+	if fmt.Sprint(ids) != "[3 4 7]" {
+		t.Fatalf("unexpected words in list: %v", ids)
+	}
+
+	rec = doRequest(t, h, http.MethodGet, fmt.Sprintf("%s/surahs/2/words?list=%d", apiPrefix, list.ID), nil, &page)
+	expectStatus(t, rec, http.StatusOK)
+
+	if len(page.Words) != 1 || page.Words[0].ID != 7 {
+		t.Fatalf("unexpected words in surah 2: %+v", page.Words)
+	}
+
+	// Remove bookmark, then the list
+	bookmarkURL := fmt.Sprintf("%s/bookmarks/%d", listURL, bookmark.ID)
+	rec = doRequest(t, h, http.MethodDelete, bookmarkURL, nil, nil)
+	expectStatus(t, rec, http.StatusNoContent)
+
+	rec = doRequest(t, h, http.MethodDelete, bookmarkURL, nil, nil)
+	expectStatus(t, rec, http.StatusNotFound)
+
+	rec = doRequest(t, h, http.MethodDelete, listURL, nil, nil)
+	expectStatus(t, rec, http.StatusNoContent)
+
+	var lists []WordList
+	rec = doRequest(t, h, http.MethodGet, apiPrefix+"/lists", nil, &lists)
+	expectStatus(t, rec, http.StatusOK)
+
+	if len(lists) != 1 || lists[0].Name != "Later" {
+		t.Fatalf("unexpected lists: %+v", lists)
+	}
+}
@@ -0,0 +1,78 @@
+package backend
+
+import (
+	"context"
+	"encoding/json"
+	"kalimah/internal/metrics"
+	"net/http"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// serverMetrics is the application metrics that recorded by handlers.
+type serverMetrics struct {
+	registry       *metrics.Registry
+	queryDurations *metrics.HistogramVec
+	answers        *metrics.CounterVec
+}
+
+func newServerMetrics() *serverMetrics {
+	registry := metrics.NewRegistry()
+	return &serverMetrics{
+		registry: registry,
+		queryDurations: registry.NewHistogramVec("kalimah_db_query_duration_seconds",
+			"Duration of the heavy database queries.", nil, "query"),
+		answers: registry.NewCounterVec("kalimah_answers_submitted_total",
+			"Number of answers that submitted and tracked.", "status"),
+	}
+}
+
+// observeQuery records the duration of query since the start time.
+func (s *Server) observeQuery(name string, start time.Time) {
+	if s.metrics != nil {
+		s.metrics.queryDurations.Observe(time.Since(start).Seconds(), name)
+	}
+}
+
+// countAnswer records a submitted answer along with its status.
+func (s *Server) countAnswer(status string) {
+	if s.metrics != nil {
+		s.metrics.answers.Inc(status)
+	}
+}
+
+// ServeMetrics serves the metrics in Prometheus text format.
+func (s *Server) ServeMetrics(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	if s.metrics == nil {
+		http.NotFound(w, r)
+		return
+	}
+	s.metrics.registry.ServeHTTP(w, r)
+}
+
+// ServeHealth reports whether the server and its database is usable.
+func (s *Server) ServeHealth(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	data := struct {
+		Status        string `json:"status"`
+		SchemaVersion int    `json:"schemaVersion"`
+		Error         string `json:"error,omitempty"`
+	}{Status: "ok"}
+
+	status := http.StatusOK
+	var err error
+	data.SchemaVersion, err = s.Store.Ping(ctx)
+	if err != nil {
+		status = http.StatusServiceUnavailable
+		data.Status = "unavailable"
+		data.Error = err.Error()
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(&data)
+}
@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// BasePath is middleware to serve the handler under a URL sub-path, e.g. when
+// the app is hosted behind reverse proxy. The sub-path will be stripped from
+// the URL before the request passed to the handler.
+type BasePath struct {
+	Handler http.Handler
+	Path    string
+}
+
+func NewBasePath(handler http.Handler, path string) http.Handler {
+	return &BasePath{handler, path}
+}
+
+func (b *BasePath) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// If base path is empty, serve as it is
+	if b.Path == "" {
+		b.Handler.ServeHTTP(w, r)
+		return
+	}
+
+	// Make sure the root of sub-path is accessed with trailing slash,
+	// so the relative URLs in page works properly
+	if r.URL.Path == b.Path {
+		target := b.Path + "/"
+		if r.URL.RawQuery != "" {
+			target += "?" + r.URL.RawQuery
+		}
+		http.Redirect(w, r, target, http.StatusMovedPermanently)
+		return
+	}
+
+	// Request outside of base path is not served
+	if !strings.HasPrefix(r.URL.Path, b.Path+"/") {
+		http.NotFound(w, r)
+		return
+	}
+
+	// Strip the base path from URL
+	r2 := new(http.Request)
+	*r2 = *r
+	r2.URL = new(url.URL)
+	*r2.URL = *r.URL
+	r2.URL.Path = strings.TrimPrefix(r.URL.Path, b.Path)
+	r2.URL.RawPath = ""
+	b.Handler.ServeHTTP(w, r2)
+}
@@ -6,7 +6,15 @@ import (
 	"github.com/NYTimes/gziphandler"
 )
 
-// NewGzipper returns a middleware to GZip all of HTTP response.
+// NewGzipper returns a middleware to GZip all of HTTP response, except for
+// event stream which must be sent to client immediately.
 func NewGzipper(handler http.Handler) http.Handler {
-	return gziphandler.GzipHandler(handler)
+	gzipHandler := gziphandler.GzipHandler(handler)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept") == "text/event-stream" {
+			handler.ServeHTTP(w, r)
+			return
+		}
+		gzipHandler.ServeHTTP(w, r)
+	})
 }
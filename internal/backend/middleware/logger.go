@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+type contextKey string
+
+const requestIDKey contextKey = "request-id"
+
+var rxRequestID = regexp.MustCompile(`^[\w.-]{1,64}$`)
+
+// Logger is middleware to log each HTTP request. Each request is given an ID,
+// either taken from `X-Request-ID` header or generated randomly, which sent
+// back to client in the same header and can be fetched using `RequestID`.
+type Logger struct {
+	Handler http.Handler
+}
+
+func NewLogger(handler http.Handler) http.Handler {
+	return &Logger{handler}
+}
+
+func (l *Logger) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// Prepare request ID
+	requestID := r.Header.Get("X-Request-ID")
+	if !rxRequestID.MatchString(requestID) {
+		requestID = newRequestID()
+	}
+
+	w.Header().Set("X-Request-ID", requestID)
+	r = r.WithContext(context.WithValue(r.Context(), requestIDKey, requestID))
+
+	// Serve the request
+	start := time.Now()
+	recorder := newResponseRecorder(w)
+	l.Handler.ServeHTTP(recorder, r)
+
+	// Log the request
+	user := "-"
+	if username, _, ok := r.BasicAuth(); ok {
+		user = username
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"method":     r.Method,
+		"path":       r.URL.Path,
+		"status":     recorder.Status,
+		"bytes":      recorder.Bytes,
+		"duration":   time.Since(start).String(),
+		"request_id": requestID,
+		"remote":     r.RemoteAddr,
+		"user":       user,
+	}).Info("request served")
+}
+
+// RequestID returns the ID of request that set by Logger.
+func RequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey).(string)
+	return requestID
+}
+
+func newRequestID() string {
+	bt := make([]byte, 8)
+	rand.Read(bt)
+	return hex.EncodeToString(bt)
+}
@@ -0,0 +1,135 @@
+package middleware
+
+import (
+	"context"
+	"kalimah/internal/metrics"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// sessionTimeout is the max idle duration before a client no longer counted as active.
+const sessionTimeout = 5 * time.Minute
+
+// maxSessions is the max number of clients that tracked at once, so a flood of
+// distinct clients can't grow the session map without limit.
+const maxSessions = 10000
+
+// unmatchedRoute is the route label for request that not handled by any route.
+const unmatchedRoute = "unmatched"
+
+type routeKey struct{}
+
+// Metrics is middleware to record the count and latency of HTTP requests, along
+// with the number of active sessions. Since kalimah doesn't have login, each
+// client is identified by its IP address and user agent.
+type Metrics struct {
+	Handler http.Handler
+
+	requests  *metrics.CounterVec
+	durations *metrics.HistogramVec
+
+	sync.Mutex
+	sessions  map[string]time.Time
+	lastPrune time.Time
+}
+
+func NewMetrics(handler http.Handler, registry *metrics.Registry) http.Handler {
+	m := &Metrics{
+		Handler:  handler,
+		sessions: map[string]time.Time{},
+	}
+
+	m.requests = registry.NewCounterVec("kalimah_http_requests_total",
+		"Number of HTTP requests.", "method", "route", "status")
+	m.durations = registry.NewHistogramVec("kalimah_http_request_duration_seconds",
+		"Latency of HTTP requests.", nil, "method", "route")
+	registry.NewGaugeFunc("kalimah_active_sessions",
+		"Number of clients which active in the last 5 minutes.", m.countSessions)
+
+	return m
+}
+
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// The matched route will be reported back by the handle, see WithRoute
+	route := unmatchedRoute
+	r = r.WithContext(context.WithValue(r.Context(), routeKey{}, &route))
+
+	start := time.Now()
+	recorder := newResponseRecorder(w)
+	m.Handler.ServeHTTP(recorder, r)
+	duration := time.Since(start)
+
+	// Record the request
+	m.requests.Inc(r.Method, route, strconv.Itoa(recorder.Status))
+	m.durations.Observe(duration.Seconds(), r.Method, route)
+
+	// Mark the client as active
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+
+	m.Lock()
+	defer m.Unlock()
+
+	// Prune periodically, since the metrics might never be scraped
+	now := time.Now()
+	if now.Sub(m.lastPrune) > time.Minute {
+		m.pruneSessions(now)
+	}
+
+	key := host + " " + r.UserAgent()
+	if _, exist := m.sessions[key]; !exist && len(m.sessions) >= maxSessions {
+		m.evictOldestSession()
+	}
+	m.sessions[key] = now
+}
+
+func (m *Metrics) countSessions() float64 {
+	m.Lock()
+	defer m.Unlock()
+
+	m.pruneSessions(time.Now())
+	return float64(len(m.sessions))
+}
+
+// pruneSessions removes the clients that idle for longer than session timeout.
+// Must be called while holding the lock.
+func (m *Metrics) pruneSessions(now time.Time) {
+	for key, lastSeen := range m.sessions {
+		if now.Sub(lastSeen) > sessionTimeout {
+			delete(m.sessions, key)
+		}
+	}
+	m.lastPrune = now
+}
+
+// evictOldestSession removes the client that idle the longest.
+// Must be called while holding the lock.
+func (m *Metrics) evictOldestSession() {
+	var oldestKey string
+	var oldestSeen time.Time
+	for key, lastSeen := range m.sessions {
+		if oldestKey == "" || lastSeen.Before(oldestSeen) {
+			oldestKey, oldestSeen = key, lastSeen
+		}
+	}
+	delete(m.sessions, oldestKey)
+}
+
+// WithRoute wraps the handle, so its requests are labeled in metrics by the route
+// pattern instead of the actual URL path. This way the number of labels is bounded
+// by the number of routes, whatever the values of its params.
+func WithRoute(pattern string, handle httprouter.Handle) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		if route, ok := r.Context().Value(routeKey{}).(*string); ok {
+			*route = pattern
+		}
+		handle(w, r, ps)
+	}
+}
@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"net"
+	"net/http"
+	"strings"
+)
+
+// ProxyHeaders is middleware to apply `X-Forwarded-*` headers that set by reverse proxy,
+// so the remote address, host and scheme of request reflect the original client.
+// Only use it when the app is behind a trusted proxy since these headers can be spoofed.
+type ProxyHeaders struct {
+	Handler http.Handler
+}
+
+func NewProxyHeaders(handler http.Handler) http.Handler {
+	return &ProxyHeaders{handler}
+}
+
+func (p *ProxyHeaders) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// Proxies append to the header instead of replacing it, so only the right-most
+	// address is added by our trusted proxy. The rest are supplied by the client.
+	if forwardedFor := r.Header.Values("X-Forwarded-For"); len(forwardedFor) > 0 {
+		addresses := strings.Split(forwardedFor[len(forwardedFor)-1], ",")
+		clientIP := strings.TrimSpace(addresses[len(addresses)-1])
+		if net.ParseIP(clientIP) != nil {
+			r.RemoteAddr = net.JoinHostPort(clientIP, "0")
+		}
+	}
+
+	if host := r.Header.Get("X-Forwarded-Host"); host != "" {
+		r.Host = host
+	}
+
+	if proto := r.Header.Get("X-Forwarded-Proto"); proto == "http" || proto == "https" {
+		r.URL.Scheme = proto
+	}
+
+	p.Handler.ServeHTTP(w, r)
+}
@@ -0,0 +1,32 @@
+package middleware
+
+import "net/http"
+
+// responseRecorder wraps the response writer to record the status and size of response.
+type responseRecorder struct {
+	http.ResponseWriter
+	Status int
+	Bytes  int
+}
+
+func newResponseRecorder(w http.ResponseWriter) *responseRecorder {
+	return &responseRecorder{ResponseWriter: w, Status: http.StatusOK}
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	r.Status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *responseRecorder) Write(bt []byte) (int, error) {
+	n, err := r.ResponseWriter.Write(bt)
+	r.Bytes += n
+	return n, err
+}
+
+// Flush sends the buffered data to client, so streaming response still works.
+func (r *responseRecorder) Flush() {
+	if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
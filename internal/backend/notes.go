@@ -0,0 +1,253 @@
+package backend
+
+import (
+	"encoding/json"
+	"kalimah/internal/database"
+	"kalimah/internal/store"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// maxNoteLength is the max length of note content in bytes.
+const maxNoteLength = 10000
+
+// GetNotes lists the notes, optionally filtered by its word or ayah.
+func (s *Server) GetNotes(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	var err error
+	defer func() {
+		if err != nil {
+			writeError(w, r, err)
+		}
+	}()
+
+	// Prepare filter
+	query := r.URL.Query()
+	var filter store.NoteFilter
+	if filter.Word, err = parseNoteFilter("word", query.Get("word")); err != nil {
+		return
+	}
+
+	if filter.Ayah, err = parseNoteFilter("ayah", query.Get("ayah")); err != nil {
+		return
+	}
+
+	// Fetch notes
+	storeNotes, err := s.Store.Notes(filter)
+	if err != nil {
+		return
+	}
+
+	notes, err := renderNotes(storeNotes)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(&notes)
+}
+
+// GetNote returns a single note.
+func (s *Server) GetNote(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	var err error
+	defer func() {
+		if err != nil {
+			writeError(w, r, err)
+		}
+	}()
+
+	note, err := s.fetchNote(ps.ByName("id"))
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(&note)
+}
+
+// CreateNote creates a note for a word or an ayah.
+func (s *Server) CreateNote(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	var err error
+	defer func() {
+		if err != nil {
+			writeError(w, r, err)
+		}
+	}()
+
+	// Decode and validate request
+	var input NoteInput
+	err = json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		err = badRequest("invalid note: %v", err)
+		return
+	}
+
+	if err = validateTarget(s.Store, "note", input.WordID, input.AyahID); err != nil {
+		return
+	}
+
+	if err = validateNoteContent(input.Content); err != nil {
+		return
+	}
+
+	// Save the note
+	id, err := s.Store.CreateNote(input.WordID, input.AyahID, input.Content, time.Now())
+	if err != nil {
+		return
+	}
+
+	note, err := s.fetchNote(strconv.Itoa(id))
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	err = json.NewEncoder(w).Encode(&note)
+}
+
+// UpdateNote replaces the content of a note.
+func (s *Server) UpdateNote(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	var err error
+	defer func() {
+		if err != nil {
+			writeError(w, r, err)
+		}
+	}()
+
+	// Decode and validate request
+	var input NoteInput
+	err = json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		err = badRequest("invalid note: %v", err)
+		return
+	}
+
+	if err = validateNoteContent(input.Content); err != nil {
+		return
+	}
+
+	// Make sure the note exists
+	note, err := s.fetchNote(ps.ByName("id"))
+	if err != nil {
+		return
+	}
+
+	// Save the changes
+	err = s.Store.UpdateNote(note.ID, input.Content, time.Now())
+	if err != nil {
+		return
+	}
+
+	note, err = s.fetchNote(ps.ByName("id"))
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(&note)
+}
+
+// DeleteNote removes a note.
+func (s *Server) DeleteNote(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	var err error
+	defer func() {
+		if err != nil {
+			writeError(w, r, err)
+		}
+	}()
+
+	note, err := s.fetchNote(ps.ByName("id"))
+	if err != nil {
+		return
+	}
+
+	err = s.Store.DeleteNote(note.ID)
+	if err != nil {
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// fetchNote fetches a note by its ID, along with its rendered content.
+func (s *Server) fetchNote(param string) (Note, error) {
+	id, err := strconv.Atoi(param)
+	if err != nil {
+		return Note{}, badRequest("note id must be a number, got %q", param)
+	}
+
+	note, err := s.Store.Note(id)
+	if err == store.ErrNotFound {
+		return Note{}, notFound("note %d not exist", id)
+	} else if err != nil {
+		return Note{}, err
+	}
+
+	notes, err := renderNotes([]store.Note{note})
+	if err != nil {
+		return Note{}, err
+	}
+	return notes[0], nil
+}
+
+// fetchAyahNotes fetches notes for the ayah and for the words within it.
+func (s *Server) fetchAyahNotes(ayahID int) ([]Note, error) {
+	notes, err := s.Store.AyahNotes(ayahID)
+	if err != nil {
+		return nil, err
+	}
+	return renderNotes(notes)
+}
+
+// renderNotes converts the notes into API notes, rendering its Markdown
+// content into HTML.
+func renderNotes(notes []store.Note) ([]Note, error) {
+	rendered := make([]Note, len(notes))
+	for i, note := range notes {
+		html, err := database.RenderMarkdown(note.Content)
+		if err != nil {
+			return nil, err
+		}
+
+		rendered[i] = Note{
+			ID:        note.ID,
+			WordID:    note.Word,
+			AyahID:    note.Ayah,
+			Content:   note.Content,
+			HTML:      html,
+			CreatedAt: note.CreatedAt,
+			UpdatedAt: note.UpdatedAt,
+		}
+	}
+	return rendered, nil
+}
+
+// parseNoteFilter parses the optional ID that used to filter the notes.
+func parseNoteFilter(column, param string) (*int, error) {
+	if param == "" {
+		return nil, nil
+	}
+
+	id, err := strconv.Atoi(param)
+	if err != nil {
+		return nil, badRequest("%s must be a number, got %q", column, param)
+	}
+	return &id, nil
+}
+
+// validateNoteContent makes sure the content of note is not empty nor too long.
+func validateNoteContent(content string) error {
+	if strings.TrimSpace(content) == "" {
+		return badRequest("note content must not be empty")
+	}
+
+	if len(content) > maxNoteLength {
+		return badRequest("note content must not exceed %d bytes", maxNoteLength)
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package backend
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestNotes(t *testing.T) {
+	_, h := newTestServer(t)
+
+	wordID, ayahID := 3, 1
+
+	// Note must belong to exactly one existing word or ayah
+	rec := doRequest(t, h, http.MethodPost, apiPrefix+"/notes",
+		NoteInput{WordID: &wordID, AyahID: &ayahID, Content: "both"}, nil)
+	expectStatus(t, rec, http.StatusBadRequest)
+
+	var note Note
+	rec = doRequest(t, h, http.MethodPost, apiPrefix+"/notes",
+		NoteInput{WordID: &wordID, Content: "**praise**"}, &note)
+	expectStatus(t, rec, http.StatusCreated)
+
+	if note.WordID == nil || *note.WordID != wordID || note.HTML != "<p><strong>praise</strong></p>" {
+		t.Fatalf("unexpected note: %+v", note)
+	}
+
+	rec = doRequest(t, h, http.MethodPost, apiPrefix+"/notes",
+		NoteInput{AyahID: &ayahID, Content: "opening"}, nil)
+	expectStatus(t, rec, http.StatusCreated)
+
+	// Filter notes by its word
+	var notes []Note
+	rec = doRequest(t, h, http.MethodGet, apiPrefix+"/notes?word=3", nil, &notes)
+	expectStatus(t, rec, http.StatusOK)
+
+	if len(notes) != 1 || notes[0].ID != note.ID {
+		t.Fatalf("unexpected notes of word 3: %+v", notes)
+	}
+
+	rec = doRequest(t, h, http.MethodGet, apiPrefix+"/notes", nil, &notes)
+	expectStatus(t, rec, http.StatusOK)
+
+	if len(notes) != 2 {
+		t.Fatalf("expected 2 notes, got %d", len(notes))
+	}
+
+	// Update then delete the note
+	noteURL := fmt.Sprintf("%s/notes/%d", apiPrefix, note.ID)
+	rec = doRequest(t, h, http.MethodPut, noteURL, NoteInput{Content: "changed"}, &note)
+	expectStatus(t, rec, http.StatusOK)
+
+	if note.Content != "changed" {
+		t.Fatalf("note is not updated: %+v", note)
+	}
+
+	rec = doRequest(t, h, http.MethodDelete, noteURL, nil, nil)
+	expectStatus(t, rec, http.StatusNoContent)
+
+	rec = doRequest(t, h, http.MethodGet, noteURL, nil, nil)
+	expectStatus(t, rec, http.StatusNotFound)
+}
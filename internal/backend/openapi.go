@@ -0,0 +1,171 @@
+package backend
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+var rxPathParam = regexp.MustCompile(`:(\w+)`)
+
+// ServeOpenAPI serves the OpenAPI 3 document for the current REST API.
+func (s *Server) ServeOpenAPI(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	doc := s.openAPIDocument()
+
+	w.Header().Add("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(&doc)
+	if err != nil {
+		writeError(w, r, err)
+	}
+}
+
+// openAPIDocument generates the OpenAPI document from the API routes.
+func (s *Server) openAPIDocument() map[string]interface{} {
+	schemas := map[string]interface{}{}
+	paths := map[string]map[string]interface{}{}
+
+	for _, route := range s.apiRoutes() {
+		// Convert path from `:param` into `{param}`
+		path := rxPathParam.ReplaceAllString(route.Path, "{$1}")
+		if paths[path] == nil {
+			paths[path] = map[string]interface{}{}
+		}
+
+		// Create operation
+		operation := map[string]interface{}{
+			"summary": route.Summary,
+			"responses": map[string]interface{}{
+				"400": map[string]interface{}{"description": "Invalid request"},
+				"500": map[string]interface{}{"description": "Internal server error"},
+			},
+		}
+
+		if len(route.Params) > 0 {
+			var params []map[string]interface{}
+			for _, p := range route.Params {
+				params = append(params, map[string]interface{}{
+					"name":        p.Name,
+					"in":          p.In,
+					"description": p.Description,
+					"required":    p.Required,
+					"schema":      map[string]string{"type": "integer"},
+				})
+			}
+			operation["parameters"] = params
+		}
+
+		if route.Request != nil {
+			operation["requestBody"] = map[string]interface{}{
+				"required": true,
+				"content": map[string]interface{}{
+					"application/json": map[string]interface{}{
+						"schema": jsonSchema(reflect.TypeOf(route.Request), schemas),
+					},
+				},
+			}
+		}
+
+		okResponse := map[string]interface{}{"description": "Success"}
+		if route.Response != nil {
+			contentType := "application/json"
+			if route.Stream {
+				contentType = "text/event-stream"
+			}
+
+			okResponse["content"] = map[string]interface{}{
+				contentType: map[string]interface{}{
+					"schema": jsonSchema(reflect.TypeOf(route.Response), schemas),
+				},
+			}
+		}
+		status := http.StatusOK
+		if route.Status != 0 {
+			status = route.Status
+		}
+
+		responses := operation["responses"].(map[string]interface{})
+		responses[strconv.Itoa(status)] = okResponse
+		if route.Conflict != "" {
+			responses["409"] = map[string]interface{}{"description": route.Conflict}
+		}
+
+		paths[path][strings.ToLower(route.Method)] = operation
+	}
+
+	return map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{
+			"title":   "Kalimah",
+			"version": "1",
+		},
+		"servers":    []map[string]string{{"url": s.BasePath + apiPrefix}},
+		"paths":      paths,
+		"components": map[string]interface{}{"schemas": schemas},
+	}
+}
+
+// jsonSchema returns JSON schema for the specified type. Struct will be
+// registered into schemas and referred by its name.
+func jsonSchema(t reflect.Type, schemas map[string]interface{}) map[string]interface{} {
+	switch t.Kind() {
+	case reflect.Ptr:
+		return jsonSchema(t.Elem(), schemas)
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{
+			"type":  "array",
+			"items": jsonSchema(t.Elem(), schemas),
+		}
+	case reflect.Map:
+		return map[string]interface{}{
+			"type":                 "object",
+			"additionalProperties": jsonSchema(t.Elem(), schemas),
+		}
+	case reflect.Struct:
+	default:
+		return map[string]interface{}{}
+	}
+
+	// Register the struct, unless it already registered before
+	ref := map[string]interface{}{"$ref": "#/components/schemas/" + t.Name()}
+	if _, exist := schemas[t.Name()]; exist {
+		return ref
+	}
+
+	properties := map[string]interface{}{}
+	schemas[t.Name()] = map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		} else if name == "" {
+			name = field.Name
+		}
+
+		properties[name] = jsonSchema(field.Type, schemas)
+	}
+
+	return ref
+}
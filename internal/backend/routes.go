@@ -0,0 +1,405 @@
+package backend
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// apiPrefix is the prefix for the current version of REST API.
+const apiPrefix = "/api/v1"
+
+// apiRoute describes an endpoint of REST API. It's used both to register
+// the handler in router and to generate the OpenAPI document, so both of
+// them always stay in sync.
+type apiRoute struct {
+	Method   string
+	Path     string
+	Aliases  []apiAlias
+	Summary  string
+	Params   []apiParam
+	Request  interface{}
+	Response interface{}
+	Status   int
+	Stream   bool
+	Conflict string
+	Handle   httprouter.Handle
+}
+
+// apiAlias is the old, unversioned route that kept for compatibility.
+type apiAlias struct {
+	Method string
+	Path   string
+}
+
+// apiParam describes parameter that used by an API endpoint.
+type apiParam struct {
+	Name        string
+	In          string
+	Description string
+	Required    bool
+}
+
+func (s *Server) apiRoutes() []apiRoute {
+	surahParam := apiParam{"surah", "path", "Surah number, between 1 and 114", true}
+	ayahParam := apiParam{"ayah", "path", "Ayah number within the surah", true}
+	pageParam := apiParam{"page", "query", "Page number, 0 or empty for the last answered page. Page beyond the last one is clamped into the last page", false}
+	noteParam := apiParam{"id", "path", "ID of the note", true}
+	listParam := apiParam{"list", "path", "ID of the word list", true}
+	wordParam := apiParam{"id", "path", "ID of the word", true}
+	suggestionParam := apiParam{"id", "path", "ID of the gloss suggestion", true}
+	sprintParam := apiParam{"id", "path", "ID of the sprint", true}
+	listFilterParam := apiParam{"list", "query", "ID of word list, to only quiz its words within the surah", false}
+	seedParam := apiParam{"seed", "query", "Seed of the choices, default to the one that rotates periodically", false}
+
+	return []apiRoute{{
+		Method:   http.MethodGet,
+		Path:     "/surahs",
+		Aliases:  []apiAlias{{http.MethodGet, "/api/surah"}},
+		Summary:  "List all surah and whether it has been translated",
+		Response: []Surah{},
+		Handle:   s.GetSurah,
+	}, {
+		Method:   http.MethodGet,
+		Path:     "/surahs/:surah/ayahs/:ayah",
+		Aliases:  []apiAlias{{http.MethodGet, "/api/tafsir/surah/:surah/ayah/:ayah"}},
+		Summary:  "Get the arabic text, translation and tafsir of an ayah",
+		Params:   []apiParam{surahParam, ayahParam},
+		Response: Ayah{},
+		Handle:   s.GetTafsir,
+	}, {
+		Method:   http.MethodGet,
+		Path:     "/surahs/:surah/words",
+		Aliases:  []apiAlias{{http.MethodGet, "/api/words/surah/:surah/page/:page"}},
+		Summary:  "Get a page of words within the surah along with its choices",
+		Params:   []apiParam{surahParam, pageParam, listFilterParam, seedParam},
+		Response: WordPage{},
+		Handle:   s.GetWords,
+	}, {
+		Method:  http.MethodGet,
+		Path:    "/surahs/:surah/ayahs/:ayah/cloze",
+		Summary: "Get the translation of an ayah with the gloss of some words blanked out, along with its choices",
+		Params: []apiParam{surahParam, ayahParam,
+			{"blanks", "query", "Number of blanks, between 1 (default) and 5", false}, seedParam},
+		Response: ClozeQuestion{},
+		Handle:   s.GetCloze,
+	}, {
+		Method:   http.MethodGet,
+		Path:     "/surahs/:surah/ayahs/:ayah/order",
+		Summary:  "Get the words of an ayah in shuffled order, to be rearranged into its original order",
+		Params:   []apiParam{surahParam, ayahParam},
+		Response: OrderExercise{},
+		Handle:   s.GetOrderExercise,
+	}, {
+		Method:   http.MethodPost,
+		Path:     "/surahs/:surah/ayahs/:ayah/order",
+		Summary:  "Check the word order of an ayah, with duration in milliseconds, and save it as an attempt",
+		Params:   []apiParam{surahParam, ayahParam},
+		Request:  OrderSubmission{},
+		Response: OrderResult{},
+		Handle:   s.SubmitOrder,
+	}, {
+		Method:  http.MethodGet,
+		Path:    "/order-stats",
+		Summary: "Get the word-order results of each attempted ayah",
+		Params: []apiParam{
+			{"surah", "query", "Only include ayahs within this surah", false},
+		},
+		Response: []OrderStat{},
+		Handle:   s.GetOrderStats,
+	}, {
+		Method:   http.MethodGet,
+		Path:     "/surahs/:surah/ayah-quiz",
+		Summary:  "Get a page of ayahs within the surah along with its translation choices",
+		Params:   []apiParam{surahParam, pageParam, seedParam},
+		Response: AyahQuizPage{},
+		Handle:   s.GetAyahQuiz,
+	}, {
+		Method:  http.MethodGet,
+		Path:    "/listening",
+		Summary: "Get a random word with its recitation audio, to be identified by its meaning or written form",
+		Params: []apiParam{
+			{"reciter", "query", "Name of the reciter, default to the one with the most words", false},
+			{"surah", "query", "Only pick the word within this surah", false},
+			{"answer", "query", "Kind of choices, either meaning (default) or arabic", false},
+			seedParam,
+		},
+		Response: ListeningQuestion{},
+		Handle:   s.GetListening,
+	}, {
+		Method:   http.MethodGet,
+		Path:     "/reciters",
+		Summary:  "List the reciters whose audio has been imported",
+		Response: []Reciter{},
+		Handle:   s.GetReciters,
+	}, {
+		Method:  http.MethodGet,
+		Path:    "/audio/:reciter/:word",
+		Summary: "Get the recitation audio of a word",
+		Params: []apiParam{
+			{"reciter", "path", "Name of the reciter", true},
+			{"word", "path", "ID of the word", true},
+		},
+		Handle: s.ServeAudio,
+	}, {
+		Method:   http.MethodGet,
+		Path:     "/progress",
+		Summary:  "Get the learning progress",
+		Response: Progress{},
+		Handle:   s.GetProgress,
+	}, {
+		Method:   http.MethodGet,
+		Path:     "/progress/events",
+		Summary:  "Stream the learning progress each time it's changed, as Server-Sent Events",
+		Response: Progress{},
+		Stream:   true,
+		Handle:   s.StreamProgress,
+	}, {
+		Method:   http.MethodPut,
+		Path:     "/progress",
+		Aliases:  []apiAlias{{http.MethodPost, "/api/track"}},
+		Summary:  "Mark the word as the last answered word, use `rewind=true` query to move it backward",
+		Request:  TrackInput{},
+		Conflict: "The word is behind the current progress without rewind, or ahead of the next unanswered word",
+		Handle:   s.TrackWord,
+	}, {
+		Method:   http.MethodPost,
+		Path:     "/progress/batch",
+		Summary:  "Track the submissions that queued while offline, deduplicated by its client ID",
+		Request:  []TrackSubmission{},
+		Response: []TrackResult{},
+		Handle:   s.TrackWordBatch,
+	}, {
+		Method:   http.MethodGet,
+		Path:     "/progress/ayahs",
+		Summary:  "Get the progress of ayah quiz, which tracked separately from the words",
+		Response: AyahProgress{},
+		Handle:   s.GetAyahProgress,
+	}, {
+		Method:   http.MethodPut,
+		Path:     "/progress/ayahs",
+		Summary:  "Mark the ayah as the last answered ayah in ayah quiz, use `rewind=true` query to move it backward",
+		Request:  TrackInput{},
+		Conflict: "The ayah is behind the current progress without rewind, ahead of the next unanswered ayah, or its words haven't been learned",
+		Handle:   s.TrackAyah,
+	}, {
+		Method:   http.MethodGet,
+		Path:     "/goals",
+		Summary:  "Get the daily goals along with today's progress",
+		Response: GoalProgress{},
+		Handle:   s.GetGoals,
+	}, {
+		Method:   http.MethodPut,
+		Path:     "/goals",
+		Summary:  "Set the daily goals of answered words and learning minutes, zero to disable it",
+		Request:  Goal{},
+		Response: GoalProgress{},
+		Handle:   s.UpdateGoals,
+	}, {
+		Method:   http.MethodPost,
+		Path:     "/sprints",
+		Summary:  "Start a timed sprint with duration in seconds, returning the first batch of due and new words",
+		Params:   []apiParam{seedParam},
+		Request:  SprintInput{},
+		Response: Sprint{},
+		Status:   http.StatusCreated,
+		Handle:   s.StartSprint,
+	}, {
+		Method:   http.MethodGet,
+		Path:     "/sprints/:id/words",
+		Summary:  "Get the next batch of words for the running sprint",
+		Params:   []apiParam{sprintParam, seedParam},
+		Response: Sprint{},
+		Conflict: "The sprint has ended",
+		Handle:   s.GetSprintWords,
+	}, {
+		Method:   http.MethodPost,
+		Path:     "/sprints/:id/answers",
+		Summary:  "Record an answer within the running sprint",
+		Params:   []apiParam{sprintParam},
+		Request:  SprintAnswer{},
+		Response: Sprint{},
+		Conflict: "The sprint has ended",
+		Handle:   s.AnswerSprint,
+	}, {
+		Method:  http.MethodGet,
+		Path:    "/notes",
+		Summary: "List the personal notes, optionally filtered by word or ayah",
+		Params: []apiParam{
+			{"word", "query", "ID of the word", false},
+			{"ayah", "query", "Absolute ID of the ayah, between 1 and 6236", false},
+		},
+		Response: []Note{},
+		Handle:   s.GetNotes,
+	}, {
+		Method:   http.MethodPost,
+		Path:     "/notes",
+		Summary:  "Create a Markdown note for either a word or an ayah",
+		Request:  NoteInput{},
+		Response: Note{},
+		Status:   http.StatusCreated,
+		Handle:   s.CreateNote,
+	}, {
+		Method:   http.MethodGet,
+		Path:     "/notes/:id",
+		Summary:  "Get a note along with its rendered HTML",
+		Params:   []apiParam{noteParam},
+		Response: Note{},
+		Handle:   s.GetNote,
+	}, {
+		Method:   http.MethodPut,
+		Path:     "/notes/:id",
+		Summary:  "Replace the content of a note",
+		Params:   []apiParam{noteParam},
+		Request:  NoteInput{},
+		Response: Note{},
+		Handle:   s.UpdateNote,
+	}, {
+		Method:  http.MethodDelete,
+		Path:    "/notes/:id",
+		Summary: "Delete a note",
+		Params:  []apiParam{noteParam},
+		Status:  http.StatusNoContent,
+		Handle:  s.DeleteNote,
+	}, {
+		Method:   http.MethodGet,
+		Path:     "/lists",
+		Summary:  "List the word lists along with the number of its bookmarks",
+		Response: []WordList{},
+		Handle:   s.GetLists,
+	}, {
+		Method:   http.MethodPost,
+		Path:     "/lists",
+		Summary:  "Create a named word list",
+		Request:  WordListInput{},
+		Response: WordList{},
+		Status:   http.StatusCreated,
+		Conflict: "Another list with the same name already exists",
+		Handle:   s.CreateList,
+	}, {
+		Method:   http.MethodGet,
+		Path:     "/lists/:list",
+		Summary:  "Get a word list along with its bookmarked words and ayahs",
+		Params:   []apiParam{listParam},
+		Response: WordList{},
+		Handle:   s.GetList,
+	}, {
+		Method:   http.MethodPut,
+		Path:     "/lists/:list",
+		Summary:  "Rename a word list",
+		Params:   []apiParam{listParam},
+		Request:  WordListInput{},
+		Response: WordList{},
+		Conflict: "Another list with the same name already exists",
+		Handle:   s.RenameList,
+	}, {
+		Method:  http.MethodDelete,
+		Path:    "/lists/:list",
+		Summary: "Delete a word list along with its bookmarks",
+		Params:  []apiParam{listParam},
+		Status:  http.StatusNoContent,
+		Handle:  s.DeleteList,
+	}, {
+		Method:   http.MethodGet,
+		Path:     "/lists/:list/words",
+		Summary:  "Get a page of words for quiz, drawn from the word list instead of surah. Answers are not tracked",
+		Params:   []apiParam{listParam, pageParam, seedParam},
+		Response: WordPage{},
+		Handle:   s.GetWords,
+	}, {
+		Method:   http.MethodPost,
+		Path:     "/lists/:list/bookmarks",
+		Summary:  "Bookmark a word or an ayah into the word list",
+		Params:   []apiParam{listParam},
+		Request:  BookmarkInput{},
+		Response: Bookmark{},
+		Status:   http.StatusCreated,
+		Conflict: "The word or ayah is already bookmarked in the list",
+		Handle:   s.AddBookmark,
+	}, {
+		Method:  http.MethodDelete,
+		Path:    "/lists/:list/bookmarks/:bookmark",
+		Summary: "Remove a bookmark from the word list",
+		Params:  []apiParam{listParam, {"bookmark", "path", "ID of the bookmark", true}},
+		Status:  http.StatusNoContent,
+		Handle:  s.RemoveBookmark,
+	}, {
+		Method:  http.MethodPost,
+		Path:    "/words/:id/attempts",
+		Summary: "Record an answer of the word without moving the progress, used by adaptive choice policy",
+		Params:  []apiParam{wordParam},
+		Request: WordAttempt{},
+		Status:  http.StatusNoContent,
+		Handle:  s.RecordAttempt,
+	}, {
+		Method:   http.MethodPost,
+		Path:     "/words/:id/suggestion",
+		Summary:  "Propose a corrected translation of the word, to be reviewed by admin",
+		Params:   []apiParam{wordParam},
+		Request:  GlossSuggestionInput{},
+		Response: GlossSuggestion{},
+		Status:   http.StatusCreated,
+		Handle:   s.SuggestGloss,
+	}, {
+		Method:  http.MethodGet,
+		Path:    "/admin/suggestions",
+		Summary: "List the gloss suggestions for review. Admin only",
+		Params: []apiParam{
+			{"status", "query", "Either pending (default), accepted, rejected or all", false},
+		},
+		Response: []GlossSuggestion{},
+		Handle:   s.adminOnly(s.GetSuggestions),
+	}, {
+		Method:   http.MethodPost,
+		Path:     "/admin/suggestions/:id/accept",
+		Summary:  "Accept the gloss suggestion, optionally with edited translation, and save it as override. Admin only",
+		Params:   []apiParam{suggestionParam},
+		Request:  GlossReviewInput{},
+		Response: GlossSuggestion{},
+		Conflict: "The suggestion has been reviewed",
+		Handle:   s.adminOnly(s.AcceptSuggestion),
+	}, {
+		Method:   http.MethodPost,
+		Path:     "/admin/suggestions/:id/reject",
+		Summary:  "Reject the gloss suggestion. Admin only",
+		Params:   []apiParam{suggestionParam},
+		Response: GlossSuggestion{},
+		Conflict: "The suggestion has been reviewed",
+		Handle:   s.adminOnly(s.RejectSuggestion),
+	}, {
+		Method:   http.MethodGet,
+		Path:     "/admin/overrides",
+		Summary:  "List the accepted gloss overrides. Admin only",
+		Response: []GlossOverride{},
+		Handle:   s.adminOnly(s.GetOverrides),
+	}, {
+		Method:  http.MethodDelete,
+		Path:    "/admin/overrides/:id",
+		Summary: "Remove the gloss override of a word and restore its original translation. Admin only",
+		Params: []apiParam{wordParam,
+			{"language", "query", "Language of the override, default to the populated one", false}},
+		Status: http.StatusNoContent,
+		Handle: s.adminOnly(s.DeleteOverride),
+	}}
+}
+
+// registerAPI registers all API routes and its aliases using the handle function.
+func (s *Server) registerAPI(handle func(method, path string, h httprouter.Handle)) {
+	for _, route := range s.apiRoutes() {
+		handle(route.Method, apiPrefix+route.Path, route.Handle)
+		for _, alias := range route.Aliases {
+			handle(alias.Method, alias.Path, route.Handle)
+		}
+	}
+
+	handle(http.MethodGet, apiPrefix+"/openapi.json", s.ServeOpenAPI)
+}
+
+// routeParam returns the value of named route param. If it doesn't exist in
+// route path, it will be looked up from the URL query instead.
+func routeParam(r *http.Request, ps httprouter.Params, name string) string {
+	if value := ps.ByName(name); value != "" {
+		return value
+	}
+	return r.URL.Query().Get(name)
+}
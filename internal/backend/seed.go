@@ -0,0 +1,75 @@
+package backend
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"kalimah/internal/store"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// maxChoiceSeed is the largest seed, limited to 53 bit so it can be
+// represented exactly as JSON number.
+const maxChoiceSeed = 1<<53 - 1
+
+// choiceSeed returns the seed for generating choices. When `seed` query is
+// specified it's used as it is, which useful to reproduce the exact choices.
+// Otherwise it's derived from the learner's secret and the current rotation
+// period, so the choices stay the same when the page is refreshed.
+func (s *Server) choiceSeed(r *http.Request, now time.Time) (uint64, error) {
+	if param := r.URL.Query().Get("seed"); param != "" {
+		seed, err := strconv.ParseUint(param, 10, 53)
+		if err != nil {
+			return 0, badRequest("seed must be a number between 0 and %d, got %q", uint64(maxChoiceSeed), param)
+		}
+		return seed, nil
+	}
+
+	return ChoiceSeed(s.Store, s.ChoiceSeedRotation, now)
+}
+
+// ChoiceSeed returns the seed that derived from the learner's secret and the rotation
+// period at the specified time. If rotation is zero, the seed never changes.
+func ChoiceSeed(st store.Store, rotation time.Duration, now time.Time) (uint64, error) {
+	secret, err := st.ChoiceSecret()
+	if err != nil {
+		return 0, err
+	}
+
+	var period int64
+	if rotation > 0 {
+		period = now.Unix() / int64(rotation/time.Second)
+	}
+
+	h := fnv.New64a()
+	h.Write([]byte(secret))
+	h.Write([]byte(":" + strconv.FormatInt(period, 10)))
+	return h.Sum64() & maxChoiceSeed, nil
+}
+
+// wordRand returns the random generator for the choices of a word,
+// which always produces the same sequence for the same seed and word.
+func wordRand(seed uint64, wordID int) *rand.Rand {
+	bt := make([]byte, 16)
+	binary.LittleEndian.PutUint64(bt, seed)
+	binary.LittleEndian.PutUint64(bt[8:], uint64(wordID))
+
+	h := fnv.New64a()
+	h.Write(bt)
+	return rand.New(rand.NewSource(int64(h.Sum64())))
+}
+
+// ayahRand returns the random generator for an ayah, e.g. for picking the blanks
+// of cloze question, which always produces the same sequence for the same seed and ayah.
+func ayahRand(seed uint64, ayahID int) *rand.Rand {
+	bt := make([]byte, 16)
+	binary.LittleEndian.PutUint64(bt, seed)
+	binary.LittleEndian.PutUint64(bt[8:], uint64(ayahID))
+
+	h := fnv.New64a()
+	h.Write([]byte("ayah:"))
+	h.Write(bt)
+	return rand.New(rand.NewSource(int64(h.Sum64())))
+}
@@ -1,17 +1,22 @@
 package backend
 
 import (
-	"database/sql"
+	"context"
 	"encoding/json"
 	"fmt"
+	"html"
 	"io/fs"
 	"io/ioutil"
 	"kalimah/internal/backend/middleware"
+	"kalimah/internal/database"
+	"kalimah/internal/store"
 	"math"
-	"math/rand"
+	"net"
 	"net/http"
+	"os"
 	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/jmoiron/sqlx"
@@ -19,49 +24,196 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+// maxBatchSize is the max number of submissions in a single batch.
+const maxBatchSize = 500
+
+// submissionRetention is how long the processed batch submissions are remembered
+// for deduplication. It must be longer than the max age of the queued submissions
+// in service worker, see `QUEUE_MAX_AGE` in sw.js.
+const submissionRetention = 14 * 24 * time.Hour
+
 // Server is server for serving app.
 type Server struct {
 	DB      *sqlx.DB
 	Assets  fs.FS
 	DevMode bool
+
+	// BasePath is the URL sub-path where the app is served, e.g. `/kalimah`.
+	// Empty means the app is served from root.
+	BasePath string
+
+	// TrustProxy marks that `X-Forwarded-*` headers from reverse proxy can be trusted.
+	TrustProxy bool
+
+	// AyahPerPage is the number of ayah in each page of words.
+	AyahPerPage int
+
+	// ChoiceCount is the number of choices for each word, including the correct one.
+	ChoiceCount int
+
+	// ChoicePolicy decides the number of choices for each word based on its past accuracy.
+	ChoicePolicy ChoicePolicy
+
+	// ChoiceSeedRotation is how long the choices of each word stay the same before
+	// it's reshuffled. Zero means the choices are never reshuffled.
+	ChoiceSeedRotation time.Duration
+
+	// ThrottleDelay is the delay for each API response. In dev mode it's default to 500ms.
+	ThrottleDelay time.Duration
+
+	// AdminToken is the bearer token for admin endpoints. If it's empty, the admin
+	// endpoints are disabled.
+	AdminToken string
+
+	// AudioDir is the directory of the imported word recitations.
+	AudioDir string
+
+	// Store provides the learning data. If it's nil, the store that uses DB is used.
+	Store store.Store
+
+	metrics  *serverMetrics
+	progress *progressBroadcaster
+}
+
+// ListenConfig is the config for listener that used by server.
+type ListenConfig struct {
+	// Address is the TCP address in `host:port` format, or path to
+	// Unix socket if it's prefixed with `unix:`.
+	Address string
+
+	// TLSCert and TLSKey is path to the certificate and key files.
+	// If both are empty, the server will be served in plain HTTP.
+	TLSCert string
+	TLSKey  string
+
+	// ShutdownTimeout is the max duration to wait for in-flight requests
+	// to finish when server is shutting down.
+	ShutdownTimeout time.Duration
 }
 
-// Serve serves app in specified port.
-func (s *Server) Serve(port int) error {
-	// Create router
+// Handler returns the handler that serves app along with its middlewares.
+func (s *Server) Handler() http.Handler {
+	// Prepare metrics, progress broadcaster and store
+	if s.metrics == nil {
+		s.metrics = newServerMetrics()
+	}
+
+	if s.progress == nil {
+		s.progress = newProgressBroadcaster()
+	}
+
+	if s.Store == nil {
+		s.Store = store.NewSQLite(s.DB)
+	}
+
+	// Create router, where each handle reports its route for metrics label
 	router := httprouter.New()
-	router.GET("/", s.ServeIndex)
-	router.GET("/res/*filepath", s.ServeFile)
-	router.GET("/build/*filepath", s.ServeFile)
-	router.GET("/api/surah", s.GetSurah)
-	router.GET("/api/words/surah/:surah/page/:page", s.GetWords)
-	router.GET("/api/tafsir/surah/:surah/ayah/:ayah", s.GetTafsir)
-	router.POST("/api/track", s.TrackWord)
+	handle := func(method, path string, h httprouter.Handle) {
+		router.Handle(method, path, middleware.WithRoute(path, h))
+	}
+
+	handle(http.MethodGet, "/", s.ServeIndex)
+	handle(http.MethodGet, "/healthz", s.ServeHealth)
+	handle(http.MethodGet, "/metrics", s.ServeMetrics)
+	handle(http.MethodGet, "/res/*filepath", s.ServeFile)
+	handle(http.MethodGet, "/build/*filepath", s.ServeFile)
+	handle(http.MethodGet, "/sw.js", s.ServeFile)
+	handle(http.MethodGet, "/manifest.webmanifest", s.ServeFile)
+	handle(http.MethodGet, "/print/worksheet", s.ServeWorksheet)
+	s.registerAPI(handle)
 
 	router.PanicHandler = func(w http.ResponseWriter, r *http.Request, arg interface{}) {
-		http.Error(w, fmt.Sprintf("unrecovered error: %v", arg), 500)
+		writeError(w, r, fmt.Errorf("unrecovered error: %v", arg))
 	}
 
 	// Apply middlewares
 	var handler http.Handler = router
+	handler = middleware.NewMetrics(handler, s.metrics.registry)
 	handler = middleware.NewGzipper(handler)
 
-	if s.DevMode {
-		handler = middleware.NewThrottler(handler, 500*time.Millisecond)
+	if s.DevMode || s.ThrottleDelay > 0 {
+		handler = middleware.NewThrottler(handler, s.ThrottleDelay)
+	}
+
+	handler = middleware.NewBasePath(handler, s.BasePath)
+	handler = middleware.NewLogger(handler)
+	if s.TrustProxy {
+		handler = middleware.NewProxyHeaders(handler)
+	}
+
+	return handler
+}
+
+// Serve serves app using the specified listen config, until the context is done.
+// Once the context is done, the server will be gracefully shut down.
+func (s *Server) Serve(ctx context.Context, cfg ListenConfig) error {
+	handler := s.Handler()
+
+	// Create listener
+	listener, err := listen(cfg.Address)
+	if err != nil {
+		return err
 	}
 
 	// Create server
-	url := fmt.Sprintf(":%d", port)
 	svr := &http.Server{
-		Addr:         url,
 		Handler:      handler,
 		ReadTimeout:  10 * time.Second,
 		WriteTimeout: time.Minute,
 	}
+	svr.RegisterOnShutdown(s.progress.Close)
+
+	// Serve app in background
+	useTLS := cfg.TLSCert != "" || cfg.TLSKey != ""
+	chErr := make(chan error, 1)
+	go func() {
+		if useTLS {
+			logrus.Println("serve app over TLS in", listener.Addr(), s.BasePath+"/")
+			chErr <- svr.ServeTLS(listener, cfg.TLSCert, cfg.TLSKey)
+		} else {
+			logrus.Println("serve app in", listener.Addr(), s.BasePath+"/")
+			chErr <- svr.Serve(listener)
+		}
+	}()
+
+	// Wait until server stopped or context is done
+	select {
+	case err = <-chErr:
+		return err
+	case <-ctx.Done():
+	}
 
-	// Serve app
-	logrus.Println("serve app in", url)
-	return svr.ListenAndServe()
+	// Drain the in-flight requests
+	logrus.Println("shutting down server")
+	timeout := cfg.ShutdownTimeout
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	err = svr.Shutdown(shutdownCtx)
+	if err != nil {
+		return fmt.Errorf("failed to shutdown: %w", err)
+	}
+
+	return nil
+}
+
+// listen creates listener for the specified address.
+func listen(address string) (net.Listener, error) {
+	if !strings.HasPrefix(address, "unix:") {
+		return net.Listen("tcp", address)
+	}
+
+	// Remove the stale socket that left by previous process
+	socketPath := strings.TrimPrefix(address, "unix:")
+	if info, err := os.Stat(socketPath); err == nil && info.Mode()&os.ModeSocket != 0 {
+		os.Remove(socketPath)
+	}
+
+	return net.Listen("unix", socketPath)
 }
 
 // ServeIndex serves the index page for admin app.
@@ -69,7 +221,7 @@ func (s *Server) ServeIndex(w http.ResponseWriter, r *http.Request, ps httproute
 	// Open HTML file
 	f, err := s.Assets.Open("app.html")
 	if err != nil {
-		http.Error(w, err.Error(), 500)
+		writeError(w, r, err)
 		return
 	}
 	defer f.Close()
@@ -77,10 +229,20 @@ func (s *Server) ServeIndex(w http.ResponseWriter, r *http.Request, ps httproute
 	// Read the entire content
 	bt, err := ioutil.ReadAll(f)
 	if err != nil {
-		http.Error(w, err.Error(), 500)
+		writeError(w, r, err)
 		return
 	}
 
+	// Prefix the asset references with base path, then expose the base path to front end
+	basePath := html.EscapeString(s.BasePath)
+	bt = []byte(strings.NewReplacer(
+		`href='/`, `href='`+basePath+`/`,
+		`href="/`, `href="`+basePath+`/`,
+		`src='/`, `src='`+basePath+`/`,
+		`src="/`, `src="`+basePath+`/`,
+		`</head>`, `<meta name="base-path" content="`+basePath+`">`+"\n</head>",
+	).Replace(string(bt)))
+
 	// Serve file
 	w.Header().Set("X-Content-Type-Options", "nosniff")
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
@@ -88,7 +250,7 @@ func (s *Server) ServeIndex(w http.ResponseWriter, r *http.Request, ps httproute
 
 	_, err = w.Write(bt)
 	if err != nil {
-		http.Error(w, err.Error(), 500)
+		writeError(w, r, err)
 	}
 }
 
@@ -96,7 +258,7 @@ func (s *Server) ServeIndex(w http.ResponseWriter, r *http.Request, ps httproute
 func (s *Server) ServeFile(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	err := serveAssets(w, r, s.Assets, r.URL.Path)
 	if err != nil {
-		http.Error(w, err.Error(), 500)
+		writeError(w, r, err)
 	}
 }
 
@@ -104,25 +266,48 @@ func (s *Server) GetSurah(w http.ResponseWriter, r *http.Request, ps httprouter.
 	var err error
 	defer func() {
 		if err != nil {
-			http.Error(w, err.Error(), 500)
+			writeError(w, r, err)
 		}
 	}()
 
-	listSurah := []Surah{}
-	err = s.DB.Select(&listSurah,
-		`WITH last_word AS (
-			SELECT IFNULL(last_word, 0) + 1 id FROM tracker WHERE id = 1),
-		last_ayah AS (
-			SELECT MAX(word.ayah) ayah
-			FROM word, last_word
-			WHERE word.id <= last_word.id)
-		SELECT s.id, s.name, s.translation, (s.start <= la.ayah) translated
-		FROM surah s, last_ayah la
-		ORDER BY s.id`)
-	if err != nil && err != sql.ErrNoRows {
+	// Fetch surah along with the progress and today's answers
+	surahs, err := s.Store.Surahs()
+	if err != nil {
+		return
+	}
+
+	progress, err := s.Store.Progress()
+	if err != nil {
+		return
+	}
+
+	today := database.StartOfDay(time.Now())
+	answered, err := s.Store.AnsweredWords(today, today.AddDate(0, 0, 1))
+	if err != nil {
+		return
+	}
+
+	goal, err := s.Store.Goal()
+	if err != nil {
 		return
 	}
 
+	listSurah := make([]Surah, len(surahs))
+	for i, surah := range surahs {
+		listSurah[i] = Surah{
+			ID:            surah.ID,
+			Name:          surah.Name,
+			Translation:   surah.Translation,
+			Translated:    progress.NextAyah > 0 && surah.Start <= progress.NextAyah,
+			AnsweredToday: answered[surah.ID],
+		}
+
+		// Compare today's answers with the daily goal
+		if goal.WordsPerDay > 0 {
+			listSurah[i].GoalCoverage = float64(answered[surah.ID]) / float64(goal.WordsPerDay)
+		}
+	}
+
 	w.Header().Add("Content-Type", "application/json")
 	err = json.NewEncoder(w).Encode(&listSurah)
 }
@@ -131,117 +316,110 @@ func (s *Server) GetWords(w http.ResponseWriter, r *http.Request, ps httprouter.
 	var err error
 	defer func() {
 		if err != nil {
-			http.Error(w, err.Error(), 500)
+			writeError(w, r, err)
 		}
 	}()
 
-	// Parse URL params
-	page, _ := strconv.Atoi(ps.ByName("page"))
-	surah, _ := strconv.Atoi(ps.ByName("surah"))
+	// Draw the words from word list instead, when list quiz is requested
+	if listParam := routeParam(r, ps, "list"); listParam != "" {
+		var surah int
+		if surahParam := ps.ByName("surah"); surahParam != "" {
+			if surah, err = parseSurah(surahParam); err != nil {
+				return
+			}
+		}
+
+		s.getListWords(w, r, listParam, surah)
+		return
+	}
+
+	// Parse surah from URL params
+	surah, err := parseSurah(ps.ByName("surah"))
+	if err != nil {
+		return
+	}
 
-	// Prepare read only transaction
-	tx, err := s.DB.Beginx()
+	// Decide the seed of choices
+	seed, err := s.choiceSeed(r, time.Now())
 	if err != nil {
 		return
 	}
-	defer tx.Rollback()
 
-	// Fetch ayah count for this surah
-	var nAyah int
-	err = tx.Get(&nAyah, `SELECT end-start+1 FROM surah WHERE id = ?`, surah)
-	if err != nil && err != sql.ErrNoRows {
+	// Fetch the surah along with the current progress
+	surahData, err := s.Store.Surah(surah)
+	if err == store.ErrNotFound {
+		err = badRequest("surah %d not exist", surah)
+		return
+	} else if err != nil {
 		return
 	}
 
-	// Fetch the last answered page
-	var lastAnsweredPage int
-	err = tx.Get(&lastAnsweredPage,
-		`WITH last_word AS (
-			SELECT IFNULL(last_word, 0) id FROM tracker WHERE id = 1),
-		last_ayah AS (
-			SELECT w.ayah FROM word w, last_word lw WHERE w.id = lw.id+1),
-		last_surah AS (
-			SELECT *
-			FROM surah s, last_ayah la
-			WHERE s.start <= la.ayah AND s.end >= la.ayah)
-		SELECT CEIL((ayah-start+1)/30.0) page FROM last_surah`)
+	progress, err := s.Store.Progress()
 	if err != nil {
 		return
 	}
 
-	// Adjust pagination
-	nAyahPerPage := 30
+	// Parse and adjust pagination
+	nAyahPerPage := s.AyahPerPage
+	if nAyahPerPage <= 0 {
+		nAyahPerPage = 30
+	}
+
+	nAyah := surahData.End - surahData.Start + 1
 	maxPage := int(math.Ceil(float64(nAyah) / float64(nAyahPerPage)))
-	if page > maxPage {
-		page = maxPage
-	} else if page <= 0 {
-		page = lastAnsweredPage
+	pageParam := routeParam(r, ps, "page")
+	if pageParam == "" {
+		pageParam = "0"
 	}
 
-	// Fetch words for this page
-	words := []Word{}
-	err = tx.Select(&words,
-		`WITH last_word AS (
-			SELECT IFNULL(last_word, 0) id FROM tracker WHERE id = 1),
-		ayah_range AS (
-			SELECT start,
-				(start + 30*(?-1)) page_start, 
-				MIN(end, start+30*?-1) page_end
-			FROM surah
-			WHERE id = ?)
-		SELECT w.id, w.ayah-ar.start+1 ayah, w.position, w.arabic,
-			w.translation, w.id <= lw.id answered, w.id > lw.id+1 disabled,
-			w.ayah <> LEAD(w.ayah, 1, w.ayah+1) OVER (ORDER BY w.ayah) is_separator
-		FROM word w, ayah_range ar, last_word lw
-		WHERE w.ayah >= ar.page_start AND w.ayah <= ar.page_end
-		ORDER BY w.id`, page, page, surah)
-	if err != nil && err != sql.ErrNoRows {
-		return
-	}
-
-	// Fetch choice candidate
-	var choiceCandidates []string
-	err = tx.Select(&choiceCandidates,
-		`SELECT DISTINCT w.translation FROM word w
-		ORDER BY RANDOM() LIMIT ?`, len(words)*5)
+	page, err := parsePage(pageParam, maxPage)
 	if err != nil {
 		return
 	}
 
-	// Apply choice to each word
-	nCandidates := len(choiceCandidates)
-	for i, word := range words {
-		// Prepare choices for this word
-		choices := make([]Choice, 8)
-		choices[0] = Choice{Text: word.Translation, IsCorrect: true}
-
-		// Fetch incorrect choice randomly
-		usedCandidateIdx := map[int]struct{}{}
-		for j := 0; j < 7; j++ {
-			var candidateIdx int
-
-			// Make sure candidate is unused and not correct
-			for {
-				candidateIdx = rand.Intn(nCandidates)
-				_, candidateIsUsed := usedCandidateIdx[candidateIdx]
-				candidateIsCorrect := choiceCandidates[candidateIdx] == word.Translation
-				if !candidateIsUsed && !candidateIsCorrect {
-					break
-				}
-			}
+	if page == 0 {
+		page, err = s.lastAnsweredPage(progress, nAyahPerPage)
+		if err != nil {
+			return
+		}
 
-			// Save the candidate
-			usedCandidateIdx[candidateIdx] = struct{}{}
-			choices[j+1] = Choice{Text: choiceCandidates[candidateIdx], IsCorrect: false}
+		// The last answered page might belong to another, longer surah
+		if page > maxPage {
+			page = maxPage
 		}
+	}
 
-		// Sort the choices
-		sort.Slice(choices, func(i, j int) bool {
-			return choices[i].Text < choices[j].Text
-		})
+	// Fetch words for this page
+	pageStart := surahData.Start + nAyahPerPage*(page-1)
+	pageEnd := surahData.Start + nAyahPerPage*page - 1
+	if pageEnd > surahData.End {
+		pageEnd = surahData.End
+	}
+
+	queryStart := time.Now()
+	pageWords, err := s.Store.Words(pageStart, pageEnd)
+	s.observeQuery("page_words", queryStart)
+	if err != nil {
+		return
+	}
 
-		// Apply choices to word
-		words[i].Choices = choices
+	words := make([]Word, len(pageWords))
+	for i, word := range pageWords {
+		words[i] = Word{
+			ID:          word.ID,
+			Ayah:        word.Ayah - surahData.Start + 1,
+			Position:    word.Position,
+			Arabic:      word.Arabic,
+			Translation: word.Translation,
+			Answered:    word.ID <= progress.LastWord,
+			Disabled:    word.ID > progress.LastWord+1,
+			IsSeparator: i == len(pageWords)-1 || word.Ayah != pageWords[i+1].Ayah,
+		}
+	}
+
+	// Apply choice to each word
+	if err = s.applyWordChoices(s.Store, words, seed); err != nil {
+		return
 	}
 
 	// Check if this page is disabled
@@ -254,16 +432,12 @@ func (s *Server) GetWords(w http.ResponseWriter, r *http.Request, ps httprouter.
 	}
 
 	// Create return data
-	data := struct {
-		CurrentPage int    `json:"currentPage"`
-		MaxPage     int    `json:"maxPage"`
-		Words       []Word `json:"words"`
-		Disabled    bool   `json:"disabled"`
-	}{
+	data := WordPage{
 		CurrentPage: page,
 		MaxPage:     maxPage,
 		Words:       words,
 		Disabled:    pageDisabled,
+		Seed:        seed,
 	}
 
 	w.Header().Add("Content-Type", "application/json")
@@ -274,30 +448,63 @@ func (s *Server) GetTafsir(w http.ResponseWriter, r *http.Request, ps httprouter
 	var err error
 	defer func() {
 		if err != nil {
-			http.Error(w, err.Error(), 500)
+			writeError(w, r, err)
 		}
 	}()
 
 	// Parse parameter
-	surah, _ := strconv.Atoi(ps.ByName("surah"))
-	ayah, _ := strconv.Atoi(ps.ByName("ayah"))
+	surah, err := parseSurah(ps.ByName("surah"))
+	if err != nil {
+		return
+	}
+
+	surahData, err := s.Store.Surah(surah)
+	if err == store.ErrNotFound {
+		err = badRequest("surah %d not exist", surah)
+		return
+	} else if err != nil {
+		return
+	}
+
+	ayahParam := ps.ByName("ayah")
+	ayah, err := strconv.Atoi(ayahParam)
+	if err != nil {
+		err = badRequest("ayah must be a number, got %q", ayahParam)
+		return
+	}
+
+	nAyah := surahData.End - surahData.Start + 1
+	if ayah < 1 || ayah > nAyah {
+		err = badRequest("surah %d only has ayah 1-%d, got %d", surah, nAyah, ayah)
+		return
+	}
 
 	// Fetch translation and tafsir
-	var data Ayah
-	err = s.DB.Get(&data,
-		`SELECT id, translation, tafsir FROM ayah
-		WHERE id = ? - 1 + (SELECT start FROM surah WHERE id = ?)`,
-		ayah, surah)
+	ayahData, err := s.Store.Ayah(surahData.Start + ayah - 1)
 	if err != nil {
 		return
 	}
 
 	// Fetch arabic text
-	err = s.DB.Get(&data.Arabic,
-		`SELECT GROUP_CONCAT(arabic, " ") FROM word 
-		WHERE ayah = ? - 1 + (SELECT start FROM surah WHERE id = ?)
-		ORDER BY position`,
-		ayah, surah)
+	words, err := s.Store.AyahWords(ayahData.ID)
+	if err != nil {
+		return
+	}
+
+	arabic := make([]string, len(words))
+	for i, word := range words {
+		arabic[i] = word.Arabic
+	}
+
+	data := Ayah{
+		ID:          ayahData.ID,
+		Arabic:      strings.Join(arabic, " "),
+		Translation: ayahData.Translation,
+		Tafsir:      ayahData.Tafsir,
+	}
+
+	// Fetch personal notes
+	data.Notes, err = s.fetchAyahNotes(data.ID)
 	if err != nil {
 		return
 	}
@@ -306,27 +513,274 @@ func (s *Server) GetTafsir(w http.ResponseWriter, r *http.Request, ps httprouter
 	err = json.NewEncoder(w).Encode(&data)
 }
 
+func (s *Server) GetProgress(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	var err error
+	defer func() {
+		if err != nil {
+			writeError(w, r, err)
+		}
+	}()
+
+	progress, err := s.fetchProgress()
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(&progress)
+}
+
 func (s *Server) TrackWord(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	// Prepare error handling
 	var err error
 	defer func() {
 		if err != nil {
-			http.Error(w, err.Error(), 500)
+			writeError(w, r, err)
 		}
 	}()
 
 	// Decode response
-	var currentWord Word
+	var currentWord TrackInput
 	err = json.NewDecoder(r.Body).Decode(&currentWord)
 	if err != nil {
+		err = badRequest("invalid word: %v", err)
+		return
+	}
+
+	// Use transaction, so the progress is not changed between validation and update.
+	// Moving the progress backward is only allowed when explicitly requested, to
+	// prevent stale session rewinds it.
+	allowRewind := r.URL.Query().Get("rewind") == "true"
+	err = s.Store.Tx(func(tx store.Store) error {
+		if err := validateTrackedWord(tx, currentWord.ID, allowRewind); err != nil {
+			s.countAnswer("rejected")
+			return err
+		}
+
+		if err := tx.SetLastWord(currentWord.ID); err != nil {
+			return err
+		}
+
+		return tx.LogAnswer(currentWord.ID, database.ActivityQuiz, true, time.Now())
+	})
+	if err != nil {
+		return
+	}
+
+	s.countAnswer("accepted")
+
+	// Notify the other sessions
+	progress, err := s.fetchProgress()
+	if err != nil {
+		return
+	}
+
+	if s.progress != nil {
+		s.progress.Publish(progress)
+	}
+}
+
+func (s *Server) TrackWordBatch(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	// Prepare error handling
+	var err error
+	defer func() {
+		if err != nil {
+			writeError(w, r, err)
+		}
+	}()
+
+	// Decode submissions
+	var submissions []TrackSubmission
+	err = json.NewDecoder(r.Body).Decode(&submissions)
+	if err != nil {
+		err = badRequest("invalid submissions: %v", err)
+		return
+	}
+
+	if len(submissions) > maxBatchSize {
+		err = badRequest("at most %d submissions allowed, got %d", maxBatchSize, len(submissions))
+		return
+	}
+
+	for _, sub := range submissions {
+		if sub.ClientID == "" || len(sub.ClientID) > 64 {
+			err = badRequest("client id must be between 1 and 64 characters, got %q", sub.ClientID)
+			return
+		}
+	}
+
+	// Process each submission in order. Submission that already processed
+	// before will get the same status, so it's safe to be sent repeatedly.
+	now := time.Now()
+	nAccepted := 0
+	results := make([]TrackResult, len(submissions))
+	err = s.Store.Tx(func(tx store.Store) error {
+		// Forget the old submissions, they won't be replayed anymore
+		if err := tx.ForgetSubmissions(now.Add(-submissionRetention)); err != nil {
+			return err
+		}
+
+		for i, sub := range submissions {
+			result := TrackResult{ClientID: sub.ClientID}
+
+			status, err := tx.Submission(sub.ClientID)
+			if err == nil {
+				result.Status = status
+				result.Duplicate = true
+				results[i] = result
+				continue
+			} else if err != store.ErrNotFound {
+				return err
+			}
+
+			err = validateTrackedWord(tx, sub.WordID, sub.Rewind)
+			if err != nil && errorStatus(err) >= 500 {
+				return err
+			}
+
+			if err != nil {
+				result.Status = "rejected"
+				result.Message = err.Error()
+				s.countAnswer("rejected")
+			} else {
+				if err = tx.SetLastWord(sub.WordID); err != nil {
+					return err
+				}
+
+				err = tx.LogAnswer(sub.WordID, database.ActivityQuiz, true, now)
+				if err != nil {
+					return err
+				}
+
+				result.Status = "accepted"
+				s.countAnswer("accepted")
+				nAccepted++
+			}
+
+			err = tx.SaveSubmission(sub.ClientID, sub.WordID, result.Status, now)
+			if err != nil {
+				return err
+			}
+
+			results[i] = result
+		}
+
+		return nil
+	})
+	if err != nil {
+		return
+	}
+
+	// Notify the other sessions
+	if nAccepted > 0 && s.progress != nil {
+		var progress Progress
+		progress, err = s.fetchProgress()
+		if err != nil {
+			return
+		}
+		s.progress.Publish(progress)
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(&results)
+}
+
+// RecordAttempt records an answer of the word without moving the progress, e.g. the
+// wrong choices picked before the correct one. It's used to decide the difficulty
+// of the word when the choice policy is adaptive.
+func (s *Server) RecordAttempt(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	var err error
+	defer func() {
+		if err != nil {
+			writeError(w, r, err)
+		}
+	}()
+
+	var attempt WordAttempt
+	err = json.NewDecoder(r.Body).Decode(&attempt)
+	if err != nil {
+		err = badRequest("invalid attempt: %v", err)
 		return
 	}
 
-	// Update tracker
-	_, err = s.DB.Exec(
-		"UPDATE tracker SET last_word = ? WHERE id = 1",
-		currentWord.ID)
+	wordParam := ps.ByName("id")
+	wordID, err := strconv.Atoi(wordParam)
 	if err != nil {
+		err = badRequest("word id must be a number, got %q", wordParam)
 		return
 	}
+
+	if err = validateTarget(s.Store, "attempt", &wordID, nil); err != nil {
+		return
+	}
+
+	err = s.Store.LogAnswer(wordID, database.ActivityQuiz, attempt.Correct, time.Now())
+	if err != nil {
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// lastAnsweredPage returns the page of the next unanswered word within its surah.
+// When every word has been answered, it's the page of the last word.
+func (s *Server) lastAnsweredPage(progress store.Progress, nAyahPerPage int) (int, error) {
+	surahs, err := s.Store.Surahs()
+	if err != nil {
+		return 0, err
+	}
+
+	surah, exist := findSurah(surahs, progress.NextAyah)
+	if !exist {
+		return 1, nil
+	}
+
+	return (progress.NextAyah-surah.Start)/nAyahPerPage + 1, nil
+}
+
+// fetchProgress fetches the current learning progress.
+func (s *Server) fetchProgress() (Progress, error) {
+	progress, err := s.Store.Progress()
+	if err != nil {
+		return Progress{}, err
+	}
+
+	return Progress{
+		LastWord: progress.LastWord,
+		Surah:    progress.Surah,
+		Ayah:     progress.Ayah,
+	}, nil
+}
+
+// findSurah finds the surah that contains the ayah.
+func findSurah(surahs []store.Surah, ayahID int) (store.Surah, bool) {
+	idx := sort.Search(len(surahs), func(i int) bool {
+		return surahs[i].End >= ayahID
+	})
+
+	if idx < len(surahs) && surahs[idx].Start <= ayahID {
+		return surahs[idx], true
+	}
+	return store.Surah{}, false
+}
+
+// locateWords converts the words into API words, along with its surah
+// and the number of its ayah within the surah.
+func locateWords(surahs []store.Surah, words []store.Word) []Word {
+	located := make([]Word, len(words))
+	for i, word := range words {
+		located[i] = Word{
+			ID:          word.ID,
+			Ayah:        word.Ayah,
+			Position:    word.Position,
+			Arabic:      word.Arabic,
+			Translation: word.Translation,
+		}
+
+		if surah, exist := findSurah(surahs, word.Ayah); exist {
+			located[i].Surah = surah.ID
+			located[i].Ayah = word.Ayah - surah.Start + 1
+		}
+	}
+	return located
 }
@@ -0,0 +1,261 @@
+package backend
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"kalimah/internal/store"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestMain(m *testing.M) {
+	logrus.SetOutput(ioutil.Discard)
+	os.Exit(m.Run())
+}
+
+// newTestStore returns the memory store with two short surahs:
+// surah 1 has ayah 1-3 with word 1-5, surah 2 has ayah 4-5 with word 6-8.
+func newTestStore() *store.Memory {
+	surahs := []store.Surah{
+		{ID: 1, Name: "Al-Fatihah", Translation: "The Opening", Start: 1, End: 3},
+		{ID: 2, Name: "Al-Baqarah", Translation: "The Cow", Start: 4, End: 5},
+	}
+
+	ayahs := []store.Ayah{
+		{ID: 1, Translation: "In the name of Allah"},
+		{ID: 2, Translation: "All praise is for Allah"},
+		{ID: 3, Translation: "The Most Merciful"},
+		{ID: 4, Translation: "Alif Lam Mim"},
+		{ID: 5, Translation: "This is the Book"},
+	}
+
+	words := []store.Word{
+		{ID: 1, Ayah: 1, Position: 1, Arabic: "بِسْمِ", Translation: "in the name"},
+		{ID: 2, Ayah: 1, Position: 2, Arabic: "اللَّهِ", Translation: "of Allah"},
+		{ID: 3, Ayah: 2, Position: 1, Arabic: "الْحَمْدُ", Translation: "all praise"},
+		{ID: 4, Ayah: 2, Position: 2, Arabic: "لِلَّهِ", Translation: "is for Allah"},
+		{ID: 5, Ayah: 3, Position: 1, Arabic: "الرَّحِيمِ", Translation: "the most merciful"},
+		{ID: 6, Ayah: 4, Position: 1, Arabic: "الم", Translation: "alif lam mim"},
+		{ID: 7, Ayah: 5, Position: 1, Arabic: "ذَٰلِكَ", Translation: "this"},
+		{ID: 8, Ayah: 5, Position: 2, Arabic: "الْكِتَابُ", Translation: "the book"},
+	}
+
+	st := store.NewMemory(surahs, ayahs, words)
+	st.SetLanguage("en")
+	return st
+}
+
+// newTestServer returns the server that backed by the memory store.
+func newTestServer(t *testing.T) (*Server, http.Handler) {
+	t.Helper()
+
+	s := &Server{
+		Store:       newTestStore(),
+		AyahPerPage: 2,
+		ChoiceCount: 4,
+		AdminToken:  "secret",
+	}
+	return s, s.Handler()
+}
+
+// doRequest sends the request with optional JSON body to the handler. If dst is
+// not nil, the response body is decoded into it when the request succeeded.
+func doRequest(t *testing.T, h http.Handler, method, url string, body interface{}, dst interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+
+	var reqBody bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&reqBody).Encode(body); err != nil {
+			t.Fatalf("failed to encode request body: %v", err)
+		}
+	}
+
+	req := httptest.NewRequest(method, url, &reqBody)
+	if strings.HasPrefix(url, apiPrefix+"/admin/") {
+		req.Header.Set("Authorization", "Bearer secret")
+	}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if dst != nil && rec.Code < 300 {
+		if err := json.NewDecoder(rec.Body).Decode(dst); err != nil {
+			t.Fatalf("%s %s: failed to decode response: %v", method, url, err)
+		}
+	}
+	return rec
+}
+
+// expectStatus makes sure the response has the expected status code.
+func expectStatus(t *testing.T, rec *httptest.ResponseRecorder, status int) {
+	t.Helper()
+	if rec.Code != status {
+		t.Fatalf("expected status %d, got %d: %s", status, rec.Code, rec.Body.String())
+	}
+}
+
+func TestGetSurah(t *testing.T) {
+	_, h := newTestServer(t)
+
+	var surahs []Surah
+	rec := doRequest(t, h, http.MethodGet, apiPrefix+"/surahs", nil, &surahs)
+	expectStatus(t, rec, http.StatusOK)
+
+	if len(surahs) != 2 || surahs[0].ID != 1 || surahs[1].ID != 2 {
+		t.Fatalf("unexpected surahs: %+v", surahs)
+	}
+}
+
+func TestGetWords(t *testing.T) {
+	_, h := newTestServer(t)
+
+	var page WordPage
+	rec := doRequest(t, h, http.MethodGet, apiPrefix+"/surahs/1/words?page=1&seed=1", nil, &page)
+	expectStatus(t, rec, http.StatusOK)
+
+	// Two ayahs per page, so the first page has words of ayah 1 and 2
+	if page.CurrentPage != 1 || page.MaxPage != 2 || page.Seed != 1 {
+		t.Fatalf("unexpected page: %+v", page)
+	}
+
+	if len(page.Words) != 4 {
+		t.Fatalf("expected 4 words, got %d", len(page.Words))
+	}
+
+	for i, word := range page.Words {
+		if word.ID != i+1 || word.Answered {
+			t.Errorf("unexpected word %d: %+v", i, word)
+		}
+
+		correct := 0
+		for _, choice := range word.Choices {
+			if choice.IsCorrect {
+				correct++
+				if choice.Text != word.Translation {
+					t.Errorf("word %d has correct choice %q", word.ID, choice.Text)
+				}
+			}
+		}
+
+		if correct != 1 {
+			t.Errorf("word %d has %d correct choices", word.ID, correct)
+		}
+	}
+
+	// Ayah in the second surah is numbered within the surah
+	rec = doRequest(t, h, http.MethodGet, apiPrefix+"/surahs/2/words?page=1", nil, &page)
+	expectStatus(t, rec, http.StatusOK)
+
+	if len(page.Words) != 3 || page.Words[0].Ayah != 1 || page.Words[2].Ayah != 2 {
+		t.Fatalf("unexpected words: %+v", page.Words)
+	}
+
+	// Unknown surah is rejected
+	rec = doRequest(t, h, http.MethodGet, apiPrefix+"/surahs/3/words", nil, nil)
+	expectStatus(t, rec, http.StatusBadRequest)
+}
+
+func TestTrackWord(t *testing.T) {
+	_, h := newTestServer(t)
+
+	// Words within the next ayah can be tracked
+	rec := doRequest(t, h, http.MethodPut, apiPrefix+"/progress", TrackInput{ID: 2}, nil)
+	expectStatus(t, rec, http.StatusOK)
+
+	var progress Progress
+	doRequest(t, h, http.MethodGet, apiPrefix+"/progress", nil, &progress)
+	if progress != (Progress{LastWord: 2, Surah: 1, Ayah: 1}) {
+		t.Fatalf("unexpected progress: %+v", progress)
+	}
+
+	// Word beyond the next ayah is rejected
+	rec = doRequest(t, h, http.MethodPut, apiPrefix+"/progress", TrackInput{ID: 5}, nil)
+	expectStatus(t, rec, http.StatusConflict)
+
+	// Moving backward needs rewind
+	rec = doRequest(t, h, http.MethodPut, apiPrefix+"/progress", TrackInput{ID: 1}, nil)
+	expectStatus(t, rec, http.StatusConflict)
+
+	rec = doRequest(t, h, http.MethodPut, apiPrefix+"/progress?rewind=true", TrackInput{ID: 1}, nil)
+	expectStatus(t, rec, http.StatusOK)
+
+	doRequest(t, h, http.MethodGet, apiPrefix+"/progress", nil, &progress)
+	if progress.LastWord != 1 {
+		t.Fatalf("expected progress rewound to word 1, got %+v", progress)
+	}
+}
+
+func TestTrackWordBatch(t *testing.T) {
+	_, h := newTestServer(t)
+
+	submissions := []TrackSubmission{
+		{ClientID: "a", WordID: 1},
+		{ClientID: "b", WordID: 2},
+		{ClientID: "c", WordID: 8},
+	}
+
+	var results []TrackResult
+	rec := doRequest(t, h, http.MethodPost, apiPrefix+"/progress/batch", submissions, &results)
+	expectStatus(t, rec, http.StatusOK)
+
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(results))
+	}
+
+	if results[0].Status != "accepted" || results[1].Status != "accepted" || results[2].Status != "rejected" {
+		t.Fatalf("unexpected results: %+v", results)
+	}
+
+	// Resending the same submissions gives the same result, flagged as duplicate
+	rec = doRequest(t, h, http.MethodPost, apiPrefix+"/progress/batch", submissions, &results)
+	expectStatus(t, rec, http.StatusOK)
+
+	for i, result := range results {
+		if !result.Duplicate {
+			t.Errorf("submission %d is not marked as duplicate: %+v", i, result)
+		}
+	}
+
+	var progress Progress
+	doRequest(t, h, http.MethodGet, apiPrefix+"/progress", nil, &progress)
+	if progress.LastWord != 2 {
+		t.Fatalf("expected progress at word 2, got %+v", progress)
+	}
+}
+
+func TestGetTafsir(t *testing.T) {
+	_, h := newTestServer(t)
+
+	ayahID := 2
+	rec := doRequest(t, h, http.MethodPost, apiPrefix+"/notes",
+		NoteInput{AyahID: &ayahID, Content: "note"}, nil)
+	expectStatus(t, rec, http.StatusCreated)
+
+	var ayah Ayah
+	rec = doRequest(t, h, http.MethodGet, apiPrefix+"/surahs/1/ayahs/2", nil, &ayah)
+	expectStatus(t, rec, http.StatusOK)
+
+	if ayah.ID != 2 || ayah.Arabic != "الْحَمْدُ لِلَّهِ" {
+		t.Fatalf("unexpected ayah: %+v", ayah)
+	}
+
+	if len(ayah.Notes) != 1 || ayah.Notes[0].Content != "note" {
+		t.Fatalf("unexpected notes: %+v", ayah.Notes)
+	}
+
+	rec = doRequest(t, h, http.MethodGet, apiPrefix+"/surahs/1/ayahs/4", nil, nil)
+	expectStatus(t, rec, http.StatusBadRequest)
+}
+
+func TestServeHealth(t *testing.T) {
+	_, h := newTestServer(t)
+
+	rec := doRequest(t, h, http.MethodGet, "/healthz", nil, nil)
+	expectStatus(t, rec, http.StatusOK)
+}
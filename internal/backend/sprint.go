@@ -0,0 +1,283 @@
+package backend
+
+import (
+	"encoding/json"
+	"kalimah/internal/database"
+	"kalimah/internal/store"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+const (
+	// defaultSprintDuration, minSprintDuration and maxSprintDuration
+	// limit the length of a sprint in seconds.
+	defaultSprintDuration = 120
+	minSprintDuration     = 30
+	maxSprintDuration     = 30 * 60
+
+	// sprintBatchSize is the number of words served in each batch of sprint.
+	sprintBatchSize = 20
+
+	// sprintGracePeriod is the extra time for answer that submitted right
+	// when the countdown ends, to tolerate the network latency.
+	sprintGracePeriod = 5 * time.Second
+)
+
+// StartSprint starts a timed sprint, then returns the first batch of words.
+func (s *Server) StartSprint(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	var err error
+	defer func() {
+		if err != nil {
+			writeError(w, r, err)
+		}
+	}()
+
+	// Decode and validate request
+	input := SprintInput{Duration: defaultSprintDuration}
+	if r.ContentLength != 0 {
+		err = json.NewDecoder(r.Body).Decode(&input)
+		if err != nil {
+			err = badRequest("invalid sprint: %v", err)
+			return
+		}
+	}
+
+	if input.Duration < minSprintDuration || input.Duration > maxSprintDuration {
+		err = badRequest("duration must be between %d and %d seconds, got %d",
+			minSprintDuration, maxSprintDuration, input.Duration)
+		return
+	}
+
+	// Save the sprint
+	now := time.Now()
+	endsAt := now.Add(time.Duration(input.Duration) * time.Second)
+	id, err := s.Store.CreateSprint(input.Duration, now, endsAt)
+	if err != nil {
+		return
+	}
+
+	sprint, err := fetchSprint(s.Store, strconv.Itoa(id))
+	if err != nil {
+		return
+	}
+
+	sprint.Words, sprint.Seed, err = s.fetchSprintWords(r)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	err = json.NewEncoder(w).Encode(&sprint)
+}
+
+// GetSprintWords returns the next batch of words for the running sprint.
+func (s *Server) GetSprintWords(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	var err error
+	defer func() {
+		if err != nil {
+			writeError(w, r, err)
+		}
+	}()
+
+	sprint, err := fetchSprint(s.Store, ps.ByName("id"))
+	if err != nil {
+		return
+	}
+
+	if err = checkSprintRunning(sprint, time.Now()); err != nil {
+		return
+	}
+
+	sprint.Words, sprint.Seed, err = s.fetchSprintWords(r)
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(&sprint)
+}
+
+// AnswerSprint records an answer within the running sprint. Correctly answered new
+// word moves the progress forward, as long as it's the next unanswered word.
+func (s *Server) AnswerSprint(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	var err error
+	defer func() {
+		if err != nil {
+			writeError(w, r, err)
+		}
+	}()
+
+	// Decode request
+	var answer SprintAnswer
+	err = json.NewDecoder(r.Body).Decode(&answer)
+	if err != nil {
+		err = badRequest("invalid answer: %v", err)
+		return
+	}
+
+	if err = validateTarget(s.Store, "answer", &answer.WordID, nil); err != nil {
+		return
+	}
+
+	now := time.Now()
+	var sprint Sprint
+	var moved bool
+	err = s.Store.Tx(func(tx store.Store) error {
+		var err error
+		sprint, err = fetchSprint(tx, ps.ByName("id"))
+		if err != nil {
+			return err
+		}
+
+		if err = checkSprintRunning(sprint, now); err != nil {
+			return err
+		}
+
+		// Save the answer
+		if err = tx.AddSprintAnswer(sprint.ID, answer.Correct); err != nil {
+			return err
+		}
+
+		err = tx.LogAnswer(answer.WordID, database.ActivitySprint, answer.Correct, now)
+		if err != nil {
+			return err
+		}
+
+		// Move the progress only when it's the next unanswered word
+		progress, err := tx.Progress()
+		if err != nil {
+			return err
+		}
+
+		if !answer.Correct || progress.LastWord+1 != answer.WordID {
+			return nil
+		}
+
+		moved = true
+		return tx.SetLastWord(answer.WordID)
+	})
+	if err != nil {
+		return
+	}
+
+	// Notify the other sessions when progress moved
+	if moved && s.progress != nil {
+		var progress Progress
+		progress, err = s.fetchProgress()
+		if err != nil {
+			return
+		}
+		s.progress.Publish(progress)
+	}
+
+	sprint, err = fetchSprint(s.Store, ps.ByName("id"))
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(&sprint)
+}
+
+// fetchSprint fetches a sprint by its ID.
+func fetchSprint(st store.ExerciseStore, param string) (Sprint, error) {
+	id, err := strconv.Atoi(param)
+	if err != nil {
+		return Sprint{}, badRequest("sprint id must be a number, got %q", param)
+	}
+
+	sprint, err := st.Sprint(id)
+	if err == store.ErrNotFound {
+		return Sprint{}, notFound("sprint %d not exist", id)
+	} else if err != nil {
+		return Sprint{}, err
+	}
+
+	return Sprint{
+		ID:        sprint.ID,
+		Duration:  sprint.Duration,
+		StartedAt: sprint.StartedAt,
+		EndsAt:    sprint.EndsAt,
+		Answered:  sprint.Answered,
+		Correct:   sprint.Correct,
+	}, nil
+}
+
+// checkSprintRunning makes sure the countdown of sprint hasn't ended yet.
+func checkSprintRunning(sprint Sprint, now time.Time) error {
+	if now.After(time.Unix(sprint.EndsAt, 0).Add(sprintGracePeriod)) {
+		return conflict("sprint %d has ended", sprint.ID)
+	}
+	return nil
+}
+
+// fetchSprintWords fetches a batch of words for sprint, interleaving the learned words
+// that due for review with the next unanswered words. Learned words that least recently
+// practiced are due first, while new words are kept in order so it can be tracked.
+// It also returns the seed that used to generate the choices.
+func (s *Server) fetchSprintWords(r *http.Request) ([]Word, uint64, error) {
+	seed, err := s.choiceSeed(r, time.Now())
+	if err != nil {
+		return nil, 0, err
+	}
+
+	surahs, err := s.Store.Surahs()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	storeDueWords, err := s.Store.DueWords(sprintBatchSize)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	dueWords := locateWords(surahs, storeDueWords)
+	for i := range dueWords {
+		dueWords[i].Answered = true
+	}
+
+	// New words are the ones right after the last answered word
+	progress, err := s.Store.Progress()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	lastWordID, err := s.Store.LastWordID()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var newIDs []int
+	for id := progress.LastWord + 1; id <= lastWordID && len(newIDs) < sprintBatchSize; id++ {
+		newIDs = append(newIDs, id)
+	}
+
+	storeNewWords, err := s.Store.WordsByID(newIDs)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	newWords := locateWords(surahs, storeNewWords)
+
+	// Interleave both kind of words, filling the batch with whichever still available
+	words := []Word{}
+	for i := 0; len(words) < sprintBatchSize && (i < len(dueWords) || i < len(newWords)); i++ {
+		if i < len(dueWords) {
+			words = append(words, dueWords[i])
+		}
+		if i < len(newWords) && len(words) < sprintBatchSize {
+			words = append(words, newWords[i])
+		}
+	}
+
+	// Apply the choices
+	if err = s.applyWordChoices(s.Store, words, seed); err != nil {
+		return nil, 0, err
+	}
+
+	return words, seed, nil
+}
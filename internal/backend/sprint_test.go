@@ -0,0 +1,53 @@
+package backend
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestSprint(t *testing.T) {
+	s, h := newTestServer(t)
+
+	if err := s.Store.SetLastWord(2); err != nil {
+		t.Fatal(err)
+	}
+
+	var sprint Sprint
+	rec := doRequest(t, h, http.MethodPost, apiPrefix+"/sprints", SprintInput{Duration: 60}, &sprint)
+	expectStatus(t, rec, http.StatusCreated)
+
+	// Every word is served, both the learned and the new ones
+	answered := map[int]bool{}
+	for _, word := range sprint.Words {
+		answered[word.ID] = word.Answered
+	}
+
+	if len(answered) != 8 || !answered[1] || !answered[2] || answered[3] {
+		t.Fatalf("unexpected sprint words: %+v", sprint.Words)
+	}
+
+	// Correct answer of the next word moves the progress, but the other doesn't
+	answersURL := fmt.Sprintf("%s/sprints/%d/answers", apiPrefix, sprint.ID)
+	for _, answer := range []SprintAnswer{
+		{WordID: 3, Correct: true},
+		{WordID: 5, Correct: true},
+		{WordID: 4, Correct: false},
+	} {
+		rec = doRequest(t, h, http.MethodPost, answersURL, answer, &sprint)
+		expectStatus(t, rec, http.StatusOK)
+	}
+
+	if sprint.Answered != 3 || sprint.Correct != 2 {
+		t.Fatalf("unexpected sprint: %+v", sprint)
+	}
+
+	var progress Progress
+	doRequest(t, h, http.MethodGet, apiPrefix+"/progress", nil, &progress)
+	if progress.LastWord != 3 {
+		t.Fatalf("expected progress at word 3, got %+v", progress)
+	}
+
+	rec = doRequest(t, h, http.MethodGet, apiPrefix+"/sprints/99/words", nil, nil)
+	expectStatus(t, rec, http.StatusNotFound)
+}
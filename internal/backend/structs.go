@@ -5,6 +5,11 @@ type Surah struct {
 	Name        string `db:"name"        json:"name"`
 	Translation string `db:"translation" json:"translation"`
 	Translated  bool   `db:"translated"  json:"translated"`
+
+	// AnsweredToday is the number of words within this surah that answered today,
+	// while GoalCoverage is its ratio to the daily words goal.
+	AnsweredToday int     `db:"answered_today" json:"answeredToday"`
+	GoalCoverage  float64 `db:"-"              json:"goalCoverage"`
 }
 
 type Ayah struct {
@@ -12,10 +17,12 @@ type Ayah struct {
 	Arabic      string `db:"arabic"      json:"arabic"`
 	Translation string `db:"translation" json:"translation"`
 	Tafsir      string `db:"tafsir"      json:"tafsir"`
+	Notes       []Note `db:"-"           json:"notes"`
 }
 
 type Word struct {
 	ID          int      `db:"id"           json:"id"`
+	Surah       int      `db:"surah"        json:"surah,omitempty"`
 	Ayah        int      `db:"ayah"         json:"ayah"`
 	Position    int      `db:"position"     json:"position"`
 	Arabic      string   `db:"arabic"       json:"arabic"`
@@ -23,10 +30,257 @@ type Word struct {
 	Answered    bool     `db:"answered"     json:"answered"`
 	Disabled    bool     `db:"disabled"     json:"-"`
 	IsSeparator bool     `db:"is_separator" json:"isSeparator"`
+	Difficulty  string   `db:"-"            json:"difficulty,omitempty"`
 	Choices     []Choice `json:"choices"`
 }
 
+// TrackInput is the word or ayah that marked as the last answered one.
+type TrackInput struct {
+	ID int `json:"id"`
+}
+
+type WordAttempt struct {
+	Correct bool `json:"correct"`
+}
+
 type Choice struct {
 	Text      string `db:"text"       json:"text"`
 	IsCorrect bool   `db:"is_correct" json:"isCorrect"`
 }
+
+type WordPage struct {
+	CurrentPage int    `json:"currentPage"`
+	MaxPage     int    `json:"maxPage"`
+	Words       []Word `json:"words"`
+	Disabled    bool   `json:"disabled"`
+	Seed        uint64 `json:"seed"`
+}
+
+type AyahQuestion struct {
+	ID          int      `db:"id"          json:"id"`
+	Ayah        int      `db:"ayah"        json:"ayah"`
+	Arabic      string   `db:"arabic"      json:"arabic"`
+	Translation string   `db:"translation" json:"translation"`
+	Answered    bool     `db:"answered"    json:"answered"`
+	Disabled    bool     `db:"disabled"    json:"-"`
+	Choices     []Choice `json:"choices"`
+}
+
+type AyahQuizPage struct {
+	CurrentPage int            `json:"currentPage"`
+	MaxPage     int            `json:"maxPage"`
+	Ayahs       []AyahQuestion `json:"ayahs"`
+	Disabled    bool           `json:"disabled"`
+}
+
+type AyahProgress struct {
+	LastAyah int `db:"last_ayah" json:"lastAyah"`
+	Surah    int `db:"surah"     json:"surah"`
+	Ayah     int `db:"ayah"      json:"ayah"`
+}
+
+type OrderExercise struct {
+	AyahID      int         `db:"id"          json:"ayahId"`
+	Surah       int         `db:"surah"       json:"surah"`
+	Ayah        int         `db:"ayah"        json:"ayah"`
+	Translation string      `db:"translation" json:"translation"`
+	Exercise    string      `db:"-"           json:"exercise"`
+	Words       []OrderWord `db:"-"           json:"words"`
+}
+
+type OrderWord struct {
+	Token       string `db:"-"           json:"token"`
+	Arabic      string `db:"arabic"      json:"arabic"`
+	Translation string `db:"translation" json:"translation"`
+}
+
+type OrderSubmission struct {
+	Exercise string   `json:"exercise"`
+	Tokens   []string `json:"tokens"`
+	Duration int      `json:"duration"`
+}
+
+type OrderResult struct {
+	Correct   bool     `json:"correct"`
+	Mistakes  int      `json:"mistakes"`
+	Misplaced []string `json:"misplaced"`
+}
+
+type OrderStat struct {
+	AyahID        int   `db:"ayah_id"         json:"ayahId"`
+	Surah         int   `db:"surah"           json:"surah"`
+	Ayah          int   `db:"ayah"            json:"ayah"`
+	Attempts      int   `db:"attempts"        json:"attempts"`
+	Mistakes      int   `db:"mistakes"        json:"mistakes"`
+	Solved        bool  `db:"solved"          json:"solved"`
+	LastCorrect   bool  `db:"last_correct"    json:"lastCorrect"`
+	BestDuration  *int  `db:"best_duration"   json:"bestDuration,omitempty"`
+	TotalDuration int   `db:"total_duration"  json:"totalDuration"`
+	LastAttemptAt int64 `db:"last_attempt_at" json:"lastAttemptAt"`
+}
+
+type ClozeQuestion struct {
+	AyahID   int            `db:"id"     json:"ayahId"`
+	Surah    int            `db:"surah"  json:"surah"`
+	Ayah     int            `db:"ayah"   json:"ayah"`
+	Arabic   string         `db:"arabic" json:"arabic"`
+	Segments []ClozeSegment `db:"-"      json:"segments"`
+	Blanks   []Word         `db:"-"      json:"blanks"`
+}
+
+type ClozeSegment struct {
+	Text  string `json:"text,omitempty"`
+	Blank *int   `json:"blank,omitempty"`
+}
+
+type ListeningQuestion struct {
+	WordID   int      `db:"id"       json:"wordId"`
+	Surah    int      `db:"surah"    json:"surah"`
+	Ayah     int      `db:"ayah"     json:"ayah"`
+	Position int      `db:"position" json:"position"`
+	Reciter  string   `db:"reciter"  json:"reciter"`
+	AudioURL string   `db:"-"        json:"audioUrl"`
+	Answer   string   `db:"-"        json:"answer"`
+	Choices  []Choice `db:"-"        json:"choices"`
+}
+
+type Reciter struct {
+	Name      string `db:"reciter"    json:"name"`
+	WordCount int    `db:"word_count" json:"wordCount"`
+}
+
+type Goal struct {
+	WordsPerDay   int `db:"words_per_day"   json:"wordsPerDay"`
+	MinutesPerDay int `db:"minutes_per_day" json:"minutesPerDay"`
+}
+
+type GoalProgress struct {
+	Goal
+	Date             string `json:"date"`
+	WordsToday       int    `json:"wordsToday"`
+	MinutesToday     int    `json:"minutesToday"`
+	WordsCompleted   bool   `json:"wordsCompleted"`
+	MinutesCompleted bool   `json:"minutesCompleted"`
+}
+
+type Sprint struct {
+	ID        int    `db:"id"         json:"id"`
+	Duration  int    `db:"duration"   json:"duration"`
+	StartedAt int64  `db:"started_at" json:"startedAt"`
+	EndsAt    int64  `db:"ends_at"    json:"endsAt"`
+	Answered  int    `db:"answered"   json:"answered"`
+	Correct   int    `db:"correct"    json:"correct"`
+	Words     []Word `db:"-"          json:"words,omitempty"`
+	Seed      uint64 `db:"-"          json:"seed,omitempty"`
+}
+
+type SprintInput struct {
+	Duration int `json:"duration"`
+}
+
+type SprintAnswer struct {
+	WordID  int  `json:"wordId"`
+	Correct bool `json:"correct"`
+}
+
+type Progress struct {
+	LastWord int `db:"last_word" json:"lastWord"`
+	Surah    int `db:"surah"     json:"surah"`
+	Ayah     int `db:"ayah"      json:"ayah"`
+}
+
+type TrackSubmission struct {
+	ClientID string `db:"client_id" json:"clientId"`
+	WordID   int    `db:"word"      json:"wordId"`
+	Rewind   bool   `json:"rewind"`
+}
+
+type TrackResult struct {
+	ClientID  string `db:"client_id" json:"clientId"`
+	Status    string `db:"status"    json:"status"`
+	Message   string `json:"message,omitempty"`
+	Duplicate bool   `json:"duplicate"`
+}
+
+type Note struct {
+	ID        int    `db:"id"         json:"id"`
+	WordID    *int   `db:"word"       json:"wordId,omitempty"`
+	AyahID    *int   `db:"ayah"       json:"ayahId,omitempty"`
+	Content   string `db:"content"    json:"content"`
+	HTML      string `db:"-"          json:"html"`
+	CreatedAt int64  `db:"created_at" json:"createdAt"`
+	UpdatedAt int64  `db:"updated_at" json:"updatedAt"`
+}
+
+type NoteInput struct {
+	WordID  *int   `json:"wordId,omitempty"`
+	AyahID  *int   `json:"ayahId,omitempty"`
+	Content string `json:"content"`
+}
+
+type WordList struct {
+	ID        int        `db:"id"         json:"id"`
+	Name      string     `db:"name"       json:"name"`
+	WordCount int        `db:"word_count" json:"wordCount"`
+	AyahCount int        `db:"ayah_count" json:"ayahCount"`
+	CreatedAt int64      `db:"created_at" json:"createdAt"`
+	Bookmarks []Bookmark `db:"-"          json:"bookmarks,omitempty"`
+}
+
+type WordListInput struct {
+	Name string `json:"name"`
+}
+
+type Bookmark struct {
+	ID        int    `db:"id"         json:"id"`
+	ListID    int    `db:"list"       json:"listId"`
+	WordID    *int   `db:"word"       json:"wordId,omitempty"`
+	AyahID    *int   `db:"ayah"       json:"ayahId,omitempty"`
+	Surah     int    `db:"surah"      json:"surah"`
+	Ayah      int    `db:"surah_ayah" json:"ayah"`
+	Arabic    string `db:"arabic"     json:"arabic"`
+	CreatedAt int64  `db:"created_at" json:"createdAt"`
+}
+
+type BookmarkInput struct {
+	WordID *int `json:"wordId,omitempty"`
+	AyahID *int `json:"ayahId,omitempty"`
+}
+
+type GlossSuggestion struct {
+	ID          int    `db:"id"          json:"id"`
+	WordID      int    `db:"word"        json:"wordId"`
+	Surah       int    `db:"surah"       json:"surah"`
+	Ayah        int    `db:"ayah"        json:"ayah"`
+	Position    int    `db:"position"    json:"position"`
+	Arabic      string `db:"arabic"      json:"arabic"`
+	Current     string `db:"current"     json:"currentTranslation"`
+	Translation string `db:"translation" json:"translation"`
+	Reason      string `db:"reason"      json:"reason"`
+	Language    string `db:"language"    json:"language"`
+	Status      string `db:"status"      json:"status"`
+	CreatedAt   int64  `db:"created_at"  json:"createdAt"`
+	ReviewedAt  *int64 `db:"reviewed_at" json:"reviewedAt,omitempty"`
+}
+
+type GlossSuggestionInput struct {
+	Translation string `json:"translation"`
+	Reason      string `json:"reason"`
+}
+
+type GlossReviewInput struct {
+	Translation string `json:"translation,omitempty"`
+}
+
+type GlossOverride struct {
+	WordID       int    `db:"word"        json:"wordId"`
+	Surah        int    `db:"surah"       json:"surah"`
+	Ayah         int    `db:"ayah"        json:"ayah"`
+	Position     int    `db:"position"    json:"position"`
+	Arabic       string `db:"arabic"      json:"arabic"`
+	Language     string `db:"language"    json:"language"`
+	Original     string `db:"original"    json:"original"`
+	Translation  string `db:"translation" json:"translation"`
+	SuggestionID *int   `db:"suggestion"  json:"suggestionId,omitempty"`
+	UpdatedAt    int64  `db:"updated_at"  json:"updatedAt"`
+}
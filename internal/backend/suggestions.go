@@ -0,0 +1,292 @@
+package backend
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"kalimah/internal/database"
+	"kalimah/internal/store"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// maxGlossLength is the max length of suggested gloss in bytes.
+const maxGlossLength = 200
+
+// SuggestGloss proposes a corrected translation for a word, which will be
+// queued for review by admin.
+func (s *Server) SuggestGloss(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	var err error
+	defer func() {
+		if err != nil {
+			writeError(w, r, err)
+		}
+	}()
+
+	// Decode and validate request
+	var input GlossSuggestionInput
+	err = json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		err = badRequest("invalid suggestion: %v", err)
+		return
+	}
+
+	wordParam := ps.ByName("id")
+	wordID, err := strconv.Atoi(wordParam)
+	if err != nil {
+		err = badRequest("word id must be a number, got %q", wordParam)
+		return
+	}
+
+	if err = validateTarget(s.Store, "suggestion", &wordID, nil); err != nil {
+		return
+	}
+
+	input.Translation = strings.TrimSpace(input.Translation)
+	if input.Translation == "" || len(input.Translation) > maxGlossLength {
+		err = badRequest("translation must be between 1 and %d bytes", maxGlossLength)
+		return
+	}
+
+	language, err := s.Store.Language()
+	if err != nil {
+		return
+	}
+
+	// Save the suggestion
+	id, err := s.Store.CreateSuggestion(wordID, language, input.Translation,
+		strings.TrimSpace(input.Reason), time.Now())
+	if err != nil {
+		return
+	}
+
+	suggestion, err := s.Store.Suggestion(id)
+	if err != nil {
+		return
+	}
+
+	data := newGlossSuggestion(suggestion)
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	err = json.NewEncoder(w).Encode(&data)
+}
+
+// GetSuggestions lists the gloss suggestions, default to the pending ones.
+func (s *Server) GetSuggestions(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	var err error
+	defer func() {
+		if err != nil {
+			writeError(w, r, err)
+		}
+	}()
+
+	status := r.URL.Query().Get("status")
+	switch status {
+	case "":
+		status = "pending"
+	case "pending", "accepted", "rejected", "all":
+	default:
+		err = badRequest("status must be pending, accepted, rejected or all, got %q", status)
+		return
+	}
+
+	if status == "all" {
+		status = ""
+	}
+
+	storeSuggestions, err := s.Store.Suggestions(status)
+	if err != nil {
+		return
+	}
+
+	suggestions := make([]GlossSuggestion, len(storeSuggestions))
+	for i, suggestion := range storeSuggestions {
+		suggestions[i] = newGlossSuggestion(suggestion)
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(&suggestions)
+}
+
+// AcceptSuggestion accepts the pending gloss suggestion and saves it as override.
+func (s *Server) AcceptSuggestion(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	s.reviewSuggestion(w, r, ps, true)
+}
+
+// RejectSuggestion rejects the pending gloss suggestion.
+func (s *Server) RejectSuggestion(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	s.reviewSuggestion(w, r, ps, false)
+}
+
+func (s *Server) reviewSuggestion(w http.ResponseWriter, r *http.Request, ps httprouter.Params, accept bool) {
+	var err error
+	defer func() {
+		if err != nil {
+			writeError(w, r, err)
+		}
+	}()
+
+	// Parse request
+	idParam := ps.ByName("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		err = badRequest("suggestion id must be a number, got %q", idParam)
+		return
+	}
+
+	var input GlossReviewInput
+	if r.ContentLength != 0 {
+		err = json.NewDecoder(r.Body).Decode(&input)
+		if err != nil {
+			err = badRequest("invalid review: %v", err)
+			return
+		}
+	}
+
+	input.Translation = strings.TrimSpace(input.Translation)
+	if len(input.Translation) > maxGlossLength {
+		err = badRequest("translation must not exceed %d bytes", maxGlossLength)
+		return
+	}
+
+	// Review the suggestion
+	if accept {
+		err = s.Store.AcceptSuggestion(id, input.Translation)
+	} else {
+		err = s.Store.RejectSuggestion(id)
+	}
+
+	switch {
+	case errors.Is(err, database.ErrSuggestionNotFound):
+		err = notFound("suggestion %d not exist", id)
+	case errors.Is(err, database.ErrSuggestionReviewed):
+		err = conflict("suggestion %d has been reviewed", id)
+	}
+
+	if err != nil {
+		return
+	}
+
+	suggestion, err := s.Store.Suggestion(id)
+	if err != nil {
+		return
+	}
+
+	data := newGlossSuggestion(suggestion)
+	w.Header().Add("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(&data)
+}
+
+// GetOverrides lists the accepted gloss overrides.
+func (s *Server) GetOverrides(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	var err error
+	defer func() {
+		if err != nil {
+			writeError(w, r, err)
+		}
+	}()
+
+	storeOverrides, err := s.Store.Overrides()
+	if err != nil {
+		return
+	}
+
+	overrides := make([]GlossOverride, len(storeOverrides))
+	for i, override := range storeOverrides {
+		overrides[i] = GlossOverride{
+			WordID:       override.Word,
+			Surah:        override.Surah,
+			Ayah:         override.Ayah,
+			Position:     override.Position,
+			Arabic:       override.Arabic,
+			Language:     override.Language,
+			Original:     override.Original,
+			Translation:  override.Translation,
+			SuggestionID: override.Suggestion,
+			UpdatedAt:    override.UpdatedAt,
+		}
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(&overrides)
+}
+
+// DeleteOverride removes the gloss override and restores the original translation.
+func (s *Server) DeleteOverride(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	var err error
+	defer func() {
+		if err != nil {
+			writeError(w, r, err)
+		}
+	}()
+
+	wordParam := ps.ByName("id")
+	wordID, err := strconv.Atoi(wordParam)
+	if err != nil {
+		err = badRequest("word id must be a number, got %q", wordParam)
+		return
+	}
+
+	language := r.URL.Query().Get("language")
+	if language == "" {
+		if language, err = s.Store.Language(); err != nil {
+			return
+		}
+	}
+
+	err = s.Store.RemoveOverride(wordID, language)
+	if errors.Is(err, database.ErrOverrideNotFound) {
+		err = notFound("word %d has no override in language %q", wordID, language)
+	}
+
+	if err != nil {
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// newGlossSuggestion converts the gloss suggestion into API gloss suggestion.
+func newGlossSuggestion(suggestion store.Suggestion) GlossSuggestion {
+	return GlossSuggestion{
+		ID:          suggestion.ID,
+		WordID:      suggestion.Word,
+		Surah:       suggestion.Surah,
+		Ayah:        suggestion.Ayah,
+		Position:    suggestion.Position,
+		Arabic:      suggestion.Arabic,
+		Current:     suggestion.Current,
+		Translation: suggestion.Translation,
+		Reason:      suggestion.Reason,
+		Language:    suggestion.Language,
+		Status:      suggestion.Status,
+		CreatedAt:   suggestion.CreatedAt,
+		ReviewedAt:  suggestion.ReviewedAt,
+	}
+}
+
+// adminOnly makes sure the request is authorized as admin before it's handled.
+// When admin token is not configured, the admin endpoints are disabled. The client
+// address is never trusted for this, since behind a reverse proxy every request
+// comes from the proxy address.
+func (s *Server) adminOnly(handle httprouter.Handle) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		if s.AdminToken == "" {
+			writeError(w, r, forbidden("admin endpoint is disabled, admin_token is not configured"))
+			return
+		}
+
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(token), []byte(s.AdminToken)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="kalimah-admin"`)
+			writeError(w, r, unauthorized("invalid admin token"))
+			return
+		}
+
+		handle(w, r, ps)
+	}
+}
@@ -0,0 +1,70 @@
+package backend
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSuggestions(t *testing.T) {
+	_, h := newTestServer(t)
+
+	var suggestion GlossSuggestion
+	rec := doRequest(t, h, http.MethodPost, apiPrefix+"/words/7/suggestion",
+		GlossSuggestionInput{Translation: "that", Reason: "demonstrative"}, &suggestion)
+	expectStatus(t, rec, http.StatusCreated)
+
+	if suggestion.Status != "pending" || suggestion.Language != "en" || suggestion.Current != "this" {
+		t.Fatalf("unexpected suggestion: %+v", suggestion)
+	}
+
+	// Admin endpoints need the token
+	req := httptest.NewRequest(http.MethodGet, apiPrefix+"/admin/suggestions", nil)
+	unauthorized := httptest.NewRecorder()
+	h.ServeHTTP(unauthorized, req)
+	expectStatus(t, unauthorized, http.StatusUnauthorized)
+
+	var suggestions []GlossSuggestion
+	rec = doRequest(t, h, http.MethodGet, apiPrefix+"/admin/suggestions", nil, &suggestions)
+	expectStatus(t, rec, http.StatusOK)
+
+	if len(suggestions) != 1 || suggestions[0].ID != suggestion.ID {
+		t.Fatalf("unexpected suggestions: %+v", suggestions)
+	}
+
+	// Accepted suggestion overrides the translation, and can't be reviewed again
+	acceptURL := fmt.Sprintf("%s/admin/suggestions/%d/accept", apiPrefix, suggestion.ID)
+	rec = doRequest(t, h, http.MethodPost, acceptURL, nil, &suggestion)
+	expectStatus(t, rec, http.StatusOK)
+
+	if suggestion.Status != "accepted" || suggestion.Current != "that" {
+		t.Fatalf("unexpected accepted suggestion: %+v", suggestion)
+	}
+
+	rec = doRequest(t, h, http.MethodPost, acceptURL, nil, nil)
+	expectStatus(t, rec, http.StatusConflict)
+
+	var overrides []GlossOverride
+	rec = doRequest(t, h, http.MethodGet, apiPrefix+"/admin/overrides", nil, &overrides)
+	expectStatus(t, rec, http.StatusOK)
+
+	if len(overrides) != 1 || overrides[0].Original != "this" || overrides[0].Translation != "that" {
+		t.Fatalf("unexpected overrides: %+v", overrides)
+	}
+
+	// Removing the override restores the original translation
+	rec = doRequest(t, h, http.MethodDelete, apiPrefix+"/admin/overrides/7", nil, nil)
+	expectStatus(t, rec, http.StatusNoContent)
+
+	rec = doRequest(t, h, http.MethodDelete, apiPrefix+"/admin/overrides/7", nil, nil)
+	expectStatus(t, rec, http.StatusNotFound)
+
+	var page WordPage
+	doRequest(t, h, http.MethodGet, apiPrefix+"/surahs/2/words?page=1", nil, &page)
+	for _, word := range page.Words {
+		if word.ID == 7 && word.Translation != "this" {
+			t.Fatalf("translation is not restored: %+v", word)
+		}
+	}
+}
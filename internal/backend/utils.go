@@ -6,6 +6,7 @@ import (
 	"io/fs"
 	"mime"
 	"net/http"
+	"path"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -16,6 +17,8 @@ var presetMimeTypes = map[string]string{
 	".html": "text/html; charset=utf-8",
 	".js":   "application/javascript",
 	".png":  "image/png",
+
+	".webmanifest": "application/manifest+json",
 }
 
 // serveAssets serve the assets for specified file path.
@@ -72,3 +75,13 @@ func guessTypeByExtension(ext string) string {
 
 	return mime.TypeByExtension(ext)
 }
+
+// NormalizeBasePath cleans the URL sub-path so it always started with slash
+// and never ended with slash. Root path will be returned as empty string.
+func NormalizeBasePath(basePath string) string {
+	basePath = strings.Trim(strings.TrimSpace(basePath), "/")
+	if basePath == "" {
+		return ""
+	}
+	return path.Clean("/" + basePath)
+}
@@ -0,0 +1,285 @@
+package backend
+
+import (
+	"errors"
+	"fmt"
+	"kalimah/internal/store"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	minSurah = 1
+	maxSurah = 114
+)
+
+// requestError is error that caused by invalid request from client.
+type requestError struct {
+	Status  int
+	Message string
+}
+
+func (e *requestError) Error() string {
+	return e.Message
+}
+
+func badRequest(format string, args ...interface{}) error {
+	return &requestError{
+		Status:  http.StatusBadRequest,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+func unauthorized(format string, args ...interface{}) error {
+	return &requestError{
+		Status:  http.StatusUnauthorized,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+func forbidden(format string, args ...interface{}) error {
+	return &requestError{
+		Status:  http.StatusForbidden,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+func notFound(format string, args ...interface{}) error {
+	return &requestError{
+		Status:  http.StatusNotFound,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+func conflict(format string, args ...interface{}) error {
+	return &requestError{
+		Status:  http.StatusConflict,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+func unprocessable(format string, args ...interface{}) error {
+	return &requestError{
+		Status:  http.StatusUnprocessableEntity,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+// errorStatus returns the HTTP status code that suitable for the error.
+func errorStatus(err error) int {
+	var reqErr *requestError
+	if errors.As(err, &reqErr) {
+		return reqErr.Status
+	}
+	return http.StatusInternalServerError
+}
+
+// parseSurah parses the surah number and make sure it's within 1-114.
+func parseSurah(param string) (int, error) {
+	surah, err := strconv.Atoi(param)
+	if err != nil || surah < minSurah || surah > maxSurah {
+		return 0, badRequest("surah must be a number between %d and %d, got %q", minSurah, maxSurah, param)
+	}
+	return surah, nil
+}
+
+// parseAyah parses the ayah number and make sure it exists within the surah.
+func parseAyah(st store.SurahStore, surah int, param string) (int, error) {
+	ayah, err := strconv.Atoi(param)
+	if err != nil {
+		return 0, badRequest("ayah must be a number, got %q", param)
+	}
+
+	nAyah, err := countAyah(st, surah)
+	if err != nil {
+		return 0, err
+	}
+
+	if ayah < 1 || ayah > nAyah {
+		return 0, badRequest("surah %d only has ayah 1-%d, got %d", surah, nAyah, ayah)
+	}
+
+	return ayah, nil
+}
+
+// parseAyahRange parses the range of ayah within the surah, e.g. "5" or "1-10",
+// then returns it as the absolute ID of ayah. Empty range means the whole surah.
+func parseAyahRange(st store.SurahStore, surah int, param string) (int, int, error) {
+	surahData, err := st.Surah(surah)
+	if err == store.ErrNotFound {
+		return 0, 0, badRequest("surah %d not exist", surah)
+	} else if err != nil {
+		return 0, 0, err
+	}
+
+	if param == "" {
+		return surahData.Start, surahData.End, nil
+	}
+
+	firstParam, lastParam := param, param
+	if parts := strings.SplitN(param, "-", 2); len(parts) == 2 {
+		firstParam, lastParam = parts[0], parts[1]
+	}
+
+	first, err := parseAyah(st, surah, firstParam)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	last, err := parseAyah(st, surah, lastParam)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if first > last {
+		return 0, 0, badRequest("ayah range must be ascending, got %q", param)
+	}
+
+	return surahData.Start + first - 1, surahData.Start + last - 1, nil
+}
+
+// parsePage parses the page number. Zero is allowed and used to mark the last answered page,
+// while page beyond the last one is clamped into the last page.
+func parsePage(param string, maxPage int) (int, error) {
+	page, err := strconv.Atoi(param)
+	if err != nil || page < 0 {
+		return 0, badRequest("page must be a non-negative number, got %q", param)
+	}
+
+	if page > maxPage {
+		page = maxPage
+	}
+	return page, nil
+}
+
+// countAyah returns the number of ayah within the surah.
+func countAyah(st store.SurahStore, surah int) (int, error) {
+	surahData, err := fetchSurah(st, surah)
+	if err != nil {
+		return 0, err
+	}
+	return surahData.End - surahData.Start + 1, nil
+}
+
+// fetchSurah fetches the surah by its number.
+func fetchSurah(st store.SurahStore, surah int) (store.Surah, error) {
+	surahData, err := st.Surah(surah)
+	if err == store.ErrNotFound {
+		return store.Surah{}, badRequest("surah %d not exist", surah)
+	}
+	return surahData, err
+}
+
+// validateTrackedWord make sure the tracked word exists and not beyond the ayah that
+// currently being answered, so learner can't skip words accidentally. Unless rewind
+// is allowed, the tracked word also must not be behind the current progress.
+func validateTrackedWord(st store.Store, wordID int, allowRewind bool) error {
+	if wordID <= 0 {
+		return badRequest("word id must be a positive number, got %d", wordID)
+	}
+
+	progress, err := st.Progress()
+	if err != nil {
+		return err
+	}
+
+	maxWord, err := st.LastWordID()
+	if err != nil {
+		return err
+	}
+
+	// The last word that allowed to be tracked is the last word
+	// in the ayah that contains the next unanswered word
+	nextAyahWords, err := st.AyahWords(progress.NextAyah)
+	if err != nil {
+		return err
+	}
+
+	allowedWord := progress.LastWord
+	if n := len(nextAyahWords); n > 0 {
+		allowedWord = nextAyahWords[n-1].ID
+	}
+
+	if wordID > maxWord {
+		return badRequest("word %d not exist", wordID)
+	}
+
+	if wordID < progress.LastWord && !allowRewind {
+		return conflict("word %d is behind the current progress at word %d, "+
+			"use rewind=true to move the progress backward", wordID, progress.LastWord)
+	}
+
+	if wordID > allowedWord {
+		return conflict("word %d is ahead of the current progress, "+
+			"the furthest word that can be tracked is %d", wordID, allowedWord)
+	}
+
+	return nil
+}
+
+// validateTrackedAyah makes sure the tracked ayah in ayah quiz exists, its words have
+// been learned, and it's not beyond the next unanswered ayah. Like the word tracker,
+// moving the progress backward is only allowed when explicitly requested.
+func validateTrackedAyah(st store.Store, ayahID int, allowRewind bool) error {
+	if ayahID <= 0 {
+		return badRequest("ayah id must be a positive number, got %d", ayahID)
+	}
+
+	progress, err := st.AyahProgress()
+	if err != nil {
+		return err
+	}
+
+	maxAyah, err := lastAyahID(st)
+	if err != nil {
+		return err
+	}
+
+	learned, err := learnedAyah(st)
+	if err != nil {
+		return err
+	}
+
+	if ayahID > maxAyah {
+		return badRequest("ayah %d not exist", ayahID)
+	}
+
+	if ayahID < progress.LastAyah && !allowRewind {
+		return conflict("ayah %d is behind the current progress at ayah %d, "+
+			"use rewind=true to move the progress backward", ayahID, progress.LastAyah)
+	}
+
+	if ayahID > progress.LastAyah+1 {
+		return conflict("ayah %d is ahead of the current progress, "+
+			"the furthest ayah that can be tracked is %d", ayahID, progress.LastAyah+1)
+	}
+
+	if ayahID > learned {
+		return conflict("words of ayah %d haven't been learned yet", ayahID)
+	}
+
+	return nil
+}
+
+// validateTarget makes sure the subject, e.g. note or bookmark, belongs to exactly
+// one existing word or ayah.
+func validateTarget(st store.Store, subject string, wordID, ayahID *int) error {
+	if (wordID == nil) == (ayahID == nil) {
+		return badRequest("%s must have either wordId or ayahId", subject)
+	}
+
+	var err error
+	table, id := "word", wordID
+	if ayahID != nil {
+		table, id = "ayah", ayahID
+		_, err = st.Ayah(*id)
+	} else {
+		_, err = st.Word(*id)
+	}
+
+	if err == store.ErrNotFound {
+		return badRequest("%s %d not exist", table, *id)
+	}
+	return err
+}
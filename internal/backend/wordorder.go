@@ -0,0 +1,270 @@
+package backend
+
+import (
+	"crypto/hmac"
+	crand "crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"hash/fnv"
+	"kalimah/internal/store"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// maxOrderDuration is the max duration of a word-order attempt in milliseconds.
+const maxOrderDuration = 24 * 60 * 60 * 1000
+
+// GetOrderExercise returns the words of an ayah in shuffled order, which
+// need to be rearranged by learner into its original order.
+func (s *Server) GetOrderExercise(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	var err error
+	defer func() {
+		if err != nil {
+			writeError(w, r, err)
+		}
+	}()
+
+	exercise, words, err := s.fetchOrderExercise(ps)
+	if err != nil {
+		return
+	}
+
+	// Each exercise has its own word tokens, so the words can't be ordered
+	// back just by sorting its IDs
+	exercise.Exercise, err = newExerciseID()
+	if err != nil {
+		return
+	}
+
+	secret, err := s.Store.ChoiceSecret()
+	if err != nil {
+		return
+	}
+
+	// Shuffle the words, making sure it's not in the original order unless
+	// the ayah only has a single word
+	rng := exerciseRand(exercise.Exercise)
+	for i := 0; i < 10; i++ {
+		rng.Shuffle(len(words), func(a, b int) {
+			words[a], words[b] = words[b], words[a]
+		})
+
+		if !isInOrder(words) {
+			break
+		}
+	}
+
+	exercise.Words = make([]OrderWord, len(words))
+	for i, word := range words {
+		exercise.Words[i] = OrderWord{
+			Token:       orderToken(secret, exercise.Exercise, word.ID),
+			Arabic:      word.Arabic,
+			Translation: word.Translation,
+		}
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(&exercise)
+}
+
+// SubmitOrder validates the word order submitted by learner, then saves it
+// as an attempt for the ayah.
+func (s *Server) SubmitOrder(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	var err error
+	defer func() {
+		if err != nil {
+			writeError(w, r, err)
+		}
+	}()
+
+	// Decode request
+	var submission OrderSubmission
+	err = json.NewDecoder(r.Body).Decode(&submission)
+	if err != nil {
+		err = badRequest("invalid submission: %v", err)
+		return
+	}
+
+	if submission.Duration < 0 || submission.Duration > maxOrderDuration {
+		err = badRequest("duration must be between 0 and %d milliseconds, got %d",
+			maxOrderDuration, submission.Duration)
+		return
+	}
+
+	if submission.Exercise == "" || len(submission.Exercise) > 64 {
+		err = badRequest("exercise must be between 1 and 64 characters, got %q", submission.Exercise)
+		return
+	}
+
+	exercise, words, err := s.fetchOrderExercise(ps)
+	if err != nil {
+		return
+	}
+
+	secret, err := s.Store.ChoiceSecret()
+	if err != nil {
+		return
+	}
+
+	// Map the tokens of the exercise back into the word positions
+	positions := map[string]int{}
+	for i, word := range words {
+		positions[orderToken(secret, submission.Exercise, word.ID)] = i
+	}
+
+	// Make sure the submission contains every word of the ayah exactly once
+	if len(submission.Tokens) != len(words) {
+		err = badRequest("ayah %d:%d has %d words, got %d",
+			exercise.Surah, exercise.Ayah, len(words), len(submission.Tokens))
+		return
+	}
+
+	submitted := map[string]struct{}{}
+	for _, token := range submission.Tokens {
+		if _, exists := positions[token]; !exists {
+			err = badRequest("token %q is not part of exercise %q for ayah %d:%d",
+				token, submission.Exercise, exercise.Surah, exercise.Ayah)
+			return
+		}
+		submitted[token] = struct{}{}
+	}
+
+	if len(submitted) != len(words) {
+		err = badRequest("every word of ayah %d:%d must be submitted exactly once",
+			exercise.Surah, exercise.Ayah)
+		return
+	}
+
+	// Compare the submission with the original order
+	result := OrderResult{Misplaced: []string{}}
+	for i, token := range submission.Tokens {
+		if positions[token] != i {
+			result.Misplaced = append(result.Misplaced, token)
+		}
+	}
+
+	result.Mistakes = len(result.Misplaced)
+	result.Correct = result.Mistakes == 0
+
+	// Save the attempt
+	err = s.Store.LogOrderAttempt(store.OrderAttempt{
+		Ayah:     exercise.AyahID,
+		Correct:  result.Correct,
+		Mistakes: result.Mistakes,
+		Duration: submission.Duration,
+		At:       time.Now(),
+	})
+	if err != nil {
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(&result)
+}
+
+// GetOrderStats returns the word-order results for each attempted ayah,
+// optionally filtered by surah.
+func (s *Server) GetOrderStats(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	var err error
+	defer func() {
+		if err != nil {
+			writeError(w, r, err)
+		}
+	}()
+
+	var surah int
+	if surahParam := r.URL.Query().Get("surah"); surahParam != "" {
+		if surah, err = parseSurah(surahParam); err != nil {
+			return
+		}
+	}
+
+	storeStats, err := s.Store.OrderStats(surah)
+	if err != nil {
+		return
+	}
+
+	stats := make([]OrderStat, len(storeStats))
+	for i, stat := range storeStats {
+		stats[i] = OrderStat(stat)
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(&stats)
+}
+
+// fetchOrderExercise fetches the ayah that specified in URL params, along
+// with its words in the original order.
+func (s *Server) fetchOrderExercise(ps httprouter.Params) (OrderExercise, []Word, error) {
+	surah, err := parseSurah(ps.ByName("surah"))
+	if err != nil {
+		return OrderExercise{}, nil, err
+	}
+
+	ayah, err := parseAyah(s.Store, surah, ps.ByName("ayah"))
+	if err != nil {
+		return OrderExercise{}, nil, err
+	}
+
+	surahData, err := s.Store.Surah(surah)
+	if err != nil {
+		return OrderExercise{}, nil, err
+	}
+
+	ayahData, err := s.Store.Ayah(surahData.Start + ayah - 1)
+	if err != nil {
+		return OrderExercise{}, nil, err
+	}
+
+	words, err := s.Store.AyahWords(ayahData.ID)
+	if err != nil {
+		return OrderExercise{}, nil, err
+	}
+
+	exercise := OrderExercise{
+		AyahID:      ayahData.ID,
+		Surah:       surah,
+		Ayah:        ayah,
+		Translation: ayahData.Translation,
+	}
+	return exercise, locateWords([]store.Surah{surahData}, words), nil
+}
+
+// newExerciseID generates the random ID of a word-order exercise.
+func newExerciseID() (string, error) {
+	bt := make([]byte, 8)
+	if _, err := crand.Read(bt); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bt), nil
+}
+
+// exerciseRand returns the random generator for shuffling the words of an exercise.
+func exerciseRand(exercise string) *rand.Rand {
+	h := fnv.New64a()
+	h.Write([]byte(exercise))
+	return rand.New(rand.NewSource(int64(h.Sum64())))
+}
+
+// orderToken returns the opaque token of a word within an exercise. It's derived
+// from the secret, so the learner can't guess the word from its token.
+func orderToken(secret string, exercise string, wordID int) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(exercise + ":" + strconv.Itoa(wordID)))
+	return hex.EncodeToString(mac.Sum(nil)[:8])
+}
+
+// isInOrder checks whether the words are sorted by its position.
+func isInOrder(words []Word) bool {
+	for i := 1; i < len(words); i++ {
+		if words[i].Position < words[i-1].Position {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,47 @@
+package backend
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestWordOrder(t *testing.T) {
+	_, h := newTestServer(t)
+
+	var exercise OrderExercise
+	rec := doRequest(t, h, http.MethodGet, apiPrefix+"/surahs/2/ayahs/2/order", nil, &exercise)
+	expectStatus(t, rec, http.StatusOK)
+
+	if exercise.AyahID != 5 || len(exercise.Words) != 2 || exercise.Exercise == "" {
+		t.Fatalf("unexpected exercise: %+v", exercise)
+	}
+
+	// Submit the words in its original order
+	positions := map[string]int{"ذَٰلِكَ": 0, "الْكِتَابُ": 1}
+	tokens := make([]string, len(exercise.Words))
+	for _, word := range exercise.Words {
+		tokens[positions[word.Arabic]] = word.Token
+	}
+	submission := OrderSubmission{Exercise: exercise.Exercise, Tokens: tokens, Duration: 1500}
+
+	var result OrderResult
+	rec = doRequest(t, h, http.MethodPost, apiPrefix+"/surahs/2/ayahs/2/order", submission, &result)
+	expectStatus(t, rec, http.StatusOK)
+
+	if !result.Correct || result.Mistakes != 0 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+
+	// Tokens are only valid within its own exercise
+	submission.Exercise = "other"
+	rec = doRequest(t, h, http.MethodPost, apiPrefix+"/surahs/2/ayahs/2/order", submission, nil)
+	expectStatus(t, rec, http.StatusBadRequest)
+
+	var stats []OrderStat
+	rec = doRequest(t, h, http.MethodGet, apiPrefix+"/order-stats?surah=2", nil, &stats)
+	expectStatus(t, rec, http.StatusOK)
+
+	if len(stats) != 1 || stats[0].Ayah != 2 || !stats[0].Solved || stats[0].TotalDuration != 1500 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+}
@@ -0,0 +1,275 @@
+package backend
+
+import (
+	"encoding/base64"
+	"fmt"
+	"html/template"
+	"io"
+	"io/fs"
+	"io/ioutil"
+	"kalimah/internal/store"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// Mode of worksheet.
+const (
+	WorksheetBlank  = "blank"
+	WorksheetChoice = "choice"
+)
+
+const (
+	worksheetTemplate   = "worksheet.html"
+	worksheetArabicFont = "res/fonts/KFGQPC-HAFS.woff2"
+
+	// defaultWorksheetChoices is fewer than the quiz in app, to keep it fit in paper.
+	defaultWorksheetChoices = 4
+	maxWorksheetChoices     = 8
+)
+
+// WorksheetOptions is the options for rendering printable worksheet.
+type WorksheetOptions struct {
+	// FirstAyah and LastAyah is the absolute ID of ayah, i.e. 1-6236.
+	FirstAyah int
+	LastAyah  int
+
+	Mode        string
+	ChoiceCount int
+
+	// Seed is the seed for generating choices, so the worksheet has the same
+	// choices as the quiz in app with the same seed.
+	Seed uint64
+
+	// FontURL is the URL of Arabic font. If empty, the font will be embedded
+	// into the page so the worksheet can be opened as standalone file.
+	FontURL string
+}
+
+type worksheetPage struct {
+	Title     string
+	Mode      string
+	FontURL   template.URL
+	Sections  []worksheetSection
+	AnswerKey []worksheetWord
+}
+
+type worksheetSection struct {
+	Surah int
+	Ayah  int
+	Words []worksheetWord
+}
+
+type worksheetWord struct {
+	Word
+	Surah        int
+	Number       int
+	AnswerLetter string
+}
+
+type worksheetWordRow struct {
+	Word
+	Surah     int
+	SurahName string
+}
+
+// RenderWorksheet renders words within the ayah range as printable HTML, along
+// with the answer key in a separate page.
+func RenderWorksheet(st store.Store, assets fs.FS, w io.Writer, opts WorksheetOptions) error {
+	// Validate options
+	switch opts.Mode {
+	case WorksheetBlank, WorksheetChoice:
+	default:
+		return badRequest("mode must be %s or %s, got %q", WorksheetBlank, WorksheetChoice, opts.Mode)
+	}
+
+	if opts.ChoiceCount == 0 {
+		opts.ChoiceCount = defaultWorksheetChoices
+	}
+
+	if opts.ChoiceCount < 2 || opts.ChoiceCount > maxWorksheetChoices {
+		return badRequest("choice count must be between 2 and %d, got %d", maxWorksheetChoices, opts.ChoiceCount)
+	}
+
+	if opts.FirstAyah <= 0 || opts.LastAyah < opts.FirstAyah {
+		return badRequest("invalid ayah range %d-%d", opts.FirstAyah, opts.LastAyah)
+	}
+
+	// Fetch words
+	storeWords, err := st.Words(opts.FirstAyah, opts.LastAyah)
+	if err != nil {
+		return err
+	}
+
+	if len(storeWords) == 0 {
+		return badRequest("no words within ayah %d-%d", opts.FirstAyah, opts.LastAyah)
+	}
+
+	surahs, err := st.Surahs()
+	if err != nil {
+		return err
+	}
+
+	surahNames := make(map[int]string, len(surahs))
+	for _, surah := range surahs {
+		surahNames[surah.ID] = surah.Name
+	}
+
+	words := locateWords(surahs, storeWords)
+	rows := make([]worksheetWordRow, len(words))
+	for i, word := range words {
+		rows[i] = worksheetWordRow{Word: word, Surah: word.Surah, SurahName: surahNames[word.Surah]}
+	}
+
+	// Generate choices the same way as the quiz in app
+	if opts.Mode == WorksheetChoice {
+		counts := make([]int, len(words))
+		for i := range counts {
+			counts[i] = opts.ChoiceCount
+		}
+
+		err = applySeededChoices(st, words, counts, opts.Seed, choiceTranslation)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Group the words by its ayah
+	page := worksheetPage{
+		Title: worksheetTitle(rows),
+		Mode:  opts.Mode,
+	}
+
+	for i, word := range words {
+		row := rows[i]
+		wsWord := worksheetWord{Word: word, Surah: row.Surah, Number: i + 1}
+		for j, choice := range word.Choices {
+			if choice.IsCorrect {
+				wsWord.AnswerLetter = choiceLetter(j)
+			}
+		}
+
+		nSection := len(page.Sections)
+		if nSection == 0 || page.Sections[nSection-1].Surah != row.Surah ||
+			page.Sections[nSection-1].Ayah != row.Ayah {
+			page.Sections = append(page.Sections, worksheetSection{Surah: row.Surah, Ayah: row.Ayah})
+			nSection++
+		}
+
+		page.Sections[nSection-1].Words = append(page.Sections[nSection-1].Words, wsWord)
+		page.AnswerKey = append(page.AnswerKey, wsWord)
+	}
+
+	// Prepare Arabic font
+	if opts.FontURL != "" {
+		page.FontURL = template.URL(opts.FontURL)
+	} else {
+		font, err := readAssetFile(assets, worksheetArabicFont)
+		if err != nil {
+			return fmt.Errorf("failed to read Arabic font: %w", err)
+		}
+		page.FontURL = template.URL("data:font/woff2;base64," + base64.StdEncoding.EncodeToString(font))
+	}
+
+	// Render the page
+	tplText, err := readAssetFile(assets, worksheetTemplate)
+	if err != nil {
+		return fmt.Errorf("failed to read worksheet template: %w", err)
+	}
+
+	tpl, err := template.New(worksheetTemplate).Parse(string(tplText))
+	if err != nil {
+		return err
+	}
+
+	return tpl.Execute(w, &page)
+}
+
+// ServeWorksheet serves the printable worksheet, as alternative for PDF.
+func (s *Server) ServeWorksheet(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	var err error
+	defer func() {
+		if err != nil {
+			writeError(w, r, err)
+		}
+	}()
+
+	// Parse query params
+	query := r.URL.Query()
+	surah, err := parseSurah(query.Get("surah"))
+	if err != nil {
+		return
+	}
+
+	firstAyah, lastAyah, err := parseAyahRange(s.Store, surah, query.Get("ayah"))
+	if err != nil {
+		return
+	}
+
+	mode := query.Get("mode")
+	if mode == "" {
+		mode = WorksheetChoice
+	}
+
+	var nChoices int
+	if param := query.Get("choices"); param != "" {
+		if nChoices, err = strconv.Atoi(param); err != nil {
+			err = badRequest("choices must be a number, got %q", param)
+			return
+		}
+	}
+
+	seed, err := s.choiceSeed(r, time.Now())
+	if err != nil {
+		return
+	}
+
+	// Render worksheet into buffer first, so error can still be reported properly
+	var sb strings.Builder
+	err = RenderWorksheet(s.Store, s.Assets, &sb, WorksheetOptions{
+		FirstAyah:   firstAyah,
+		LastAyah:    lastAyah,
+		Mode:        mode,
+		ChoiceCount: nChoices,
+		Seed:        seed,
+		FontURL:     s.BasePath + "/" + worksheetArabicFont,
+	})
+	if err != nil {
+		return
+	}
+
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, err = io.WriteString(w, sb.String())
+}
+
+// worksheetTitle returns the title for worksheet, e.g. "Al-Ikhlas 1-4".
+func worksheetTitle(rows []worksheetWordRow) string {
+	first, last := rows[0], rows[len(rows)-1]
+	if first.Surah == last.Surah && first.Ayah == last.Ayah {
+		return fmt.Sprintf("%s %d", first.SurahName, first.Ayah)
+	} else if first.Surah == last.Surah {
+		return fmt.Sprintf("%s %d-%d", first.SurahName, first.Ayah, last.Ayah)
+	}
+	return fmt.Sprintf("%s %d - %s %d", first.SurahName, first.Ayah, last.SurahName, last.Ayah)
+}
+
+// choiceLetter returns the letter for the n-th choice, e.g. A for the first one.
+func choiceLetter(n int) string {
+	return string(rune('A' + n))
+}
+
+// readAssetFile reads the entire content of file in assets. It's used instead of
+// fs.ReadFile, since the embedded assets has ReadFile method that ignores its sub path.
+func readAssetFile(assets fs.FS, name string) ([]byte, error) {
+	f, err := assets.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ioutil.ReadAll(f)
+}
@@ -0,0 +1,206 @@
+package cmd
+
+import (
+	"database/sql"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	fp "path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var (
+	rxReciterName = regexp.MustCompile(`^[a-z0-9][a-z0-9_.-]*$`)
+	rxAudioFile   = regexp.MustCompile(`(\d+)\D+(\d+)\D+(\d+)\.(mp3|ogg|opus|m4a|wav)$`)
+)
+
+func audioCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "audio",
+		Short: "Manage the word recitations, or print the reciters when used without subcommand",
+		Args:  cobra.NoArgs,
+		RunE:  audioCmdHandler,
+	}
+
+	importCmd := &cobra.Command{
+		Use:   "import <dir>",
+		Short: "Import the recitation of each word from files named <surah>_<ayah>_<position>.<ext>",
+		Args:  cobra.ExactArgs(1),
+		RunE:  audioImportCmdHandler,
+	}
+	importCmd.Flags().String("reciter", "", "Name of the reciter, e.g. alafasy")
+	importCmd.MarkFlagRequired("reciter")
+
+	cmd.AddCommand(importCmd, &cobra.Command{
+		Use:   "remove <reciter>",
+		Short: "Remove the imported recitations of a reciter",
+		Args:  cobra.ExactArgs(1),
+		RunE:  audioRemoveCmdHandler,
+	})
+
+	return cmd
+}
+
+func audioCmdHandler(cmd *cobra.Command, args []string) error {
+	var reciters []struct {
+		Name      string `db:"reciter"`
+		WordCount int    `db:"word_count"`
+	}
+
+	err := db.Select(&reciters,
+		`SELECT reciter, COUNT(*) word_count FROM word_audio
+		GROUP BY reciter ORDER BY reciter`)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "RECITER\tWORDS")
+	for _, reciter := range reciters {
+		fmt.Fprintf(w, "%s\t%d\n", reciter.Name, reciter.WordCount)
+	}
+
+	return w.Flush()
+}
+
+func audioImportCmdHandler(cmd *cobra.Command, args []string) error {
+	// Get flags value
+	srcDir := args[0]
+	reciter, _ := cmd.Flags().GetString("reciter")
+	reciter = strings.ToLower(strings.TrimSpace(reciter))
+	if !rxReciterName.MatchString(reciter) {
+		return fmt.Errorf("reciter name must only contain lowercase letters, digits, dot, "+
+			"dash or underscore, got %q", reciter)
+	}
+
+	// Prepare destination
+	dstDir := fp.Join(audioDir(), reciter)
+	if err := os.MkdirAll(dstDir, os.ModePerm); err != nil {
+		return err
+	}
+
+	// Prepare transaction, so the audio is either all or none registered
+	tx, err := db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Preparex(
+		`SELECT w.id FROM word w, surah s
+		WHERE s.id = ? AND w.ayah = s.start+?-1 AND w.ayah <= s.end AND w.position = ?`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	// Copy each audio file then register it
+	var nImported, nSkipped int
+	err = fp.WalkDir(srcDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		parts := rxAudioFile.FindStringSubmatch(fp.ToSlash(path))
+		if len(parts) == 0 {
+			nSkipped++
+			return nil
+		}
+
+		surah, _ := strconv.Atoi(parts[1])
+		ayah, _ := strconv.Atoi(parts[2])
+		position, _ := strconv.Atoi(parts[3])
+
+		var wordID int
+		err = stmt.Get(&wordID, surah, ayah, position)
+		if err == sql.ErrNoRows || (err == nil && ayah <= 0) {
+			logrus.Warnf("skip %s: word %d:%d:%d not exist", path, surah, ayah, position)
+			nSkipped++
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		name := strconv.Itoa(wordID) + "." + parts[4]
+		if err = copyFile(path, fp.Join(dstDir, name)); err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(`
+			INSERT INTO word_audio (reciter, word, path) VALUES (?, ?, ?)
+			ON CONFLICT (reciter, word) DO UPDATE SET path = excluded.path`,
+			reciter, wordID, reciter+"/"+name)
+		if err != nil {
+			return err
+		}
+
+		nImported++
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to import audio: %w", err)
+	}
+
+	if nImported == 0 {
+		return fmt.Errorf("no audio named <surah>_<ayah>_<position>.<ext> found in %s", srcDir)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return err
+	}
+
+	logrus.Printf("imported %d audio for reciter %s, %d files skipped", nImported, reciter, nSkipped)
+	return nil
+}
+
+func audioRemoveCmdHandler(cmd *cobra.Command, args []string) error {
+	reciter := strings.ToLower(strings.TrimSpace(args[0]))
+	if !rxReciterName.MatchString(reciter) {
+		return fmt.Errorf("reciter %q not exist", args[0])
+	}
+
+	res, err := db.Exec(`DELETE FROM word_audio WHERE reciter = ?`, reciter)
+	if err != nil {
+		return err
+	}
+
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("reciter %q not exist", args[0])
+	}
+
+	return os.RemoveAll(fp.Join(audioDir(), reciter))
+}
+
+// audioDir returns the directory where the imported recitations are stored,
+// which placed beside the database file.
+func audioDir() string {
+	return fp.Join(dataDir(), "audio")
+}
+
+// copyFile copies the file content from src into dst, replacing the existing one.
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+
+	if _, err = io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+
+	return out.Close()
+}
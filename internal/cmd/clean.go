@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"kalimah/internal/database"
 	"os"
 
 	"github.com/spf13/cobra"
@@ -15,14 +16,14 @@ func cleanCmd() *cobra.Command {
 }
 
 func cleanCmdHandler(cmd *cobra.Command, args []string) error {
+	// PostgreSQL database is not a file, so drop its tables instead
+	if database.DialectOfDB(db) == database.Postgres {
+		return database.DropTables(db)
+	}
+
 	// Close database
 	db.Close()
 
 	// Remove files
-	dbPath, err := getDBPath()
-	if err != nil {
-		return err
-	}
-
-	return os.RemoveAll(dbPath)
+	return os.RemoveAll(cfg.DBPath)
 }
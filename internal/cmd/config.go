@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"fmt"
+	"kalimah/internal/config"
+	"kalimah/internal/database"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+func configCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Manage the configuration",
+
+		// Config doesn't need database, so only load the config
+		PersistentPreRunE:  loadConfig,
+		PersistentPostRunE: func(*cobra.Command, []string) error { return nil },
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "show",
+		Short: "Show the effective config and where each value comes from",
+		Args:  cobra.NoArgs,
+		RunE:  configShowCmdHandler,
+	})
+
+	return cmd
+}
+
+func configShowCmdHandler(cmd *cobra.Command, args []string) error {
+	fmt.Printf("# config file: %s\n", cfg.Path())
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, key := range cfg.Keys() {
+		source := string(cfg.Source(key))
+		switch cfg.Source(key) {
+		case config.SourceEnv:
+			source += " " + config.EnvKey(key)
+		case config.SourceFlag:
+			source += " --" + sourceFlagName(cmd, key)
+		}
+
+		// Only quote non-numeric value, so the output can be used as config file
+		value := cfg.Get(key)
+		if key == "db_path" && database.DialectOf(value) == database.Postgres {
+			value = redactDSN(value)
+		}
+
+		if _, err := strconv.Atoi(value); err != nil {
+			value = strconv.Quote(value)
+		}
+
+		// Don't leak the secret into terminal
+		if key == "admin_token" && cfg.AdminToken != "" {
+			value = `"********"`
+		}
+
+		fmt.Fprintf(w, "%s = %s\t# %s\n", key, value, source)
+	}
+
+	return w.Flush()
+}
+
+// sourceFlagName returns the name of flag that set the config key. The database is
+// chosen by --db or --profile flag instead of the one named after its key.
+func sourceFlagName(cmd *cobra.Command, key string) string {
+	if key == "db_path" {
+		if profile, _ := cmd.Flags().GetString("profile"); profile != "" {
+			return "profile"
+		}
+		return "db"
+	}
+	return config.FlagName(key)
+}
+
+// loadConfig loads config from default values, config file, environment
+// variables and flags, where the latter overrides the former.
+func loadConfig(cmd *cobra.Command, args []string) error {
+	// Prepare default config
+	dbPath, err := getDBPath()
+	if err != nil {
+		return fmt.Errorf("failed to get database path: %w", err)
+	}
+
+	// Get config path
+	configPath, _ := cmd.Flags().GetString("config")
+	if configPath == "" {
+		configPath, err = getConfigPath()
+		if err != nil {
+			return fmt.Errorf("failed to get config path: %w", err)
+		}
+	}
+
+	// Load config
+	cfg, err = config.Load(configPath, config.Default(dbPath))
+	if err != nil {
+		return err
+	}
+
+	err = cfg.ApplyFlags(cmd.Flags())
+	if err != nil {
+		return err
+	}
+
+	// Configure logger
+	if cfg.LogFormat == "json" {
+		logrus.SetFormatter(&logrus.JSONFormatter{
+			TimestampFormat: "2006-01-02 15:04:05",
+		})
+	}
+
+	logLevel, _ := logrus.ParseLevel(cfg.LogLevel)
+	logrus.SetLevel(logLevel)
+
+	// Resolve the database from profile or path flag
+	dbFlag, _ := cmd.Flags().GetString("db")
+	profile, _ := cmd.Flags().GetString("profile")
+
+	switch {
+	case dbFlag != "" && profile != "":
+		return fmt.Errorf("--db and --profile can't be used together")
+	case dbFlag != "":
+		cfg.DBPath = dbFlag
+		cfg.SetSource("db_path", config.SourceFlag)
+	case profile != "":
+		cfg.DBPath, err = profileDBPath(dbPath, profile)
+		if err != nil {
+			return err
+		}
+		cfg.SetSource("db_path", config.SourceFlag)
+	}
+
+	return nil
+}
@@ -0,0 +1,186 @@
+package cmd
+
+import (
+	"fmt"
+	"kalimah/internal/database"
+	"net/url"
+	"os"
+	fp "path/filepath"
+	"regexp"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/spf13/cobra"
+)
+
+var rxProfileName = regexp.MustCompile(`^[\w-]+$`)
+
+func dbCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "db",
+		Short: "Manage the databases",
+
+		// Listing database doesn't need the active database opened
+		PersistentPreRunE:  loadConfig,
+		PersistentPostRunE: func(*cobra.Command, []string) error { return nil },
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "list",
+		Short: "List known databases along with its size and progress",
+		Args:  cobra.NoArgs,
+		RunE:  dbListCmdHandler,
+	})
+
+	return cmd
+}
+
+func dbListCmdHandler(cmd *cobra.Command, args []string) error {
+	// Collect known databases
+	defaultPath, err := getDBPath()
+	if err != nil {
+		return fmt.Errorf("failed to get database path: %w", err)
+	}
+
+	type dbEntry struct {
+		Name string
+		Path string
+	}
+
+	entries := []dbEntry{{"(default)", defaultPath}}
+	profilePaths, err := fp.Glob(fp.Join(profileDir(defaultPath), "*.db"))
+	if err != nil {
+		return err
+	}
+
+	for _, path := range profilePaths {
+		name := strings.TrimSuffix(fp.Base(path), ".db")
+		entries = append(entries, dbEntry{name, path})
+	}
+
+	// If active database is outside of the known location, list it as well
+	activeIsKnown := false
+	for _, entry := range entries {
+		if samePath(entry.Path, cfg.DBPath) {
+			activeIsKnown = true
+			break
+		}
+	}
+
+	if !activeIsKnown {
+		entries = append(entries, dbEntry{"(custom)", cfg.DBPath})
+	}
+
+	// Print each database
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "\tNAME\tSIZE\tPROGRESS\tPATH")
+	for _, entry := range entries {
+		active := ""
+		if samePath(entry.Path, cfg.DBPath) {
+			active = "*"
+		}
+
+		size, progress, path := "-", "-", entry.Path
+		if database.DialectOf(entry.Path) == database.Postgres {
+			progress = readProgress(database.Postgres, entry.Path)
+			path = redactDSN(entry.Path)
+		} else if info, err := os.Stat(entry.Path); err == nil {
+			size = formatSize(info.Size())
+			progress = readProgress(database.SQLite, entry.Path)
+		} else {
+			progress = "not created"
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", active, entry.Name, size, progress, path)
+	}
+
+	return w.Flush()
+}
+
+// profileDir returns the directory where the profile databases are stored.
+func profileDir(defaultDBPath string) string {
+	return fp.Join(fp.Dir(defaultDBPath), "profiles")
+}
+
+// profileDBPath returns the database path for the named profile.
+func profileDBPath(defaultDBPath string, profile string) (string, error) {
+	if !rxProfileName.MatchString(profile) {
+		return "", fmt.Errorf("profile name %q may only contain letters, numbers, dash and underscore", profile)
+	}
+	return fp.Join(profileDir(defaultDBPath), profile+".db"), nil
+}
+
+// readProgress returns the learning progress that saved in database.
+func readProgress(dialect database.Dialect, dsn string) string {
+	if dialect == database.SQLite {
+		q := url.Values{}
+		q.Add("mode", "ro")
+		dsn = "file:" + dsn + "?" + q.Encode()
+	}
+
+	rdb, err := sqlx.Open(string(dialect), dsn)
+	if err != nil {
+		return "unreadable"
+	}
+	defer rdb.Close()
+
+	var progress struct {
+		LastWord   int `db:"last_word"`
+		TotalWords int `db:"total_words"`
+		Surah      int `db:"surah"`
+		Ayah       int `db:"ayah"`
+	}
+
+	err = rdb.Get(&progress,
+		`WITH last_word AS (
+			SELECT COALESCE(MAX(last_word), 0) id FROM tracker WHERE id = 1)
+		SELECT lw.id last_word, (SELECT COUNT(*) FROM word) total_words,
+			COALESCE(s.id, 0) surah, COALESCE(w.ayah-s.start+1, 0) ayah
+		FROM last_word lw
+		LEFT JOIN word w ON w.id = lw.id
+		LEFT JOIN surah s ON w.ayah >= s.start AND w.ayah <= s.end`)
+	if err != nil || progress.TotalWords == 0 {
+		return "not initialized"
+	}
+
+	if progress.LastWord == 0 {
+		return "not started"
+	}
+
+	percentage := float64(progress.LastWord) / float64(progress.TotalWords) * 100
+	return fmt.Sprintf("%d:%d (%.1f%%)", progress.Surah, progress.Ayah, percentage)
+}
+
+// redactDSN hides the password in PostgreSQL URL, so it's safe to be printed.
+func redactDSN(dsn string) string {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return dsn
+	}
+	return u.Redacted()
+}
+
+func samePath(a, b string) bool {
+	absA, errA := fp.Abs(a)
+	absB, errB := fp.Abs(b)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+	return absA == absB
+}
+
+func formatSize(size int64) string {
+	units := []string{"B", "KB", "MB", "GB"}
+	value := float64(size)
+	unit := 0
+	for value >= 1024 && unit < len(units)-1 {
+		value /= 1024
+		unit++
+	}
+
+	if unit == 0 {
+		return fmt.Sprintf("%d %s", size, units[unit])
+	}
+	return fmt.Sprintf("%.1f %s", value, units[unit])
+}
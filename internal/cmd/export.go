@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"kalimah/internal/database"
+	"kalimah/internal/export"
+	"os"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+func exportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export the data into other format",
+	}
+
+	cmd.AddCommand(exportAnkiCmd(), exportCorpusCmd())
+	return cmd
+}
+
+func exportAnkiCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "anki",
+		Short: "Export words or ayahs as Anki package",
+		Args:  cobra.NoArgs,
+		RunE:  exportAnkiCmdHandler,
+	}
+
+	cmd.Flags().String("surah", "1-114", "Range of surah or ayah to export, e.g. 112, 78-114 or 2:1-2:50")
+	cmd.Flags().String("note", export.AnkiWordNote, "Kind of note, either word or ayah")
+	cmd.Flags().String("deck", "", "Name of the deck, default to Kalimah::<range>")
+	cmd.Flags().Bool("only-learned", false, "Only export the words or ayahs that already learned")
+	cmd.Flags().StringP("output", "o", "", "Path to the output .apkg file")
+	cmd.MarkFlagRequired("output")
+	return cmd
+}
+
+func exportAnkiCmdHandler(cmd *cobra.Command, args []string) error {
+	// Get flags value
+	surahRange, _ := cmd.Flags().GetString("surah")
+	noteKind, _ := cmd.Flags().GetString("note")
+	deckName, _ := cmd.Flags().GetString("deck")
+	onlyLearned, _ := cmd.Flags().GetBool("only-learned")
+	output, _ := cmd.Flags().GetString("output")
+
+	scope, err := parseScope(surahRange, onlyLearned)
+	if err != nil {
+		return err
+	}
+
+	if deckName == "" {
+		deckName = "Kalimah::" + surahRange
+	}
+
+	// Bundle the Arabic font so it rendered properly in Anki
+	font, err := readAsset("res/fonts/KFGQPC-HAFS.woff2")
+	if err != nil {
+		logrus.Warnf("failed to read Arabic font, it will not be bundled: %v", err)
+	}
+
+	// Export the package
+	err = export.ExportAnki(db, output, export.AnkiOptions{
+		Scope:      scope,
+		NoteKind:   noteKind,
+		DeckName:   deckName,
+		ArabicFont: font,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to export Anki package: %w", err)
+	}
+
+	logrus.Printf("Anki package exported to %s", output)
+	return nil
+}
+
+func exportCorpusCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "corpus",
+		Short: "Export words, ayahs or surahs as CSV, TSV or JSONL",
+		Args:  cobra.NoArgs,
+		RunE:  exportCorpusCmdHandler,
+	}
+
+	cmd.Flags().String("format", export.FormatCSV, "Output format, either csv, tsv or jsonl")
+	cmd.Flags().String("level", export.LevelWord, "What each row represents, either word, ayah or surah")
+	cmd.Flags().String("range", "1-114", "Range of surah or ayah to export, e.g. 112, 78-114 or 2:1-2:50")
+	cmd.Flags().String("lang", "", "Language of translation, default to the one used in database")
+	cmd.Flags().Bool("tafsir", false, "Include tafsir in ayah level")
+	cmd.Flags().Bool("only-learned", false, "Only export the rows that already learned")
+	cmd.Flags().Bool("bom", false, "Write UTF-8 byte order mark for CSV and TSV, useful for spreadsheet apps")
+	cmd.Flags().Bool("isolate", false, "Wrap Arabic text with Unicode directional isolates")
+	cmd.Flags().StringP("output", "o", "", "Path to the output file, default to stdout")
+	return cmd
+}
+
+func exportCorpusCmdHandler(cmd *cobra.Command, args []string) error {
+	// Get flags value
+	format, _ := cmd.Flags().GetString("format")
+	level, _ := cmd.Flags().GetString("level")
+	textRange, _ := cmd.Flags().GetString("range")
+	language, _ := cmd.Flags().GetString("lang")
+	includeTafsir, _ := cmd.Flags().GetBool("tafsir")
+	onlyLearned, _ := cmd.Flags().GetBool("only-learned")
+	writeBOM, _ := cmd.Flags().GetBool("bom")
+	isolateArabic, _ := cmd.Flags().GetBool("isolate")
+	output, _ := cmd.Flags().GetString("output")
+
+	scope, err := parseScope(textRange, onlyLearned)
+	if err != nil {
+		return err
+	}
+
+	// Database only contains a single translation, so make sure it's the requested one
+	dbLanguage, err := database.Language(db)
+	if err != nil {
+		return err
+	}
+
+	if language == "" {
+		language = dbLanguage
+	} else if language != dbLanguage {
+		return fmt.Errorf("database is populated with language \"%s\", "+
+			"run init with --language %s to switch it", dbLanguage, language)
+	}
+
+	// Prepare output
+	var w io.Writer = os.Stdout
+	if output != "" {
+		f, err := os.Create(output)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	bw := bufio.NewWriter(w)
+	err = export.ExportCorpus(db, bw, export.CorpusOptions{
+		Scope:         scope,
+		Format:        format,
+		Level:         level,
+		Language:      language,
+		IncludeTafsir: includeTafsir,
+		WriteBOM:      writeBOM,
+		IsolateArabic: isolateArabic,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to export corpus: %w", err)
+	}
+
+	if err = bw.Flush(); err != nil {
+		return err
+	}
+
+	if output != "" {
+		logrus.Printf("corpus exported to %s", output)
+	}
+	return nil
+}
+
+// readAsset reads the entire content of file in assets.
+func readAsset(name string) ([]byte, error) {
+	f, err := assets.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ioutil.ReadAll(f)
+}
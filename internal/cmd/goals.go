@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"fmt"
+	"kalimah/internal/store"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func goalsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "goals",
+		Short: "Print the daily goals along with today's progress",
+		Args:  cobra.NoArgs,
+		RunE:  goalsCmdHandler,
+	}
+
+	setCmd := &cobra.Command{
+		Use:   "set",
+		Short: "Set the daily goals, zero to disable it",
+		Args:  cobra.NoArgs,
+		RunE:  goalsSetCmdHandler,
+	}
+	setCmd.Flags().Int("words", 0, "Number of words to answer each day")
+	setCmd.Flags().Int("minutes", 0, "Minutes of learning each day")
+
+	cmd.AddCommand(setCmd)
+	return cmd
+}
+
+func goalsCmdHandler(cmd *cobra.Command, args []string) error {
+	st := store.NewSQLite(db)
+	goal, err := st.Goal()
+	if err != nil {
+		return err
+	}
+
+	nWords, active, err := st.DailyActivity(time.Now())
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "GOAL\tTODAY\tTARGET")
+	fmt.Fprintf(w, "words\t%d\t%s\n", nWords, formatGoal(goal.WordsPerDay))
+	fmt.Fprintf(w, "minutes\t%d\t%s\n", int(active/time.Minute), formatGoal(goal.MinutesPerDay))
+	return w.Flush()
+}
+
+func goalsSetCmdHandler(cmd *cobra.Command, args []string) error {
+	words, _ := cmd.Flags().GetInt("words")
+	minutes, _ := cmd.Flags().GetInt("minutes")
+
+	if words < 0 || minutes < 0 {
+		return fmt.Errorf("goals must not be negative")
+	}
+
+	// Only change the goals that explicitly set
+	st := store.NewSQLite(db)
+	goal, err := st.Goal()
+	if err != nil {
+		return err
+	}
+
+	if cmd.Flags().Changed("words") {
+		goal.WordsPerDay = words
+	}
+
+	if cmd.Flags().Changed("minutes") {
+		goal.MinutesPerDay = minutes
+	}
+
+	return st.SetGoal(goal)
+}
+
+func formatGoal(target int) string {
+	if target <= 0 {
+		return "-"
+	}
+	return fmt.Sprint(target)
+}
@@ -7,13 +7,16 @@ import (
 )
 
 func initCmd() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "init",
 		Short: "Initiate the database",
 		RunE:  initCmdHandler,
 	}
+
+	cmd.Flags().String("language", "", "Language of the translation")
+	return cmd
 }
 
 func initCmdHandler(cmd *cobra.Command, args []string) error {
-	return database.PopulateData(db)
+	return database.PopulateData(db, cfg.Language)
 }
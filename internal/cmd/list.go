@@ -0,0 +1,275 @@
+package cmd
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/spf13/cobra"
+)
+
+var rxBookmarkRef = regexp.MustCompile(`^(\d+):(\d+)(?::(\d+))?$`)
+
+func listCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "Manage the word lists, or print them when used without subcommand",
+		Args:  cobra.NoArgs,
+		RunE:  listCmdHandler,
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "create <name>",
+		Short: "Create a new word list",
+		Args:  cobra.ExactArgs(1),
+		RunE:  listCreateCmdHandler,
+	}, &cobra.Command{
+		Use:   "rename <name> <new-name>",
+		Short: "Rename a word list",
+		Args:  cobra.ExactArgs(2),
+		RunE:  listRenameCmdHandler,
+	}, &cobra.Command{
+		Use:   "delete <name>",
+		Short: "Delete a word list along with its bookmarks",
+		Args:  cobra.ExactArgs(1),
+		RunE:  listDeleteCmdHandler,
+	}, &cobra.Command{
+		Use:   "show <name>",
+		Short: "Print the bookmarked words and ayahs within a word list",
+		Args:  cobra.ExactArgs(1),
+		RunE:  listShowCmdHandler,
+	}, &cobra.Command{
+		Use:   "add <name> <surah>:<ayah>[:<position>]...",
+		Short: "Bookmark ayahs, or words when position is specified, into a word list",
+		Args:  cobra.MinimumNArgs(2),
+		RunE:  listAddCmdHandler,
+	}, &cobra.Command{
+		Use:   "remove <name> <surah>:<ayah>[:<position>]...",
+		Short: "Remove bookmarked ayahs or words from a word list",
+		Args:  cobra.MinimumNArgs(2),
+		RunE:  listRemoveCmdHandler,
+	})
+
+	return cmd
+}
+
+func listCmdHandler(cmd *cobra.Command, args []string) error {
+	var lists []struct {
+		Name      string `db:"name"`
+		WordCount int    `db:"word_count"`
+		AyahCount int    `db:"ayah_count"`
+	}
+
+	err := db.Select(&lists,
+		`SELECT l.name, COUNT(b.word) word_count, COUNT(b.ayah) ayah_count
+		FROM list l
+		LEFT JOIN bookmark b ON b.list = l.id
+		GROUP BY l.id
+		ORDER BY l.name`)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tWORDS\tAYAHS")
+	for _, list := range lists {
+		fmt.Fprintf(w, "%s\t%d\t%d\n", list.Name, list.WordCount, list.AyahCount)
+	}
+
+	return w.Flush()
+}
+
+func listCreateCmdHandler(cmd *cobra.Command, args []string) error {
+	name := strings.TrimSpace(args[0])
+	if name == "" {
+		return fmt.Errorf("list name must not be empty")
+	}
+
+	if _, err := findListID(db, name); err == nil {
+		return fmt.Errorf("list %q already exists", name)
+	}
+
+	_, err := db.Exec(`INSERT INTO list (name, created_at) VALUES (?, ?)`, name, time.Now().Unix())
+	return err
+}
+
+func listRenameCmdHandler(cmd *cobra.Command, args []string) error {
+	listID, err := findListID(db, args[0])
+	if err != nil {
+		return err
+	}
+
+	newName := strings.TrimSpace(args[1])
+	if newName == "" {
+		return fmt.Errorf("list name must not be empty")
+	}
+
+	if otherID, err := findListID(db, newName); err == nil && otherID != listID {
+		return fmt.Errorf("list %q already exists", newName)
+	}
+
+	_, err = db.Exec(`UPDATE list SET name = ? WHERE id = ?`, newName, listID)
+	return err
+}
+
+func listDeleteCmdHandler(cmd *cobra.Command, args []string) error {
+	listID, err := findListID(db, args[0])
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Exec(`DELETE FROM list WHERE id = ?`, listID)
+	return err
+}
+
+func listShowCmdHandler(cmd *cobra.Command, args []string) error {
+	listID, err := findListID(db, args[0])
+	if err != nil {
+		return err
+	}
+
+	var bookmarks []struct {
+		Surah       int    `db:"surah"`
+		Ayah        int    `db:"ayah"`
+		Position    int    `db:"position"`
+		Arabic      string `db:"arabic"`
+		Translation string `db:"translation"`
+	}
+
+	err = db.Select(&bookmarks,
+		`SELECT s.id surah, a.id-s.start+1 ayah, COALESCE(w.position, 0) AS position,
+			COALESCE(w.arabic, (SELECT STRING_AGG(arabic, ' ') FROM
+				(SELECT arabic FROM word WHERE ayah = a.id ORDER BY position) aw)) arabic,
+			COALESCE(w.translation, a.translation) translation
+		FROM bookmark b
+		LEFT JOIN word w ON w.id = b.word
+		JOIN ayah a ON a.id = COALESCE(b.ayah, w.ayah)
+		JOIN surah s ON a.id >= s.start AND a.id <= s.end
+		WHERE b.list = ?
+		ORDER BY a.id, position`, listID)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "REF\tARABIC\tTRANSLATION")
+	for _, b := range bookmarks {
+		ref := fmt.Sprintf("%d:%d", b.Surah, b.Ayah)
+		if b.Position > 0 {
+			ref += ":" + strconv.Itoa(b.Position)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", ref, b.Arabic, b.Translation)
+	}
+
+	return w.Flush()
+}
+
+func listAddCmdHandler(cmd *cobra.Command, args []string) error {
+	// Prepare transaction, so either all or none of the refs are bookmarked
+	tx, err := db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	listID, err := findListID(tx, args[0])
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().Unix()
+	for _, ref := range args[1:] {
+		column, id, err := parseBookmarkRef(tx, ref)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(`INSERT INTO bookmark (list, `+column+`, created_at)
+			VALUES (?, ?, ?) ON CONFLICT DO NOTHING`, listID, id, now)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func listRemoveCmdHandler(cmd *cobra.Command, args []string) error {
+	tx, err := db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	listID, err := findListID(tx, args[0])
+	if err != nil {
+		return err
+	}
+
+	for _, ref := range args[1:] {
+		column, id, err := parseBookmarkRef(tx, ref)
+		if err != nil {
+			return err
+		}
+
+		res, err := tx.Exec(`DELETE FROM bookmark WHERE list = ? AND `+column+` = ?`, listID, id)
+		if err != nil {
+			return err
+		}
+
+		if n, _ := res.RowsAffected(); n == 0 {
+			return fmt.Errorf("%s is not bookmarked in list %q", ref, args[0])
+		}
+	}
+
+	return tx.Commit()
+}
+
+// findListID returns the ID of word list with the specified name.
+func findListID(q sqlx.Queryer, name string) (int, error) {
+	var id int
+	err := sqlx.Get(q, &id, `SELECT id FROM list WHERE name = ?`, strings.TrimSpace(name))
+	if err == sql.ErrNoRows {
+		return 0, fmt.Errorf("list %q not exist", name)
+	}
+	return id, err
+}
+
+// parseBookmarkRef parses reference to an ayah (`2:255`) or a word within
+// ayah (`2:255:3`), then returns the bookmark column and ID for it.
+func parseBookmarkRef(q sqlx.Queryer, ref string) (string, int, error) {
+	parts := rxBookmarkRef.FindStringSubmatch(ref)
+	if len(parts) == 0 {
+		return "", 0, fmt.Errorf("%q is not in <surah>:<ayah>[:<position>] format", ref)
+	}
+
+	surah, _ := strconv.Atoi(parts[1])
+	ayah, _ := strconv.Atoi(parts[2])
+	position, _ := strconv.Atoi(parts[3])
+
+	var id int
+	var err error
+	column := "ayah"
+	if position == 0 {
+		err = sqlx.Get(q, &id,
+			`SELECT start+?-1 FROM surah WHERE id = ? AND start+?-1 <= "end"`,
+			ayah, surah, ayah)
+	} else {
+		column = "word"
+		err = sqlx.Get(q, &id,
+			`SELECT w.id FROM word w, surah s
+			WHERE s.id = ? AND w.ayah = s.start+?-1 AND w.ayah <= s.end AND w.position = ?`,
+			surah, ayah, position)
+	}
+
+	if err == sql.ErrNoRows || (err == nil && ayah <= 0) {
+		return "", 0, fmt.Errorf("%s not exist", ref)
+	}
+	return column, id, err
+}
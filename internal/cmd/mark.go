@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"kalimah/internal/store"
 	"regexp"
 	"strconv"
 
@@ -19,56 +20,34 @@ func markCmd() *cobra.Command {
 	}
 }
 
-func markCmdHandler(cmd *cobra.Command, args []string) (err error) {
+func markCmdHandler(cmd *cobra.Command, args []string) error {
 	// Parse args
 	parts := rxSurahAyah.FindStringSubmatch(args[0])
 	if len(parts) == 0 {
 		return fmt.Errorf("argument %q is not in <surah>:<ayah> format", args[0])
 	}
 
-	surah, _ := strconv.ParseInt(parts[1], 10, 64)
-	ayah, _ := strconv.ParseInt(parts[2], 10, 64)
+	surah, _ := strconv.Atoi(parts[1])
+	ayah, _ := strconv.Atoi(parts[2])
 
-	// Prepare transaction
-	tx, err := db.Beginx()
-	if err != nil {
-		return
+	// Fetch the last word of the ayah
+	st := store.NewSQLite(db)
+	surahData, err := st.Surah(surah)
+	if err == store.ErrNotFound || (err == nil && (ayah < 1 || surahData.Start+ayah-1 > surahData.End)) {
+		return fmt.Errorf("surah %d ayah %d not exist", surah, ayah)
+	} else if err != nil {
+		return err
 	}
 
-	// Make sure to rollback if error ever happened
-	defer func() {
-		if err != nil {
-			tx.Rollback()
-		}
-	}()
-
-	// Fetch the last word ID
-	var lastWordID int
-	err = tx.Get(&lastWordID,
-		`WITH last_ayah AS (
-			SELECT ?+start-1 ayah
-			FROM surah WHERE id = ?)
-		SELECT IFNULL(MAX(w.id), 0) last_word 
-		FROM word w
-		CROSS JOIN last_ayah la
-		WHERE w.ayah = la.ayah`, ayah, surah)
+	words, err := st.AyahWords(surahData.Start + ayah - 1)
 	if err != nil {
-		return
+		return err
 	}
 
-	if lastWordID <= 0 {
+	if len(words) == 0 {
 		return fmt.Errorf("surah %d ayah %d not exist", surah, ayah)
 	}
 
 	// Save to track
-	_, err = tx.Exec(
-		`INSERT INTO tracker (id, last_word) VALUES (1, ?) 
-		ON CONFLICT DO UPDATE SET last_word = excluded.last_word`,
-		lastWordID)
-	if err != nil {
-		return err
-	}
-
-	// Commit
-	return tx.Commit()
+	return st.SetLastWord(words[len(words)-1].ID)
 }
@@ -0,0 +1,202 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"kalimah/internal/database"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+func overridesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "overrides",
+		Short: "Review the gloss suggestions and manage the accepted overrides",
+	}
+
+	acceptCmd := &cobra.Command{
+		Use:   "accept <id>",
+		Short: "Accept a pending gloss suggestion and save it as override",
+		Args:  cobra.ExactArgs(1),
+		RunE:  overridesAcceptCmdHandler,
+	}
+	acceptCmd.Flags().String("translation", "", "Use this translation instead of the suggested one")
+
+	exportCmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export the accepted overrides as JSON",
+		Args:  cobra.NoArgs,
+		RunE:  overridesExportCmdHandler,
+	}
+	exportCmd.Flags().String("format", "map", "Either map (word ID to translation, like the word data) or detail")
+	exportCmd.Flags().String("lang", "", "Language of the overrides, default to the one used in database")
+	exportCmd.Flags().StringP("output", "o", "", "Path to the output file, default to stdout")
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "pending",
+		Short: "Print the gloss suggestions that waiting for review",
+		Args:  cobra.NoArgs,
+		RunE:  overridesPendingCmdHandler,
+	}, acceptCmd, &cobra.Command{
+		Use:   "reject <id>",
+		Short: "Reject a pending gloss suggestion",
+		Args:  cobra.ExactArgs(1),
+		RunE:  overridesRejectCmdHandler,
+	}, &cobra.Command{
+		Use:   "remove <word-id> [language]",
+		Short: "Remove the override of a word and restore its original translation",
+		Args:  cobra.RangeArgs(1, 2),
+		RunE:  overridesRemoveCmdHandler,
+	}, exportCmd)
+
+	return cmd
+}
+
+func overridesPendingCmdHandler(cmd *cobra.Command, args []string) error {
+	var suggestions []struct {
+		ID          int    `db:"id"`
+		Surah       int    `db:"surah"`
+		Ayah        int    `db:"ayah"`
+		Position    int    `db:"position"`
+		Language    string `db:"language"`
+		Current     string `db:"current"`
+		Translation string `db:"translation"`
+		Reason      string `db:"reason"`
+	}
+
+	err := db.Select(&suggestions,
+		`SELECT g.id, s.id surah, w.ayah-s.start+1 ayah, w.position, g.language,
+			w.translation AS current, g.translation, g.reason
+		FROM gloss_suggestion g
+		JOIN word w ON w.id = g.word
+		JOIN surah s ON w.ayah >= s.start AND w.ayah <= s.end
+		WHERE g.status = 'pending'
+		ORDER BY g.id`)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tREF\tLANG\tCURRENT\tSUGGESTED\tREASON")
+	for _, s := range suggestions {
+		fmt.Fprintf(w, "%d\t%d:%d:%d\t%s\t%s\t%s\t%s\n", s.ID, s.Surah, s.Ayah, s.Position,
+			s.Language, s.Current, s.Translation, s.Reason)
+	}
+
+	return w.Flush()
+}
+
+func overridesAcceptCmdHandler(cmd *cobra.Command, args []string) error {
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("suggestion id must be a number, got %q", args[0])
+	}
+
+	translation, _ := cmd.Flags().GetString("translation")
+	return database.AcceptSuggestion(db, id, translation)
+}
+
+func overridesRejectCmdHandler(cmd *cobra.Command, args []string) error {
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("suggestion id must be a number, got %q", args[0])
+	}
+
+	return database.RejectSuggestion(db, id)
+}
+
+func overridesRemoveCmdHandler(cmd *cobra.Command, args []string) error {
+	wordID, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("word id must be a number, got %q", args[0])
+	}
+
+	var language string
+	if len(args) > 1 {
+		language = args[1]
+	} else if language, err = database.Language(db); err != nil {
+		return err
+	}
+
+	return database.RemoveOverride(db, wordID, language)
+}
+
+func overridesExportCmdHandler(cmd *cobra.Command, args []string) error {
+	// Get flags value
+	format, _ := cmd.Flags().GetString("format")
+	language, _ := cmd.Flags().GetString("lang")
+	output, _ := cmd.Flags().GetString("output")
+
+	if format != "map" && format != "detail" {
+		return fmt.Errorf("format must be either map or detail, got %q", format)
+	}
+
+	var err error
+	if language == "" {
+		if language, err = database.Language(db); err != nil {
+			return err
+		}
+	}
+
+	// Fetch overrides
+	overrides := []struct {
+		WordID      int    `db:"word"        json:"wordId"`
+		Surah       int    `db:"surah"       json:"surah"`
+		Ayah        int    `db:"ayah"        json:"ayah"`
+		Position    int    `db:"position"    json:"position"`
+		Arabic      string `db:"arabic"      json:"arabic"`
+		Original    string `db:"original"    json:"original"`
+		Translation string `db:"translation" json:"translation"`
+	}{}
+
+	err = db.Select(&overrides,
+		`SELECT o.word, s.id surah, w.ayah-s.start+1 ayah, w.position, w.arabic,
+			o.original, o.translation
+		FROM gloss_override o
+		JOIN word w ON w.id = o.word
+		JOIN surah s ON w.ayah >= s.start AND w.ayah <= s.end
+		WHERE o.language = ?
+		ORDER BY o.word`, language)
+	if err != nil {
+		return err
+	}
+
+	// Prepare output
+	var w io.Writer = os.Stdout
+	if output != "" {
+		f, err := os.Create(output)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	if format == "detail" {
+		err = encoder.Encode(&overrides)
+	} else {
+		// Use the same shape as word translation data, so it can be merged back upstream
+		glosses := make(map[string]string, len(overrides))
+		for _, o := range overrides {
+			glosses[strconv.Itoa(o.WordID)] = o.Translation
+		}
+		err = encoder.Encode(glosses)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if output != "" {
+		logrus.Printf("%d overrides exported to %s", len(overrides), output)
+	}
+	return nil
+}
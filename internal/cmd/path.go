@@ -18,3 +18,16 @@ func getDBPath() (string, error) {
 
 	return dbPath, nil
 }
+
+func getConfigPath() (string, error) {
+	// Get platform specific path
+	scope := ap.NewScope(ap.User, "kalimah")
+
+	// Set config path
+	configPath, err := scope.ConfigPath("config.toml")
+	if err != nil {
+		return "", err
+	}
+
+	return configPath, nil
+}
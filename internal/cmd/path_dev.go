@@ -9,3 +9,7 @@ func init() {
 func getDBPath() (string, error) {
 	return "kalimah.db", nil
 }
+
+func getConfigPath() (string, error) {
+	return "kalimah.toml", nil
+}
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"fmt"
+	"kalimah/internal/export"
+	"regexp"
+	"strconv"
+)
+
+var rxRangePoint = regexp.MustCompile(`^(\d+)(?::(\d+))?$`)
+
+// parseScope parses range of surah or ayah into export scope. Each end of range
+// is either a surah (`78`) or an ayah within surah (`2:50`), so the accepted
+// formats are `112`, `78-114`, `2:1-2:50` and `2:250-3:10`.
+func parseScope(text string, onlyLearned bool) (export.Scope, error) {
+	// Split the range
+	start, end := text, text
+	for i := 1; i < len(text); i++ {
+		if text[i] == '-' {
+			start, end = text[:i], text[i+1:]
+			break
+		}
+	}
+
+	// Parse both end of range
+	firstAyah, _, err := parseRangePoint(start)
+	if err != nil {
+		return export.Scope{}, err
+	}
+
+	_, lastAyah, err := parseRangePoint(end)
+	if err != nil {
+		return export.Scope{}, err
+	}
+
+	if firstAyah > lastAyah {
+		return export.Scope{}, fmt.Errorf("range %q is reversed", text)
+	}
+
+	return export.Scope{
+		FirstAyah:   firstAyah,
+		LastAyah:    lastAyah,
+		OnlyLearned: onlyLearned,
+	}, nil
+}
+
+// parseRangePoint returns the absolute ID of first and last ayah for the point.
+// If the point is a single ayah, both will be the same.
+func parseRangePoint(point string) (int, int, error) {
+	parts := rxRangePoint.FindStringSubmatch(point)
+	if len(parts) == 0 {
+		return 0, 0, fmt.Errorf("%q is neither <surah> nor <surah>:<ayah>", point)
+	}
+
+	surah, _ := strconv.Atoi(parts[1])
+	var ayahRange struct {
+		Start int `db:"start"`
+		End   int `db:"end"`
+	}
+
+	err := db.Get(&ayahRange, `SELECT start, "end" FROM surah WHERE id = ?`, surah)
+	if err != nil {
+		return 0, 0, fmt.Errorf("surah %d not exist", surah)
+	}
+
+	if parts[2] == "" {
+		return ayahRange.Start, ayahRange.End, nil
+	}
+
+	ayah, _ := strconv.Atoi(parts[2])
+	ayahID := ayahRange.Start + ayah - 1
+	if ayah < 1 || ayahID > ayahRange.End {
+		return 0, 0, fmt.Errorf("surah %d ayah %d not exist", surah, ayah)
+	}
+
+	return ayahID, ayahID, nil
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"io/fs"
+	"kalimah/internal/config"
 	"kalimah/internal/database"
 	"os"
 	fp "path/filepath"
@@ -13,6 +14,7 @@ import (
 
 var (
 	db     *sqlx.DB
+	cfg    *config.Config
 	assets fs.FS
 
 	developmentMode = false
@@ -29,20 +31,29 @@ func RootCmd(assetsFs fs.FS) *cobra.Command {
 		PersistentPostRunE: postRunHandler,
 	}
 
-	rootCmd.AddCommand(startCmd(), initCmd(), cleanCmd(), markCmd())
+	rootCmd.PersistentFlags().String("config", "", "Path to config file")
+	rootCmd.PersistentFlags().String("db", "", "Path to database file, or PostgreSQL URL")
+	rootCmd.PersistentFlags().String("profile", "", "Name of profile which database will be used")
+	rootCmd.PersistentFlags().String("log-format", "text", "Format of log, either text or json")
+	rootCmd.PersistentFlags().String("log-level", "info", "Minimum level of log to be printed")
+	rootCmd.AddCommand(startCmd(), initCmd(), cleanCmd(), markCmd(), configCmd(), dbCmd(), exportCmd(), worksheetCmd(), listCmd(), overridesCmd(), audioCmd(), goalsCmd())
 	return rootCmd
 }
 
 func preRunHandler(cmd *cobra.Command, args []string) error {
-	// Get and create database path
-	dbPath, err := getDBPath()
+	// Load config
+	err := loadConfig(cmd, args)
 	if err != nil {
-		return fmt.Errorf("failed to get database path: %w", err)
+		return err
 	}
 
-	err = os.MkdirAll(fp.Dir(dbPath), os.ModePerm)
-	if err != nil {
-		return fmt.Errorf("failed to create database dir: %w", err)
+	// Create database path
+	dbPath := cfg.DBPath
+	if database.DialectOf(dbPath) == database.SQLite {
+		err = os.MkdirAll(fp.Dir(dbPath), os.ModePerm)
+		if err != nil {
+			return fmt.Errorf("failed to create database dir: %w", err)
+		}
 	}
 
 	// Open database
@@ -57,3 +68,15 @@ func preRunHandler(cmd *cobra.Command, args []string) error {
 func postRunHandler(cmd *cobra.Command, args []string) error {
 	return db.Close()
 }
+
+// dataDir returns the directory for the files that kept beside the database, e.g.
+// the imported audio. PostgreSQL database is not a file, so the directory of the
+// default database is used instead.
+func dataDir() string {
+	if database.DialectOf(cfg.DBPath) == database.Postgres {
+		if defaultPath, err := getDBPath(); err == nil {
+			return fp.Dir(defaultPath)
+		}
+	}
+	return fp.Dir(cfg.DBPath)
+}
@@ -1,8 +1,19 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"kalimah/internal/backend"
+	"kalimah/internal/config"
+	"kalimah/internal/database"
+	"kalimah/internal/store"
+	"net"
+	"os"
+	"os/signal"
+	fp "path/filepath"
+	"strconv"
+	"syscall"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -16,27 +27,95 @@ func startCmd() *cobra.Command {
 	}
 
 	cmd.Flags().IntP("port", "p", 8080, "Port used by the server")
+	cmd.Flags().String("host", "", "Host or IP address used by the server")
+	cmd.Flags().String("addr", "", "Listen address in host:port format or unix:<path> for Unix socket, override host and port")
+	cmd.Flags().Int("ayah-per-page", 0, "Number of ayah in each page of words")
+	cmd.Flags().Int("choice-count", 0, "Number of choices for each word")
+	cmd.Flags().String("choice-policy", "", "How the number of choices is decided, either fixed or adaptive to past accuracy")
+	cmd.Flags().Duration("choice-seed-rotation", 0, "How long the choices of each word stay the same before reshuffled, 0 to never reshuffle")
+	cmd.Flags().Duration("throttle-delay", 0, "Delay for each API response, used to emulate slow connection")
+	cmd.Flags().String("tls-cert", "", "Path to TLS certificate file")
+	cmd.Flags().String("tls-key", "", "Path to TLS key file")
+	cmd.Flags().Bool("tls-self-signed", false, "Serve over TLS using generated self-signed certificate")
+	cmd.Flags().String("base-path", "", "URL sub-path where the app is served, e.g. /kalimah")
+	cmd.Flags().Bool("trust-proxy", false, "Trust X-Forwarded-* headers sent by reverse proxy")
+	cmd.Flags().Duration("shutdown-timeout", 30*time.Second, "Max duration to wait for in-flight requests on shutdown")
 	return cmd
 }
 
 func startCmdHandler(cmd *cobra.Command, args []string) error {
 	// Get flags value
 	port, _ := cmd.Flags().GetInt("port")
+	host, _ := cmd.Flags().GetString("host")
+	tlsCert, _ := cmd.Flags().GetString("tls-cert")
+	tlsKey, _ := cmd.Flags().GetString("tls-key")
+	tlsSelfSigned, _ := cmd.Flags().GetBool("tls-self-signed")
+	shutdownTimeout, _ := cmd.Flags().GetDuration("shutdown-timeout")
+	basePath, _ := cmd.Flags().GetString("base-path")
+	trustProxy, _ := cmd.Flags().GetBool("trust-proxy")
+
+	// Host and port flags override the configured address, unless address flag is used
+	addr := cfg.Addr
+	hostPortChanged := cmd.Flags().Changed("host") || cmd.Flags().Changed("port")
+	if hostPortChanged && !cmd.Flags().Changed("addr") {
+		addr = net.JoinHostPort(host, strconv.Itoa(port))
+		cfg.Addr = addr
+		cfg.SetSource("addr", config.SourceFlag)
+	}
+
+	// Prepare TLS certificate
+	if (tlsCert == "") != (tlsKey == "") {
+		return fmt.Errorf("both --tls-cert and --tls-key must be specified")
+	}
+
+	if tlsSelfSigned && tlsCert == "" {
+		tlsCert = fp.Join(dataDir(), "kalimah-cert.pem")
+		tlsKey = fp.Join(dataDir(), "kalimah-key.pem")
+		err := backend.EnsureSelfSignedCert(tlsCert, tlsKey)
+		if err != nil {
+			return fmt.Errorf("failed to generate self-signed certificate: %w", err)
+		}
+	}
 
 	// Start server
 	server := backend.Server{
 		DB:      db,
 		Assets:  assets,
 		DevMode: developmentMode,
+
+		BasePath:    backend.NormalizeBasePath(basePath),
+		TrustProxy:  trustProxy,
+		AyahPerPage: cfg.AyahPerPage,
+		ChoiceCount: cfg.ChoiceCount,
+		ChoicePolicy: backend.ChoicePolicy{
+			Adaptive:      cfg.ChoicePolicy == "adaptive",
+			NewCount:      cfg.ChoiceCountNew,
+			MasteredCount: cfg.ChoiceCountMastered,
+		},
+		ChoiceSeedRotation: cfg.ChoiceSeedRotation,
+		ThrottleDelay:      cfg.ThrottleDelay,
+		AdminToken:         cfg.AdminToken,
+		AudioDir:           audioDir(),
+		Store:              store.NewSQLite(db),
 	}
 
 	if developmentMode {
 		logrus.Println("development mode enabled")
 	}
 
-	if err := server.Serve(port); err != nil {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	err := server.Serve(ctx, backend.ListenConfig{
+		Address:         addr,
+		TLSCert:         tlsCert,
+		TLSKey:          tlsKey,
+		ShutdownTimeout: shutdownTimeout,
+	})
+	if err != nil {
 		return fmt.Errorf("server error: %w", err)
 	}
 
-	return nil
+	// Server stopped gracefully, checkpoint the WAL before database closed
+	return database.Checkpoint(db)
 }
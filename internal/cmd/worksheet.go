@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"kalimah/internal/backend"
+	"kalimah/internal/store"
+	"os"
+	"os/exec"
+	fp "path/filepath"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// pdfBrowsers is the Chromium based browsers that can be used to print PDF.
+var pdfBrowsers = []string{
+	"chromium",
+	"chromium-browser",
+	"google-chrome",
+	"google-chrome-stable",
+	"microsoft-edge",
+}
+
+func worksheetCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "worksheet",
+		Short: "Generate printable worksheet for words in surah",
+		Args:  cobra.NoArgs,
+		RunE:  worksheetCmdHandler,
+	}
+
+	cmd.Flags().String("surah", "", "Range of surah or ayah to print, e.g. 112, 78-80 or 2:1-2:10")
+	cmd.Flags().String("format", "html", "Output format, either html or pdf")
+	cmd.Flags().String("mode", backend.WorksheetChoice, "Kind of question, either choice or blank")
+	cmd.Flags().Int("choice-count", 0, "Number of choices for each word in choice mode (default 4)")
+	cmd.Flags().Uint64("seed", 0, "Seed of the choices, to print the same choices as the app with that seed")
+	cmd.Flags().String("browser", "", "Path to Chromium based browser used to render PDF")
+	cmd.Flags().StringP("output", "o", "", "Path to the output file")
+	cmd.MarkFlagRequired("surah")
+	cmd.MarkFlagRequired("output")
+	return cmd
+}
+
+func worksheetCmdHandler(cmd *cobra.Command, args []string) error {
+	// Get flags value
+	surahRange, _ := cmd.Flags().GetString("surah")
+	format, _ := cmd.Flags().GetString("format")
+	mode, _ := cmd.Flags().GetString("mode")
+	nChoices, _ := cmd.Flags().GetInt("choice-count")
+	seed, _ := cmd.Flags().GetUint64("seed")
+	browser, _ := cmd.Flags().GetString("browser")
+	output, _ := cmd.Flags().GetString("output")
+
+	if format != "html" && format != "pdf" {
+		return fmt.Errorf("format must be html or pdf, got %q", format)
+	}
+
+	scope, err := parseScope(surahRange, false)
+	if err != nil {
+		return err
+	}
+
+	// Without seed, use the same choices as the app currently does
+	if !cmd.Flags().Changed("seed") {
+		seed, err = backend.ChoiceSeed(store.NewSQLite(db), cfg.ChoiceSeedRotation, time.Now())
+		if err != nil {
+			return err
+		}
+	}
+
+	// Find the browser early, so the user doesn't wait for nothing
+	if format == "pdf" {
+		browser, err = findPDFBrowser(browser)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Render the HTML. For PDF, it's rendered into temporary file then printed by browser.
+	htmlPath := output
+	if format == "pdf" {
+		tmpDir, err := ioutil.TempDir("", "kalimah-worksheet-")
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(tmpDir)
+		htmlPath = fp.Join(tmpDir, "worksheet.html")
+	}
+
+	err = renderWorksheetFile(htmlPath, backend.WorksheetOptions{
+		FirstAyah:   scope.FirstAyah,
+		LastAyah:    scope.LastAyah,
+		Mode:        mode,
+		ChoiceCount: nChoices,
+		Seed:        seed,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to render worksheet: %w", err)
+	}
+
+	if format == "pdf" {
+		err = printPDF(browser, htmlPath, output)
+		if err != nil {
+			return fmt.Errorf("failed to print PDF: %w", err)
+		}
+	}
+
+	logrus.Printf("worksheet saved to %s", output)
+	return nil
+}
+
+func renderWorksheetFile(dstPath string, opts backend.WorksheetOptions) error {
+	f, err := os.Create(dstPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	bw := bufio.NewWriter(f)
+	err = backend.RenderWorksheet(store.NewSQLite(db), assets, bw, opts)
+	if err != nil {
+		return err
+	}
+
+	if err = bw.Flush(); err != nil {
+		return err
+	}
+
+	return f.Close()
+}
+
+// findPDFBrowser looks for the Chromium based browser, since it's the one that
+// able to print HTML into PDF with proper Arabic shaping from the command line.
+func findPDFBrowser(browser string) (string, error) {
+	if browser != "" {
+		return exec.LookPath(browser)
+	}
+
+	for _, name := range pdfBrowsers {
+		if path, err := exec.LookPath(name); err == nil {
+			return path, nil
+		}
+	}
+
+	return "", fmt.Errorf("no Chromium based browser found to render PDF; " +
+		"specify it with --browser, or use --format html and print it from your browser")
+}
+
+// printPDF prints the HTML file into PDF using headless browser.
+func printPDF(browser, htmlPath, dstPath string) error {
+	absHTMLPath, err := fp.Abs(htmlPath)
+	if err != nil {
+		return err
+	}
+
+	absDstPath, err := fp.Abs(dstPath)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	out, err := exec.CommandContext(ctx, browser,
+		"--headless",
+		"--disable-gpu",
+		"--no-pdf-header-footer",
+		"--print-to-pdf="+absDstPath,
+		"file://"+fp.ToSlash(absHTMLPath)).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%v: %s", err, out)
+	}
+
+	return nil
+}
@@ -0,0 +1,252 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/pflag"
+)
+
+// Source is the origin of a config value.
+type Source string
+
+const (
+	SourceDefault Source = "default"
+	SourceFile    Source = "file"
+	SourceEnv     Source = "env"
+	SourceFlag    Source = "flag"
+)
+
+// EnvPrefix is prefix for environment variables that used to set config.
+const EnvPrefix = "KALIMAH_"
+
+// Config is the settings for kalimah. Each field is identified by its `toml` tag,
+// which also used to derive its environment variable (`KALIMAH_<KEY>`) and
+// its flag name (key with dash instead of underscore).
+type Config struct {
+	DBPath              string        `toml:"db_path"`
+	Addr                string        `toml:"addr"`
+	AyahPerPage         int           `toml:"ayah_per_page"`
+	ChoiceCount         int           `toml:"choice_count"`
+	ChoicePolicy        string        `toml:"choice_policy"`
+	ChoiceCountNew      int           `toml:"choice_count_new"`
+	ChoiceCountMastered int           `toml:"choice_count_mastered"`
+	ChoiceSeedRotation  time.Duration `toml:"choice_seed_rotation"`
+	Language            string        `toml:"language"`
+	ThrottleDelay       time.Duration `toml:"throttle_delay"`
+	LogFormat           string        `toml:"log_format"`
+	LogLevel            string        `toml:"log_level"`
+	AdminToken          string        `toml:"admin_token"`
+
+	path    string
+	sources map[string]Source
+}
+
+// Default returns the default config.
+func Default(dbPath string) *Config {
+	return &Config{
+		DBPath:              dbPath,
+		Addr:                ":8080",
+		AyahPerPage:         30,
+		ChoiceCount:         8,
+		ChoicePolicy:        "fixed",
+		ChoiceCountNew:      4,
+		ChoiceCountMastered: 12,
+		ChoiceSeedRotation:  24 * time.Hour,
+		Language:            "id",
+		ThrottleDelay:       0,
+		LogFormat:           "text",
+		LogLevel:            "info",
+		sources:             map[string]Source{},
+	}
+}
+
+// Load loads config from the file in specified path then from environment variables,
+// on top of the default values. Missing config file is not an error.
+func Load(path string, defaults *Config) (*Config, error) {
+	cfg := defaults
+	cfg.path = path
+	if cfg.sources == nil {
+		cfg.sources = map[string]Source{}
+	}
+
+	// Load config file
+	content, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	if err == nil {
+		var fileValues map[string]interface{}
+		if _, err = toml.Decode(string(content), &fileValues); err != nil {
+			return nil, fmt.Errorf("failed to decode config file: %w", err)
+		}
+
+		for key, value := range fileValues {
+			if err = cfg.set(key, fmt.Sprint(value), SourceFile); err != nil {
+				return nil, fmt.Errorf("invalid config file: %w", err)
+			}
+		}
+	}
+
+	// Load environment variables
+	for _, key := range cfg.Keys() {
+		value, exist := os.LookupEnv(EnvKey(key))
+		if !exist {
+			continue
+		}
+
+		if err = cfg.set(key, value, SourceEnv); err != nil {
+			return nil, fmt.Errorf("invalid environment variable %s: %w", EnvKey(key), err)
+		}
+	}
+
+	return cfg, cfg.Validate()
+}
+
+// ApplyFlags overrides the config using the flags that explicitly set by user.
+func (cfg *Config) ApplyFlags(flags *pflag.FlagSet) error {
+	for _, key := range cfg.Keys() {
+		flag := flags.Lookup(FlagName(key))
+		if flag == nil || !flag.Changed {
+			continue
+		}
+
+		if err := cfg.set(key, flag.Value.String(), SourceFlag); err != nil {
+			return fmt.Errorf("invalid flag --%s: %w", flag.Name, err)
+		}
+	}
+
+	return cfg.Validate()
+}
+
+// Validate makes sure the config values are usable.
+func (cfg *Config) Validate() error {
+	switch {
+	case cfg.DBPath == "":
+		return fmt.Errorf("db_path must not be empty")
+	case cfg.Addr == "":
+		return fmt.Errorf("addr must not be empty")
+	case cfg.AyahPerPage < 1:
+		return fmt.Errorf("ayah_per_page must be at least 1, got %d", cfg.AyahPerPage)
+	case cfg.ChoiceCount < 2 || cfg.ChoiceCount > 20:
+		return fmt.Errorf("choice_count must be between 2 and 20, got %d", cfg.ChoiceCount)
+	case cfg.ChoicePolicy != "fixed" && cfg.ChoicePolicy != "adaptive":
+		return fmt.Errorf("choice_policy must be either fixed or adaptive, got %q", cfg.ChoicePolicy)
+	case cfg.ChoiceCountNew < 2 || cfg.ChoiceCountNew > 20:
+		return fmt.Errorf("choice_count_new must be between 2 and 20, got %d", cfg.ChoiceCountNew)
+	case cfg.ChoiceCountMastered < 2 || cfg.ChoiceCountMastered > 20:
+		return fmt.Errorf("choice_count_mastered must be between 2 and 20, got %d", cfg.ChoiceCountMastered)
+	case cfg.ChoiceSeedRotation != 0 && cfg.ChoiceSeedRotation < time.Second:
+		return fmt.Errorf("choice_seed_rotation must be 0 to never rotate or at least 1s, got %s",
+			cfg.ChoiceSeedRotation)
+	case cfg.ThrottleDelay < 0:
+		return fmt.Errorf("throttle_delay must not be negative, got %s", cfg.ThrottleDelay)
+	case cfg.LogFormat != "text" && cfg.LogFormat != "json":
+		return fmt.Errorf("log_format must be either text or json, got %q", cfg.LogFormat)
+	}
+
+	if _, err := logrus.ParseLevel(cfg.LogLevel); err != nil {
+		return fmt.Errorf("log_level is invalid: %w", err)
+	}
+	return nil
+}
+
+// Path returns the path of config file.
+func (cfg *Config) Path() string {
+	return cfg.path
+}
+
+// Keys returns all config keys in order of declaration.
+func (cfg *Config) Keys() []string {
+	var keys []string
+	rt := reflect.TypeOf(cfg).Elem()
+	for i := 0; i < rt.NumField(); i++ {
+		if key := rt.Field(i).Tag.Get("toml"); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// Get returns the value of config key as string.
+func (cfg *Config) Get(key string) string {
+	field, ok := cfg.field(key)
+	if !ok {
+		return ""
+	}
+
+	if d, isDuration := field.Interface().(time.Duration); isDuration {
+		return d.String()
+	}
+	return fmt.Sprint(field.Interface())
+}
+
+// Source returns the origin of the config key value.
+func (cfg *Config) Source(key string) Source {
+	if source, exist := cfg.sources[key]; exist {
+		return source
+	}
+	return SourceDefault
+}
+
+// SetSource marks the origin of config key. Used when value is set directly to the field.
+func (cfg *Config) SetSource(key string, source Source) {
+	cfg.sources[key] = source
+}
+
+func (cfg *Config) set(key string, value string, source Source) error {
+	field, ok := cfg.field(key)
+	if !ok {
+		return fmt.Errorf("unknown key %q", key)
+	}
+
+	switch field.Interface().(type) {
+	case string:
+		field.SetString(value)
+	case int:
+		number, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("%s must be a number, got %q", key, value)
+		}
+		field.SetInt(int64(number))
+	case time.Duration:
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("%s must be a duration, got %q", key, value)
+		}
+		field.SetInt(int64(duration))
+	}
+
+	cfg.sources[key] = source
+	return nil
+}
+
+func (cfg *Config) field(key string) (reflect.Value, bool) {
+	rv := reflect.ValueOf(cfg).Elem()
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		if rt.Field(i).Tag.Get("toml") == key {
+			return rv.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// EnvKey returns the environment variable name for the config key.
+func EnvKey(key string) string {
+	return EnvPrefix + strings.ToUpper(key)
+}
+
+// FlagName returns the flag name for the config key.
+func FlagName(key string) string {
+	return strings.ReplaceAll(key, "_", "-")
+}
@@ -0,0 +1,68 @@
+package database
+
+import (
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	// ActivityQuiz marks the word answered in the regular word quiz.
+	ActivityQuiz = "quiz"
+
+	// ActivitySprint marks the word answered in timed sprint.
+	ActivitySprint = "sprint"
+)
+
+// ActivityIdleLimit is the max gap between two answers that still counted as
+// active learning time. Longer gap is assumed as a break.
+const ActivityIdleLimit = 2 * time.Minute
+
+// LogActivity records an answered word, which used to measure the daily goal.
+func LogActivity(e sqlx.Execer, wordID int, mode string, correct bool, at time.Time) error {
+	_, err := e.Exec(`INSERT INTO activity (word, mode, correct, created_at)
+		VALUES (?, ?, ?, ?)`, wordID, mode, correct, at.Unix())
+	return err
+}
+
+// StartOfDay returns the beginning of the day of t in local time.
+func StartOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+// DailyActivity returns the number of distinct words correctly answered within the
+// day of t, along with the active learning time. The active time is the sum of gaps
+// between consecutive answers, where gap longer than ActivityIdleLimit is ignored.
+func DailyActivity(q sqlx.Queryer, t time.Time) (int, time.Duration, error) {
+	start := StartOfDay(t)
+	end := start.AddDate(0, 0, 1)
+
+	var nWords int
+	err := sqlx.Get(q, &nWords,
+		`SELECT COUNT(DISTINCT word) FROM activity
+		WHERE correct = 1 AND created_at >= ? AND created_at < ?`,
+		start.Unix(), end.Unix())
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var timestamps []int64
+	err = sqlx.Select(q, &timestamps,
+		`SELECT created_at FROM activity
+		WHERE created_at >= ? AND created_at < ?
+		ORDER BY created_at`, start.Unix(), end.Unix())
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var active time.Duration
+	for i := 1; i < len(timestamps); i++ {
+		gap := time.Duration(timestamps[i]-timestamps[i-1]) * time.Second
+		if gap <= ActivityIdleLimit {
+			active += gap
+		}
+	}
+
+	return nWords, active, nil
+}
@@ -1,6 +1,7 @@
 package database
 
 import (
+	"database/sql"
 	"fmt"
 	"net/url"
 	"time"
@@ -8,20 +9,30 @@ import (
 	"github.com/jmoiron/sqlx"
 )
 
-// Open database on specified path.
-func Open(dbPath string) (db *sqlx.DB, err error) {
+// SchemaVersion is the version of database schema that used by this app.
+const SchemaVersion = 10
+
+// Open database on specified DSN, which is either path to SQLite database
+// file or PostgreSQL connection URL.
+func Open(dsn string) (db *sqlx.DB, err error) {
 	// Prepare DSN
-	q := url.Values{}
-	q.Add("_foreign_keys", "1")
-	q.Add("_journal_mode", "WAL")
-	dsn := dbPath + "?" + q.Encode()
+	dialect := DialectOf(dsn)
+	if dialect == SQLite {
+		q := url.Values{}
+		q.Add("_foreign_keys", "1")
+		q.Add("_journal_mode", "WAL")
+		dsn += "?" + q.Encode()
+	}
 
 	// Connect to database
-	db, err = sqlx.Connect("sqlite3", dsn)
+	db, err = dialect.connect(dsn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %v", err)
 	}
-	db.SetConnMaxLifetime(time.Minute)
+
+	if dialect == SQLite {
+		db.SetConnMaxLifetime(time.Minute)
+	}
 
 	// Create transaction
 	var tx *sqlx.Tx
@@ -43,21 +54,69 @@ func Open(dbPath string) (db *sqlx.DB, err error) {
 		}
 	}()
 
-	// Generate tables
-	ddlQueries := []string{
-		ddlCreateSurah,
-		ddlCreateAyah,
-		ddlCreateWord,
-		ddlCreateTracker}
+	// Check the existing schema version
+	var version int
+	version, err = currentSchemaVersion(dialect, tx)
+	if err != nil {
+		return nil, err
+	}
 
-	for _, query := range ddlQueries {
+	// Generate tables
+	for _, query := range dialect.ddlQueries() {
 		_, err = tx.Exec(query)
 		if err != nil {
 			return nil, err
 		}
 	}
 
+	// Upgrade the schema then save its version
+	err = migrate(dialect, tx, version)
+	if err != nil {
+		return nil, err
+	}
+
 	// Commit transaction
 	err = tx.Commit()
 	return db, err
 }
+
+// Checkpoint writes the content of WAL file back into the database file,
+// then truncates the WAL file. It does nothing for PostgreSQL.
+func Checkpoint(db *sqlx.DB) error {
+	if DialectOfDB(db) != SQLite {
+		return nil
+	}
+
+	_, err := db.Exec(`PRAGMA wal_checkpoint(TRUNCATE)`)
+	if err != nil {
+		return fmt.Errorf("failed to checkpoint WAL: %v", err)
+	}
+	return nil
+}
+
+// DropTables removes every table in the database, which used to reset the PostgreSQL
+// database since unlike SQLite it can't simply be removed as a file.
+func DropTables(db *sqlx.DB) error {
+	_, err := db.Exec(`DROP TABLE IF EXISTS sprint, goal, activity, word_audio,
+		order_attempt, gloss_override, gloss_suggestion, bookmark, list, note,
+		metadata, track_submission, ayah_tracker, tracker, word, ayah, surah CASCADE`)
+	if err != nil {
+		return fmt.Errorf("failed to drop tables: %v", err)
+	}
+	return nil
+}
+
+// SchemaVersionOf returns the schema version of the database.
+func SchemaVersionOf(db *sqlx.DB) (int, error) {
+	return DialectOfDB(db).schemaVersion(db)
+}
+
+// Language returns the language of translation that populated in database.
+func Language(q sqlx.Queryer) (string, error) {
+	var language string
+	err := sqlx.Get(q, &language, `SELECT value FROM metadata WHERE key = 'language'`)
+	if err == sql.ErrNoRows {
+		return "", fmt.Errorf("database is not initialized, run init first")
+	}
+	return language, err
+}
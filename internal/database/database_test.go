@@ -0,0 +1,150 @@
+package database_test
+
+import (
+	"kalimah/internal/database"
+	"kalimah/internal/store"
+	"os"
+	fp "path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// postgresDSNEnv is the environment variable of PostgreSQL URL that used to run
+// the tests against PostgreSQL. Every table in it will be dropped, so it must point
+// to a disposable database.
+const postgresDSNEnv = "KALIMAH_TEST_POSTGRES_DSN"
+
+func TestOpenSQLite(t *testing.T) {
+	testOpen(t, fp.Join(t.TempDir(), "kalimah.db"))
+}
+
+func TestOpenPostgres(t *testing.T) {
+	dsn := os.Getenv(postgresDSNEnv)
+	if dsn == "" {
+		t.Skipf("%s is not set", postgresDSNEnv)
+	}
+
+	// Start and end with clean database
+	db, err := database.Open(dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dropTables := func() {
+		if err := database.DropTables(db); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	dropTables()
+	t.Cleanup(func() {
+		dropTables()
+		db.Close()
+	})
+
+	testOpen(t, dsn)
+}
+
+// testOpen creates the schema in the database, populates it then makes sure the
+// progress can be tracked and the database can be reopened.
+func testOpen(t *testing.T, dsn string) {
+	db := openDB(t, dsn)
+
+	if err := database.PopulateData(db, "id"); err != nil {
+		t.Fatalf("failed to populate data: %v", err)
+	}
+
+	language, err := database.Language(db)
+	if err != nil || language != "id" {
+		t.Fatalf("unexpected language %q: %v", language, err)
+	}
+
+	// Track the first ayah of Al-Fatihah
+	st := store.NewSQLite(db)
+	words, err := st.Words(1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(words) != 4 {
+		t.Fatalf("expected 4 words in the first ayah, got %d", len(words))
+	}
+
+	lastWord := words[len(words)-1]
+	err = st.Tx(func(tx store.Store) error {
+		if err := tx.SetLastWord(lastWord.ID); err != nil {
+			return err
+		}
+		return tx.LogAnswer(lastWord.ID, database.ActivityQuiz, true, time.Now())
+	})
+	if err != nil {
+		t.Fatalf("failed to track word: %v", err)
+	}
+
+	// Reopening keeps the schema and the progress
+	db.Close()
+	db = openDB(t, dsn)
+
+	version, err := database.SchemaVersionOf(db)
+	if err != nil || version != database.SchemaVersion {
+		t.Fatalf("expected schema version %d, got %d: %v", database.SchemaVersion, version, err)
+	}
+
+	progress, err := store.NewSQLite(db).Progress()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if progress.LastWord != lastWord.ID || progress.Surah != 1 || progress.Ayah != 1 || progress.NextAyah != 2 {
+		t.Fatalf("unexpected progress: %+v", progress)
+	}
+
+	count, _, err := database.DailyActivity(db, time.Now())
+	if err != nil || count != 1 {
+		t.Fatalf("expected 1 answer today, got %d: %v", count, err)
+	}
+}
+
+func TestMigrateLegacySQLite(t *testing.T) {
+	// Database from before schema versioning, where word has no nastaliq
+	dsn := fp.Join(t.TempDir(), "kalimah.db")
+	legacy, err := sqlx.Open("sqlite3", dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	legacy.MustExec(`CREATE TABLE word (
+		id          INT  NOT NULL,
+		ayah        INT  NOT NULL,
+		position    INT  NOT NULL,
+		arabic      TEXT NOT NULL,
+		translation TEXT NOT NULL,
+		PRIMARY KEY (id))`)
+	legacy.MustExec(`INSERT INTO word VALUES (1, 1, 1, 'بِسْمِ', 'dengan nama')`)
+	legacy.Close()
+
+	db := openDB(t, dsn)
+	version, err := database.SchemaVersionOf(db)
+	if err != nil || version != database.SchemaVersion {
+		t.Fatalf("expected schema version %d, got %d: %v", database.SchemaVersion, version, err)
+	}
+
+	var nastaliq string
+	err = db.Get(&nastaliq, `SELECT nastaliq FROM word WHERE id = 1`)
+	if err != nil || nastaliq != "" {
+		t.Fatalf("nastaliq column is not added: %q, %v", nastaliq, err)
+	}
+}
+
+func openDB(t *testing.T, dsn string) *sqlx.DB {
+	t.Helper()
+
+	db, err := database.Open(dsn)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
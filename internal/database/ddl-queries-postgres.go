@@ -0,0 +1,197 @@
+package database
+
+// The tables in PostgreSQL use the same columns as in SQLite. The auto increment ID
+// becomes BIGSERIAL, the unix time becomes BIGINT so it doesn't overflow in 2038, and
+// the foreign key follows the type of column it refers to. The indexes don't have any
+// column type, so they are shared with SQLite.
+
+const ddlPgCreateSurah = `
+CREATE TABLE IF NOT EXISTS surah (
+	id          INT  NOT NULL,
+	name        TEXT NOT NULL,
+	translation TEXT NOT NULL,
+	start       INT  NOT NULL,
+	"end"       INT  NOT NULL,
+	PRIMARY KEY (id))`
+
+const ddlPgCreateAyah = `
+CREATE TABLE IF NOT EXISTS ayah (
+	id          INT  NOT NULL,
+	translation TEXT NOT NULL,
+	tafsir      TEXT NOT NULL,
+	PRIMARY KEY (id))`
+
+const ddlPgCreateWord = `
+CREATE TABLE IF NOT EXISTS word (
+	id          INT  NOT NULL,
+	ayah        INT  NOT NULL,
+	position    INT  NOT NULL,
+	arabic      TEXT NOT NULL,
+	translation TEXT NOT NULL,
+	nastaliq    TEXT NOT NULL DEFAULT '',
+	PRIMARY KEY (id),
+	CONSTRAINT word_UNIQUE UNIQUE (ayah, position),
+	CONSTRAINT word_ayah_FK FOREIGN KEY (ayah) REFERENCES ayah (id))`
+
+const ddlPgCreateTracker = `
+CREATE TABLE IF NOT EXISTS tracker (
+	id        INT NOT NULL,
+	last_word INT DEFAULT NULL,
+	PRIMARY KEY (id),
+	CONSTRAINT tracker_word_FK FOREIGN KEY (last_word) REFERENCES word (id))`
+
+const ddlPgCreateAyahTracker = `
+CREATE TABLE IF NOT EXISTS ayah_tracker (
+	id        INT NOT NULL,
+	last_ayah INT DEFAULT NULL,
+	PRIMARY KEY (id),
+	CONSTRAINT ayah_tracker_ayah_FK FOREIGN KEY (last_ayah) REFERENCES ayah (id))`
+
+const ddlPgCreateTrackSubmission = `
+CREATE TABLE IF NOT EXISTS track_submission (
+	client_id  TEXT   NOT NULL,
+	word       INT    NOT NULL,
+	status     TEXT   NOT NULL,
+	created_at BIGINT NOT NULL,
+	PRIMARY KEY (client_id))`
+
+const ddlPgCreateMetadata = `
+CREATE TABLE IF NOT EXISTS metadata (
+	key   TEXT NOT NULL,
+	value TEXT NOT NULL,
+	PRIMARY KEY (key))`
+
+const ddlPgCreateNote = `
+CREATE TABLE IF NOT EXISTS note (
+	id         BIGSERIAL NOT NULL,
+	word       INT       DEFAULT NULL,
+	ayah       INT       DEFAULT NULL,
+	content    TEXT      NOT NULL,
+	created_at BIGINT    NOT NULL,
+	updated_at BIGINT    NOT NULL,
+	PRIMARY KEY (id),
+	CONSTRAINT note_target_CHECK CHECK ((word IS NULL) <> (ayah IS NULL)),
+	CONSTRAINT note_word_FK FOREIGN KEY (word) REFERENCES word (id),
+	CONSTRAINT note_ayah_FK FOREIGN KEY (ayah) REFERENCES ayah (id))`
+
+const ddlPgCreateList = `
+CREATE TABLE IF NOT EXISTS list (
+	id         BIGSERIAL NOT NULL,
+	name       TEXT      NOT NULL,
+	created_at BIGINT    NOT NULL,
+	PRIMARY KEY (id),
+	CONSTRAINT list_UNIQUE UNIQUE (name))`
+
+const ddlPgCreateBookmark = `
+CREATE TABLE IF NOT EXISTS bookmark (
+	id         BIGSERIAL NOT NULL,
+	list       BIGINT    NOT NULL,
+	word       INT       DEFAULT NULL,
+	ayah       INT       DEFAULT NULL,
+	created_at BIGINT    NOT NULL,
+	PRIMARY KEY (id),
+	CONSTRAINT bookmark_target_CHECK CHECK ((word IS NULL) <> (ayah IS NULL)),
+	CONSTRAINT bookmark_list_FK FOREIGN KEY (list) REFERENCES list (id) ON DELETE CASCADE,
+	CONSTRAINT bookmark_word_FK FOREIGN KEY (word) REFERENCES word (id),
+	CONSTRAINT bookmark_ayah_FK FOREIGN KEY (ayah) REFERENCES ayah (id))`
+
+const ddlPgCreateGlossSuggestion = `
+CREATE TABLE IF NOT EXISTS gloss_suggestion (
+	id          BIGSERIAL NOT NULL,
+	word        INT       NOT NULL,
+	language    TEXT      NOT NULL,
+	translation TEXT      NOT NULL,
+	reason      TEXT      NOT NULL DEFAULT '',
+	status      TEXT      NOT NULL DEFAULT 'pending',
+	created_at  BIGINT    NOT NULL,
+	reviewed_at BIGINT    DEFAULT NULL,
+	PRIMARY KEY (id),
+	CONSTRAINT gloss_suggestion_status_CHECK CHECK (status IN ('pending', 'accepted', 'rejected')),
+	CONSTRAINT gloss_suggestion_word_FK FOREIGN KEY (word) REFERENCES word (id))`
+
+const ddlPgCreateGlossOverride = `
+CREATE TABLE IF NOT EXISTS gloss_override (
+	word        INT    NOT NULL,
+	language    TEXT   NOT NULL,
+	translation TEXT   NOT NULL,
+	original    TEXT   NOT NULL,
+	suggestion  BIGINT DEFAULT NULL,
+	updated_at  BIGINT NOT NULL,
+	PRIMARY KEY (word, language),
+	CONSTRAINT gloss_override_word_FK FOREIGN KEY (word) REFERENCES word (id),
+	CONSTRAINT gloss_override_suggestion_FK FOREIGN KEY (suggestion)
+		REFERENCES gloss_suggestion (id) ON DELETE SET NULL)`
+
+const ddlPgCreateOrderAttempt = `
+CREATE TABLE IF NOT EXISTS order_attempt (
+	id         BIGSERIAL NOT NULL,
+	ayah       INT       NOT NULL,
+	correct    INT       NOT NULL,
+	mistakes   INT       NOT NULL,
+	duration   INT       NOT NULL,
+	created_at BIGINT    NOT NULL,
+	PRIMARY KEY (id),
+	CONSTRAINT order_attempt_ayah_FK FOREIGN KEY (ayah) REFERENCES ayah (id))`
+
+const ddlPgCreateWordAudio = `
+CREATE TABLE IF NOT EXISTS word_audio (
+	reciter TEXT NOT NULL,
+	word    INT  NOT NULL,
+	path    TEXT NOT NULL,
+	PRIMARY KEY (reciter, word),
+	CONSTRAINT word_audio_word_FK FOREIGN KEY (word) REFERENCES word (id))`
+
+const ddlPgCreateActivity = `
+CREATE TABLE IF NOT EXISTS activity (
+	id         BIGSERIAL NOT NULL,
+	word       INT       NOT NULL,
+	mode       TEXT      NOT NULL,
+	correct    INT       NOT NULL,
+	created_at BIGINT    NOT NULL,
+	PRIMARY KEY (id),
+	CONSTRAINT activity_word_FK FOREIGN KEY (word) REFERENCES word (id))`
+
+const ddlPgCreateGoal = `
+CREATE TABLE IF NOT EXISTS goal (
+	id              INT    NOT NULL,
+	words_per_day   INT    NOT NULL DEFAULT 0,
+	minutes_per_day INT    NOT NULL DEFAULT 0,
+	updated_at      BIGINT NOT NULL,
+	PRIMARY KEY (id))`
+
+const ddlPgCreateSprint = `
+CREATE TABLE IF NOT EXISTS sprint (
+	id         BIGSERIAL NOT NULL,
+	duration   INT       NOT NULL,
+	started_at BIGINT    NOT NULL,
+	ends_at    BIGINT    NOT NULL,
+	answered   INT       NOT NULL DEFAULT 0,
+	correct    INT       NOT NULL DEFAULT 0,
+	PRIMARY KEY (id))`
+
+// postgresDDL is the queries for creating the tables in PostgreSQL, in the same
+// order as sqliteDDL.
+var postgresDDL = []string{
+	ddlPgCreateSurah,
+	ddlPgCreateAyah,
+	ddlPgCreateWord,
+	ddlPgCreateTracker,
+	ddlPgCreateAyahTracker,
+	ddlPgCreateTrackSubmission,
+	ddlPgCreateMetadata,
+	ddlPgCreateNote,
+	ddlCreateNoteIndex,
+	ddlPgCreateList,
+	ddlPgCreateBookmark,
+	ddlCreateBookmarkWordIndex,
+	ddlCreateBookmarkAyahIndex,
+	ddlPgCreateGlossSuggestion,
+	ddlPgCreateGlossOverride,
+	ddlPgCreateOrderAttempt,
+	ddlCreateOrderAttemptIndex,
+	ddlPgCreateWordAudio,
+	ddlPgCreateActivity,
+	ddlCreateActivityIndex,
+	ddlPgCreateGoal,
+	ddlPgCreateSprint,
+}
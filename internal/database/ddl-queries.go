@@ -6,7 +6,7 @@ CREATE TABLE IF NOT EXISTS surah (
 	name        TEXT NOT NULL,
 	translation TEXT NOT NULL,
 	start       INT  NOT NULL,
-	end         INT  NOT NULL,
+	"end"       INT  NOT NULL,
 	PRIMARY KEY (id))`
 
 const ddlCreateAyah = `
@@ -23,6 +23,7 @@ CREATE TABLE IF NOT EXISTS word (
 	position    INT  NOT NULL,
 	arabic      TEXT NOT NULL,
 	translation TEXT NOT NULL,
+	nastaliq    TEXT NOT NULL DEFAULT '',
 	PRIMARY KEY (id),
 	CONSTRAINT word_UNIQUE UNIQUE (ayah, position),
 	CONSTRAINT word_ayah_FK FOREIGN KEY (ayah) REFERENCES ayah (id))`
@@ -33,3 +34,176 @@ CREATE TABLE IF NOT EXISTS tracker (
 	last_word INT DEFAULT NULL,
 	PRIMARY KEY (id),
 	CONSTRAINT tracker_word_FK FOREIGN KEY (last_word) REFERENCES word (id))`
+
+const ddlCreateAyahTracker = `
+CREATE TABLE IF NOT EXISTS ayah_tracker (
+	id        INT NOT NULL,
+	last_ayah INT DEFAULT NULL,
+	PRIMARY KEY (id),
+	CONSTRAINT ayah_tracker_ayah_FK FOREIGN KEY (last_ayah) REFERENCES ayah (id))`
+
+const ddlCreateTrackSubmission = `
+CREATE TABLE IF NOT EXISTS track_submission (
+	client_id  TEXT NOT NULL,
+	word       INT  NOT NULL,
+	status     TEXT NOT NULL,
+	created_at INT  NOT NULL,
+	PRIMARY KEY (client_id))`
+
+const ddlCreateMetadata = `
+CREATE TABLE IF NOT EXISTS metadata (
+	key   TEXT NOT NULL,
+	value TEXT NOT NULL,
+	PRIMARY KEY (key))`
+
+const ddlCreateNote = `
+CREATE TABLE IF NOT EXISTS note (
+	id         INTEGER NOT NULL,
+	word       INT     DEFAULT NULL,
+	ayah       INT     DEFAULT NULL,
+	content    TEXT    NOT NULL,
+	created_at INT     NOT NULL,
+	updated_at INT     NOT NULL,
+	PRIMARY KEY (id),
+	CONSTRAINT note_target_CHECK CHECK ((word IS NULL) <> (ayah IS NULL)),
+	CONSTRAINT note_word_FK FOREIGN KEY (word) REFERENCES word (id),
+	CONSTRAINT note_ayah_FK FOREIGN KEY (ayah) REFERENCES ayah (id))`
+
+const ddlCreateNoteIndex = `
+CREATE INDEX IF NOT EXISTS note_target_IDX ON note (word, ayah)`
+
+const ddlCreateList = `
+CREATE TABLE IF NOT EXISTS list (
+	id         INTEGER NOT NULL,
+	name       TEXT    NOT NULL,
+	created_at INT     NOT NULL,
+	PRIMARY KEY (id),
+	CONSTRAINT list_UNIQUE UNIQUE (name))`
+
+const ddlCreateBookmark = `
+CREATE TABLE IF NOT EXISTS bookmark (
+	id         INTEGER NOT NULL,
+	list       INT     NOT NULL,
+	word       INT     DEFAULT NULL,
+	ayah       INT     DEFAULT NULL,
+	created_at INT     NOT NULL,
+	PRIMARY KEY (id),
+	CONSTRAINT bookmark_target_CHECK CHECK ((word IS NULL) <> (ayah IS NULL)),
+	CONSTRAINT bookmark_list_FK FOREIGN KEY (list) REFERENCES list (id) ON DELETE CASCADE,
+	CONSTRAINT bookmark_word_FK FOREIGN KEY (word) REFERENCES word (id),
+	CONSTRAINT bookmark_ayah_FK FOREIGN KEY (ayah) REFERENCES ayah (id))`
+
+const ddlCreateBookmarkWordIndex = `
+CREATE UNIQUE INDEX IF NOT EXISTS bookmark_word_UNIQUE ON bookmark (list, word)
+WHERE word IS NOT NULL`
+
+const ddlCreateBookmarkAyahIndex = `
+CREATE UNIQUE INDEX IF NOT EXISTS bookmark_ayah_UNIQUE ON bookmark (list, ayah)
+WHERE ayah IS NOT NULL`
+
+const ddlCreateGlossSuggestion = `
+CREATE TABLE IF NOT EXISTS gloss_suggestion (
+	id          INTEGER NOT NULL,
+	word        INT     NOT NULL,
+	language    TEXT    NOT NULL,
+	translation TEXT    NOT NULL,
+	reason      TEXT    NOT NULL DEFAULT '',
+	status      TEXT    NOT NULL DEFAULT 'pending',
+	created_at  INT     NOT NULL,
+	reviewed_at INT     DEFAULT NULL,
+	PRIMARY KEY (id),
+	CONSTRAINT gloss_suggestion_status_CHECK CHECK (status IN ('pending', 'accepted', 'rejected')),
+	CONSTRAINT gloss_suggestion_word_FK FOREIGN KEY (word) REFERENCES word (id))`
+
+const ddlCreateGlossOverride = `
+CREATE TABLE IF NOT EXISTS gloss_override (
+	word        INT  NOT NULL,
+	language    TEXT NOT NULL,
+	translation TEXT NOT NULL,
+	original    TEXT NOT NULL,
+	suggestion  INT  DEFAULT NULL,
+	updated_at  INT  NOT NULL,
+	PRIMARY KEY (word, language),
+	CONSTRAINT gloss_override_word_FK FOREIGN KEY (word) REFERENCES word (id),
+	CONSTRAINT gloss_override_suggestion_FK FOREIGN KEY (suggestion)
+		REFERENCES gloss_suggestion (id) ON DELETE SET NULL)`
+
+const ddlCreateOrderAttempt = `
+CREATE TABLE IF NOT EXISTS order_attempt (
+	id         INTEGER NOT NULL,
+	ayah       INT     NOT NULL,
+	correct    INT     NOT NULL,
+	mistakes   INT     NOT NULL,
+	duration   INT     NOT NULL,
+	created_at INT     NOT NULL,
+	PRIMARY KEY (id),
+	CONSTRAINT order_attempt_ayah_FK FOREIGN KEY (ayah) REFERENCES ayah (id))`
+
+const ddlCreateOrderAttemptIndex = `
+CREATE INDEX IF NOT EXISTS order_attempt_ayah_IDX ON order_attempt (ayah)`
+
+const ddlCreateWordAudio = `
+CREATE TABLE IF NOT EXISTS word_audio (
+	reciter TEXT NOT NULL,
+	word    INT  NOT NULL,
+	path    TEXT NOT NULL,
+	PRIMARY KEY (reciter, word),
+	CONSTRAINT word_audio_word_FK FOREIGN KEY (word) REFERENCES word (id))`
+
+const ddlCreateActivity = `
+CREATE TABLE IF NOT EXISTS activity (
+	id         INTEGER NOT NULL,
+	word       INT     NOT NULL,
+	mode       TEXT    NOT NULL,
+	correct    INT     NOT NULL,
+	created_at INT     NOT NULL,
+	PRIMARY KEY (id),
+	CONSTRAINT activity_word_FK FOREIGN KEY (word) REFERENCES word (id))`
+
+const ddlCreateActivityIndex = `
+CREATE INDEX IF NOT EXISTS activity_created_at_IDX ON activity (created_at)`
+
+const ddlCreateGoal = `
+CREATE TABLE IF NOT EXISTS goal (
+	id              INT NOT NULL,
+	words_per_day   INT NOT NULL DEFAULT 0,
+	minutes_per_day INT NOT NULL DEFAULT 0,
+	updated_at      INT NOT NULL,
+	PRIMARY KEY (id))`
+
+const ddlCreateSprint = `
+CREATE TABLE IF NOT EXISTS sprint (
+	id         INTEGER NOT NULL,
+	duration   INT     NOT NULL,
+	started_at INT     NOT NULL,
+	ends_at    INT     NOT NULL,
+	answered   INT     NOT NULL DEFAULT 0,
+	correct    INT     NOT NULL DEFAULT 0,
+	PRIMARY KEY (id))`
+
+// sqliteDDL is the queries for creating the tables in SQLite, ordered so each
+// table is created after the tables it refers to.
+var sqliteDDL = []string{
+	ddlCreateSurah,
+	ddlCreateAyah,
+	ddlCreateWord,
+	ddlCreateTracker,
+	ddlCreateAyahTracker,
+	ddlCreateTrackSubmission,
+	ddlCreateMetadata,
+	ddlCreateNote,
+	ddlCreateNoteIndex,
+	ddlCreateList,
+	ddlCreateBookmark,
+	ddlCreateBookmarkWordIndex,
+	ddlCreateBookmarkAyahIndex,
+	ddlCreateGlossSuggestion,
+	ddlCreateGlossOverride,
+	ddlCreateOrderAttempt,
+	ddlCreateOrderAttemptIndex,
+	ddlCreateWordAudio,
+	ddlCreateActivity,
+	ddlCreateActivityIndex,
+	ddlCreateGoal,
+	ddlCreateSprint,
+}
@@ -0,0 +1,376 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/mattn/go-sqlite3"
+)
+
+// Dialect is the flavour of SQL used by the database, named after its driver.
+//
+// The queries in kalimah are written in the subset of SQL that understood by both
+// SQLite and PostgreSQL: `?` placeholder, COALESCE, CASE, ON CONFLICT with explicit
+// target, RETURNING, LEAST, GREATEST, STRING_AGG and CEIL. The last four don't exist
+// in SQLite, so they are registered as custom functions, while for PostgreSQL the
+// placeholders are rebound and boolean arguments are stored as integer like SQLite.
+type Dialect string
+
+// Supported dialects.
+const (
+	SQLite   Dialect = "sqlite3"
+	Postgres Dialect = "postgres"
+)
+
+// sqliteDriverName is the SQLite driver with the functions needed by the dialect.
+const sqliteDriverName = "sqlite3_kalimah"
+
+func init() {
+	sql.Register(sqliteDriverName, &sqlite3.SQLiteDriver{
+		ConnectHook: registerSQLiteFunctions,
+	})
+}
+
+// DialectOf returns the dialect for the DSN. PostgreSQL is used for DSN in URL form,
+// i.e. prefixed with `postgres://` or `postgresql://`, while the rest is a path to
+// SQLite database file.
+func DialectOf(dsn string) Dialect {
+	if strings.HasPrefix(dsn, "postgres://") || strings.HasPrefix(dsn, "postgresql://") {
+		return Postgres
+	}
+	return SQLite
+}
+
+// DialectOfDB returns the dialect of the opened database.
+func DialectOfDB(db *sqlx.DB) Dialect {
+	return Dialect(db.DriverName())
+}
+
+// connect opens the database for the DSN.
+func (d Dialect) connect(dsn string) (*sqlx.DB, error) {
+	if d == Postgres {
+		connector, err := pq.NewConnector(dsn)
+		if err != nil {
+			return nil, err
+		}
+
+		db := sqlx.NewDb(sql.OpenDB(postgresConnector{connector}), string(Postgres))
+		return db, db.Ping()
+	}
+
+	db, err := sqlx.Connect(sqliteDriverName, dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	// Keep the driver name, so sqlx still knows it uses `?` placeholder
+	return sqlx.NewDb(db.DB, string(SQLite)), nil
+}
+
+// ddlQueries returns the queries for creating the tables in the dialect.
+func (d Dialect) ddlQueries() []string {
+	if d == Postgres {
+		return postgresDDL
+	}
+	return sqliteDDL
+}
+
+// migrationQueries returns the queries of the migration in the dialect.
+func (d Dialect) migrationQueries(m migration) []string {
+	if d == Postgres {
+		return m.postgres
+	}
+	return m.sqlite
+}
+
+// schemaVersion returns the schema version that saved in the database. For SQLite
+// it's stored as user version, while for PostgreSQL it's stored in metadata table.
+func (d Dialect) schemaVersion(q sqlx.Queryer) (int, error) {
+	var version int
+	if d != Postgres {
+		err := sqlx.Get(q, &version, `PRAGMA user_version`)
+		return version, err
+	}
+
+	var hasMetadata bool
+	err := sqlx.Get(q, &hasMetadata, `SELECT to_regclass('metadata') IS NOT NULL`)
+	if err != nil || !hasMetadata {
+		return 0, err
+	}
+
+	var value string
+	err = sqlx.Get(q, &value, `SELECT value FROM metadata WHERE key = 'schema_version'`)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+
+	return strconv.Atoi(value)
+}
+
+// setSchemaVersion saves the schema version into the database.
+func (d Dialect) setSchemaVersion(e sqlx.Execer, version int) error {
+	if d != Postgres {
+		_, err := e.Exec(fmt.Sprintf(`PRAGMA user_version = %d`, version))
+		return err
+	}
+
+	_, err := e.Exec(`INSERT INTO metadata (key, value) VALUES ('schema_version', ?)
+		ON CONFLICT (key) DO UPDATE SET value = excluded.value`, strconv.Itoa(version))
+	return err
+}
+
+// hasLegacyTables checks if the database already has tables from the time before
+// schema versioning exists, which only happened in SQLite.
+func (d Dialect) hasLegacyTables(q sqlx.Queryer) (bool, error) {
+	if d == Postgres {
+		return false, nil
+	}
+
+	var nTable int
+	err := sqlx.Get(q, &nTable, `SELECT COUNT(*) FROM sqlite_master
+		WHERE type = 'table' AND name = 'word'`)
+	return nTable > 0, err
+}
+
+// registerSQLiteFunctions registers the functions that exist in PostgreSQL but
+// not in SQLite, so the same queries can be used for both.
+func registerSQLiteFunctions(conn *sqlite3.SQLiteConn) error {
+	if err := conn.RegisterFunc("least", sqliteLeast, true); err != nil {
+		return err
+	}
+
+	if err := conn.RegisterFunc("greatest", sqliteGreatest, true); err != nil {
+		return err
+	}
+
+	if err := conn.RegisterFunc("ceil", sqliteCeil, true); err != nil {
+		return err
+	}
+
+	return conn.RegisterAggregator("string_agg", newSQLiteStringAgg, true)
+}
+
+// sqliteCeil rounds the number up. Integer is returned as it is.
+func sqliteCeil(arg interface{}) interface{} {
+	if f, isFloat := arg.(float64); isFloat {
+		return math.Ceil(f)
+	}
+	return arg
+}
+
+// sqliteLeast returns the smallest argument, ignoring NULL like PostgreSQL.
+func sqliteLeast(args ...interface{}) interface{} {
+	return sqliteExtreme(args, -1)
+}
+
+// sqliteGreatest returns the largest argument, ignoring NULL like PostgreSQL.
+func sqliteGreatest(args ...interface{}) interface{} {
+	return sqliteExtreme(args, 1)
+}
+
+// sqliteExtreme returns the argument which compared to the others has the same sign as
+// direction. The arguments are compared as integer, unless one of them is a float.
+func sqliteExtreme(args []interface{}, direction int) interface{} {
+	var result interface{}
+	for _, arg := range args {
+		switch arg.(type) {
+		case int64, float64:
+		default:
+			continue
+		}
+
+		if result == nil || compareNumber(arg, result) == direction {
+			result = arg
+		}
+	}
+	return result
+}
+
+// compareNumber compares two numbers that either int64 or float64.
+func compareNumber(a, b interface{}) int {
+	ai, aIsInt := a.(int64)
+	bi, bIsInt := b.(int64)
+	if aIsInt && bIsInt {
+		switch {
+		case ai < bi:
+			return -1
+		case ai > bi:
+			return 1
+		}
+		return 0
+	}
+
+	af, bf := toFloat(a), toFloat(b)
+	switch {
+	case af < bf:
+		return -1
+	case af > bf:
+		return 1
+	}
+	return 0
+}
+
+func toFloat(v interface{}) float64 {
+	if i, isInt := v.(int64); isInt {
+		return float64(i)
+	}
+	return v.(float64)
+}
+
+// sqliteStringAgg concatenates the non NULL values using the separator.
+type sqliteStringAgg struct {
+	values    []string
+	separator string
+}
+
+func newSQLiteStringAgg() *sqliteStringAgg {
+	return &sqliteStringAgg{}
+}
+
+func (agg *sqliteStringAgg) Step(value interface{}, separator string) {
+	agg.separator = separator
+	switch v := value.(type) {
+	case string:
+		agg.values = append(agg.values, v)
+	case []byte:
+		if v != nil {
+			agg.values = append(agg.values, string(v))
+		}
+	case nil:
+	default:
+		agg.values = append(agg.values, fmt.Sprint(v))
+	}
+}
+
+func (agg *sqliteStringAgg) Done() interface{} {
+	if len(agg.values) == 0 {
+		return nil
+	}
+	return strings.Join(agg.values, agg.separator)
+}
+
+// postgresConnector creates the PostgreSQL connection that accepts the queries
+// written for SQLite.
+type postgresConnector struct {
+	driver.Connector
+}
+
+func (c postgresConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.Connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &postgresConn{conn}, nil
+}
+
+// postgresConn rebinds the `?` placeholders into `$n`, and converts boolean
+// arguments into integer since kalimah stores boolean as integer column.
+type postgresConn struct {
+	driver.Conn
+}
+
+func (c *postgresConn) Prepare(query string) (driver.Stmt, error) {
+	return c.Conn.Prepare(rebindPostgres(query))
+}
+
+func (c *postgresConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	if preparer, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		return preparer.PrepareContext(ctx, rebindPostgres(query))
+	}
+	return c.Prepare(query)
+}
+
+func (c *postgresConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if queryer, ok := c.Conn.(driver.QueryerContext); ok {
+		return queryer.QueryContext(ctx, rebindPostgres(query), args)
+	}
+	return nil, driver.ErrSkip
+}
+
+func (c *postgresConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if execer, ok := c.Conn.(driver.ExecerContext); ok {
+		return execer.ExecContext(ctx, rebindPostgres(query), args)
+	}
+	return nil, driver.ErrSkip
+}
+
+func (c *postgresConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if beginner, ok := c.Conn.(driver.ConnBeginTx); ok {
+		return beginner.BeginTx(ctx, opts)
+	}
+	return c.Conn.Begin()
+}
+
+func (c *postgresConn) Ping(ctx context.Context) error {
+	if pinger, ok := c.Conn.(driver.Pinger); ok {
+		return pinger.Ping(ctx)
+	}
+	return nil
+}
+
+func (c *postgresConn) ResetSession(ctx context.Context) error {
+	if resetter, ok := c.Conn.(driver.SessionResetter); ok {
+		return resetter.ResetSession(ctx)
+	}
+	return nil
+}
+
+func (c *postgresConn) IsValid() bool {
+	if validator, ok := c.Conn.(driver.Validator); ok {
+		return validator.IsValid()
+	}
+	return true
+}
+
+func (c *postgresConn) CheckNamedValue(nv *driver.NamedValue) error {
+	value, err := driver.DefaultParameterConverter.ConvertValue(nv.Value)
+	if err != nil {
+		return err
+	}
+
+	if b, isBool := value.(bool); isBool {
+		value = int64(0)
+		if b {
+			value = int64(1)
+		}
+	}
+
+	nv.Value = value
+	return nil
+}
+
+// rebindPostgres replaces the `?` placeholders with `$n`, skipping the one
+// inside string literal or quoted identifier.
+func rebindPostgres(query string) string {
+	var sb strings.Builder
+	sb.Grow(len(query) + 10)
+
+	n := 0
+	var quote rune
+	for _, r := range query {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"':
+			quote = r
+		case r == '?':
+			n++
+			sb.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		sb.WriteRune(r)
+	}
+
+	return sb.String()
+}
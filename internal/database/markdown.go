@@ -0,0 +1,18 @@
+package database
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/yuin/goldmark"
+)
+
+// RenderMarkdown converts Markdown into HTML. Raw HTML within the Markdown is
+// omitted, so the result is safe to be shown as it is.
+func RenderMarkdown(content string) (string, error) {
+	var buf bytes.Buffer
+	if err := goldmark.Convert([]byte(content), &buf); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
@@ -0,0 +1,76 @@
+package database
+
+import (
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// migration is the queries to upgrade the schema by one version, written for each
+// dialect since the column types differ between them.
+type migration struct {
+	sqlite   []string
+	postgres []string
+}
+
+// migrations is the queries to upgrade database from the previous schema version,
+// where migrations[i] upgrades the schema from version i+1 into version i+2. Table
+// that newly added doesn't need migration since it's created by DDL queries.
+var migrations = []migration{
+	// 1 -> 2: add track_submission table
+	{},
+	// 2 -> 3: add nastaliq script for word and metadata table
+	{
+		sqlite:   []string{`ALTER TABLE word ADD COLUMN nastaliq TEXT NOT NULL DEFAULT ''`},
+		postgres: []string{`ALTER TABLE word ADD COLUMN IF NOT EXISTS nastaliq TEXT NOT NULL DEFAULT ''`},
+	},
+	// 3 -> 4: add note table
+	{},
+	// 4 -> 5: add list and bookmark table
+	{},
+	// 5 -> 6: add gloss_suggestion and gloss_override table
+	{},
+	// 6 -> 7: add ayah_tracker table
+	{},
+	// 7 -> 8: add order_attempt table
+	{},
+	// 8 -> 9: add word_audio table
+	{},
+	// 9 -> 10: add activity, goal and sprint table
+	{},
+}
+
+// currentSchemaVersion returns the schema version of database before it's opened by
+// this app. Database that created before schema versioning exists is on version 1,
+// while the new, empty database is on version 0.
+func currentSchemaVersion(dialect Dialect, tx *sqlx.Tx) (int, error) {
+	version, err := dialect.schemaVersion(tx)
+	if err != nil || version > 0 {
+		return version, err
+	}
+
+	hasLegacyTables, err := dialect.hasLegacyTables(tx)
+	if err != nil || !hasLegacyTables {
+		return 0, err
+	}
+
+	return 1, nil
+}
+
+// migrate upgrades the database schema from the specified version into the latest.
+func migrate(dialect Dialect, tx *sqlx.Tx, fromVersion int) error {
+	if fromVersion > SchemaVersion {
+		return fmt.Errorf("database schema version %d is newer than supported version %d",
+			fromVersion, SchemaVersion)
+	}
+
+	for version := fromVersion; version > 0 && version < SchemaVersion; version++ {
+		for _, query := range dialect.migrationQueries(migrations[version-1]) {
+			if _, err := tx.Exec(query); err != nil {
+				return fmt.Errorf("failed to migrate schema to version %d: %w", version+1, err)
+			}
+		}
+	}
+
+	return dialect.setSchemaVersion(tx, SchemaVersion)
+}
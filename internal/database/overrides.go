@@ -0,0 +1,167 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var (
+	// ErrSuggestionNotFound is returned when the reviewed gloss suggestion doesn't exist.
+	ErrSuggestionNotFound = errors.New("suggestion not exist")
+
+	// ErrSuggestionReviewed is returned when the gloss suggestion has been reviewed before.
+	ErrSuggestionReviewed = errors.New("suggestion has been reviewed")
+
+	// ErrOverrideNotFound is returned when the removed gloss override doesn't exist.
+	ErrOverrideNotFound = errors.New("override not exist")
+)
+
+// AcceptSuggestion accepts the pending gloss suggestion and saves it as override.
+// If translation is not empty, it's used instead of the suggested one, which
+// allows the reviewer to fix the suggestion before accepting it.
+func AcceptSuggestion(db sqlx.Ext, id int, translation string) error {
+	return withTx(db, func(tx sqlx.Ext) error {
+		suggestion, err := pendingSuggestion(tx, id)
+		if err != nil {
+			return err
+		}
+
+		if translation == "" {
+			translation = suggestion.Translation
+		}
+
+		// Mark suggestion as accepted
+		now := time.Now().Unix()
+		_, err = tx.Exec(`UPDATE gloss_suggestion
+			SET status = 'accepted', translation = ?, reviewed_at = ?
+			WHERE id = ?`, translation, now, id)
+		if err != nil {
+			return err
+		}
+
+		// Save the override. The original translation is only available in word table
+		// when the suggestion is for the language that currently populated, otherwise
+		// it will be filled once the database is populated with that language.
+		_, err = tx.Exec(`
+			INSERT INTO gloss_override (word, language, translation, original, suggestion, updated_at)
+			SELECT w.id, ?, ?, CASE WHEN m.value = ? THEN w.translation ELSE '' END, ?, ?
+			FROM word w
+			LEFT JOIN metadata m ON m.key = 'language'
+			WHERE w.id = ?
+			ON CONFLICT (word, language) DO UPDATE
+			SET translation = excluded.translation,
+				suggestion = excluded.suggestion,
+				updated_at = excluded.updated_at`,
+			suggestion.Language, translation, suggestion.Language, id, now, suggestion.Word)
+		if err != nil {
+			return err
+		}
+
+		return applyOverrides(tx, suggestion.Language)
+	})
+}
+
+// RejectSuggestion rejects the pending gloss suggestion.
+func RejectSuggestion(db sqlx.Ext, id int) error {
+	return withTx(db, func(tx sqlx.Ext) error {
+		if _, err := pendingSuggestion(tx, id); err != nil {
+			return err
+		}
+
+		_, err := tx.Exec(`UPDATE gloss_suggestion
+			SET status = 'rejected', reviewed_at = ?
+			WHERE id = ?`, time.Now().Unix(), id)
+		return err
+	})
+}
+
+// RemoveOverride removes the gloss override, then restores the original
+// translation of the word.
+func RemoveOverride(db sqlx.Ext, wordID int, language string) error {
+	return withTx(db, func(tx sqlx.Ext) error {
+		var original string
+		err := sqlx.Get(tx, &original, `SELECT original FROM gloss_override
+			WHERE word = ? AND language = ?`, wordID, language)
+		if err == sql.ErrNoRows {
+			return ErrOverrideNotFound
+		} else if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(`UPDATE word SET translation = ?
+			WHERE id = ? AND ? = (SELECT value FROM metadata WHERE key = 'language')`,
+			original, wordID, language)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(`DELETE FROM gloss_override
+			WHERE word = ? AND language = ?`, wordID, language)
+		return err
+	})
+}
+
+// applyOverrides replaces the translation of words with the accepted overrides,
+// as long as the words are populated in the same language.
+func applyOverrides(tx sqlx.Execer, language string) error {
+	_, err := tx.Exec(`UPDATE word
+		SET translation = o.translation
+		FROM gloss_override o
+		WHERE o.word = word.id AND o.language = ?
+			AND o.language = (SELECT value FROM metadata WHERE key = 'language')`, language)
+	if err != nil {
+		return fmt.Errorf("failed to apply overrides: %w", err)
+	}
+	return nil
+}
+
+type suggestionRow struct {
+	Word        int    `db:"word"`
+	Language    string `db:"language"`
+	Translation string `db:"translation"`
+	Status      string `db:"status"`
+}
+
+// pendingSuggestion fetches the gloss suggestion and make sure it's still pending.
+func pendingSuggestion(tx sqlx.Queryer, id int) (suggestionRow, error) {
+	var suggestion suggestionRow
+	err := sqlx.Get(tx, &suggestion, `SELECT word, language, translation, status
+		FROM gloss_suggestion WHERE id = ?`, id)
+	if err == sql.ErrNoRows {
+		return suggestion, ErrSuggestionNotFound
+	} else if err != nil {
+		return suggestion, err
+	}
+
+	if suggestion.Status != "pending" {
+		return suggestion, ErrSuggestionReviewed
+	}
+
+	return suggestion, nil
+}
+
+// withTx runs the function within transaction, which committed when
+// the function succeed or rolled back otherwise. If db is already a
+// transaction, the function simply runs within it.
+func withTx(db sqlx.Ext, fn func(tx sqlx.Ext) error) error {
+	conn, isDB := db.(*sqlx.DB)
+	if !isDB {
+		return fn(db)
+	}
+
+	tx, err := conn.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err = fn(tx); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
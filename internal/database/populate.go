@@ -7,7 +7,12 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
-func PopulateData(db *sqlx.DB) error {
+func PopulateData(db *sqlx.DB, language string) error {
+	// Make sure the language is supported
+	if _, supported := Languages[language]; !supported {
+		return fmt.Errorf("language %q is not supported", language)
+	}
+
 	// Create transaction
 	logrus.Println("opening transaction")
 	tx, err := db.Beginx()
@@ -24,20 +29,33 @@ func PopulateData(db *sqlx.DB) error {
 
 	// Populate data
 	logrus.Println("populate surah")
-	if err = populateSurah(tx); err != nil {
+	if err = populateSurah(tx, language); err != nil {
 		return fmt.Errorf("failed to populate surah: %v", err)
 	}
 
 	logrus.Println("populate ayah")
-	if err = populateAyah(tx); err != nil {
+	if err = populateAyah(tx, language); err != nil {
 		return fmt.Errorf("failed to populate ayah: %v", err)
 	}
 
 	logrus.Println("populate words")
-	if err = populateWord(tx); err != nil {
+	if err = populateWord(tx, language); err != nil {
 		return fmt.Errorf("failed to populate word: %v", err)
 	}
 
+	logrus.Println("populate metadata")
+	_, err = tx.Exec(`
+		INSERT INTO metadata (key, value) VALUES ('language', ?)
+		ON CONFLICT (key) DO UPDATE SET value = excluded.value`, language)
+	if err != nil {
+		return fmt.Errorf("failed to populate metadata: %v", err)
+	}
+
+	logrus.Println("populate overrides")
+	if err = populateOverrides(tx, language); err != nil {
+		return fmt.Errorf("failed to populate overrides: %v", err)
+	}
+
 	logrus.Println("populate tracker")
 	_, err = tx.Exec(`
 		INSERT INTO tracker (id) VALUES (1) 
@@ -46,6 +64,13 @@ func PopulateData(db *sqlx.DB) error {
 		return fmt.Errorf("failed to populate tracker: %v", err)
 	}
 
+	_, err = tx.Exec(`
+		INSERT INTO ayah_tracker (id) VALUES (1)
+		ON CONFLICT DO NOTHING`)
+	if err != nil {
+		return fmt.Errorf("failed to populate ayah tracker: %v", err)
+	}
+
 	// Commit transaction
 	logrus.Println("commit transaction")
 	err = tx.Commit()
@@ -53,27 +78,27 @@ func PopulateData(db *sqlx.DB) error {
 	return nil
 }
 
-func populateSurah(tx *sqlx.Tx) error {
+func populateSurah(tx *sqlx.Tx, language string) error {
 	// Parse surah
 	ranges, err := parseSurahRange()
 	if err != nil {
 		return err
 	}
 
-	translations, err := parseSurahTranslation()
+	translations, err := parseSurahTranslation(language)
 	if err != nil {
 		return err
 	}
 
 	// Prepare query statement
 	stmt, err := tx.Preparex(`
-		INSERT INTO surah (id, name, translation, start, end)
+		INSERT INTO surah (id, name, translation, start, "end")
 		VALUES (?, ?, ?, ?, ?)
-		ON CONFLICT DO UPDATE
+		ON CONFLICT (id) DO UPDATE
 		SET name = excluded.name,
 			translation = excluded.translation,
 			start = excluded.start,
-			end = excluded.end`)
+			"end" = excluded."end"`)
 	if err != nil {
 		return err
 	}
@@ -93,14 +118,14 @@ func populateSurah(tx *sqlx.Tx) error {
 	return nil
 }
 
-func populateAyah(tx *sqlx.Tx) error {
+func populateAyah(tx *sqlx.Tx, language string) error {
 	// Open data
-	translations, err := parseAyahTranslation()
+	translations, err := parseAyahTranslation(language)
 	if err != nil {
 		return err
 	}
 
-	tafsirs, err := parseAyahTafsir()
+	tafsirs, err := parseAyahTafsir(language)
 	if err != nil {
 		return err
 	}
@@ -109,7 +134,7 @@ func populateAyah(tx *sqlx.Tx) error {
 	stmt, err := tx.Preparex(`
 		INSERT INTO ayah (id, translation, tafsir)
 		VALUES (?, ?, ?)
-		ON CONFLICT DO UPDATE
+		ON CONFLICT (id) DO UPDATE
 		SET translation = excluded.translation,
 			tafsir = excluded.tafsir`)
 	if err != nil {
@@ -130,26 +155,27 @@ func populateAyah(tx *sqlx.Tx) error {
 	return nil
 }
 
-func populateWord(tx *sqlx.Tx) error {
+func populateWord(tx *sqlx.Tx, language string) error {
 	// Open data
 	words, err := parseWord()
 	if err != nil {
 		return err
 	}
 
-	translations, err := parseWordTranslation()
+	translations, err := parseWordTranslation(language)
 	if err != nil {
 		return err
 	}
 
 	// Prepare query statement
 	stmt, err := tx.Preparex(`
-		INSERT INTO word (id, ayah, position, arabic, translation)
-		VALUES (?, ?, ?, ?, ?)
-		ON CONFLICT DO UPDATE
+		INSERT INTO word (id, ayah, position, arabic, nastaliq, translation)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE
 		SET ayah = excluded.ayah,
 			position = excluded.position,
 			arabic = excluded.arabic,
+			nastaliq = excluded.nastaliq,
 			translation = excluded.translation`)
 	if err != nil {
 		return err
@@ -162,7 +188,7 @@ func populateWord(tx *sqlx.Tx) error {
 		translation := translations[id]
 		arabic := word.Uthmani
 
-		_, err = stmt.Exec(id, word.Ayah, word.Position, arabic, translation)
+		_, err = stmt.Exec(id, word.Ayah, word.Position, arabic, word.Nastaliq, translation)
 		if err != nil {
 			return err
 		}
@@ -170,3 +196,17 @@ func populateWord(tx *sqlx.Tx) error {
 
 	return nil
 }
+
+// populateOverrides re-applies the accepted gloss overrides on top of the newly
+// populated words, so it's not clobbered by the embedded source. The seeded
+// translation is saved as the original one.
+func populateOverrides(tx *sqlx.Tx, language string) error {
+	_, err := tx.Exec(`UPDATE gloss_override
+		SET original = (SELECT translation FROM word WHERE id = gloss_override.word)
+		WHERE language = ?`, language)
+	if err != nil {
+		return err
+	}
+
+	return applyOverrides(tx, language)
+}
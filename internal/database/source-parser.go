@@ -2,7 +2,6 @@ package database
 
 import (
 	"bufio"
-	"bytes"
 	"compress/gzip"
 	"embed"
 	"encoding/json"
@@ -10,8 +9,6 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
-
-	"github.com/yuin/goldmark"
 )
 
 type SurahRange struct {
@@ -31,6 +28,12 @@ type Word struct {
 	Nastaliq string `json:"nastaliq"`
 }
 
+// Languages is the list of supported translation language, mapped from its code
+// into the name that used in source files.
+var Languages = map[string]string{
+	"id": "indonesia",
+}
+
 var (
 	//go:embed source
 	sourceAssets embed.FS
@@ -62,9 +65,9 @@ func parseSurahRange() (map[int]SurahRange, error) {
 	return data, nil
 }
 
-func parseSurahTranslation() (map[int]SurahTranslation, error) {
+func parseSurahTranslation(language string) (map[int]SurahTranslation, error) {
 	// Open source
-	f, err := sourceAssets.Open("source/surah-indonesia.json.gz")
+	f, err := sourceAssets.Open(sourcePath("surah", language, "json.gz"))
 	if err != nil {
 		return nil, fmt.Errorf("open failed: %w", err)
 	}
@@ -87,9 +90,9 @@ func parseSurahTranslation() (map[int]SurahTranslation, error) {
 	return data, nil
 }
 
-func parseAyahTranslation() (map[int]string, error) {
+func parseAyahTranslation(language string) (map[int]string, error) {
 	// Open source
-	f, err := sourceAssets.Open("source/ayah-indonesia.json.gz")
+	f, err := sourceAssets.Open(sourcePath("ayah", language, "json.gz"))
 	if err != nil {
 		return nil, fmt.Errorf("open failed: %w", err)
 	}
@@ -116,9 +119,9 @@ func parseAyahTranslation() (map[int]string, error) {
 	return data.Translations, nil
 }
 
-func parseAyahTafsir() (map[int]string, error) {
+func parseAyahTafsir(language string) (map[int]string, error) {
 	// Open source
-	f, err := sourceAssets.Open("source/ayah-tafsir-indonesia.md.gz")
+	f, err := sourceAssets.Open(sourcePath("ayah-tafsir", language, "md.gz"))
 	if err != nil {
 		return nil, fmt.Errorf("open failed: %w", err)
 	}
@@ -142,14 +145,11 @@ func parseAyahTafsir() (map[int]string, error) {
 			return nil
 		}
 
-		var buf bytes.Buffer
-		content := strings.Join(currentContent, "\n\n")
-		err = goldmark.Convert([]byte(content), &buf)
+		content, err := RenderMarkdown(strings.Join(currentContent, "\n\n"))
 		if err != nil {
 			return err
 		}
 
-		content = strings.TrimSpace(buf.String())
 		tafsirs[currentID] = content
 		return nil
 	}
@@ -211,9 +211,9 @@ func parseWord() (map[int]Word, error) {
 	return data, nil
 }
 
-func parseWordTranslation() (map[int]string, error) {
+func parseWordTranslation(language string) (map[int]string, error) {
 	// Open source
-	f, err := sourceAssets.Open("source/word-indonesia.json.gz")
+	f, err := sourceAssets.Open(sourcePath("word", language, "json.gz"))
 	if err != nil {
 		return nil, fmt.Errorf("open failed: %w", err)
 	}
@@ -235,3 +235,8 @@ func parseWordTranslation() (map[int]string, error) {
 
 	return data, nil
 }
+
+// sourcePath returns the path of translated source file for the language.
+func sourcePath(name string, language string, ext string) string {
+	return fmt.Sprintf("source/%s-%s.%s", name, Languages[language], ext)
+}
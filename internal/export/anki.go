@@ -0,0 +1,461 @@
+package export
+
+import (
+	"archive/zip"
+	"crypto/sha1"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"kalimah/internal/database"
+	"os"
+	fp "path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Kind of note that can be exported to Anki.
+const (
+	AnkiWordNote = "word"
+	AnkiAyahNote = "ayah"
+)
+
+// ankiFontName is the name of Arabic font inside the package. Media prefixed
+// with underscore is kept by Anki even when it's not referenced in any field.
+const ankiFontName = "_KFGQPC-HAFS.woff2"
+
+var rxHTMLTag = regexp.MustCompile(`<[^>]*>`)
+
+// AnkiOptions is the options for exporting Anki package.
+type AnkiOptions struct {
+	Scope
+	NoteKind string
+	DeckName string
+
+	// ArabicFont is the content of font file for Arabic text. Optional.
+	ArabicFont []byte
+}
+
+type ankiNote struct {
+	GUID   string
+	Fields []string
+	Tags   []string
+}
+
+type ankiModel struct {
+	Name     string
+	Fields   []string
+	RTL      []bool
+	Question string
+	Answer   string
+}
+
+// ExportAnki exports words or ayahs into Anki package (.apkg) in the destination path.
+func ExportAnki(db *sqlx.DB, dstPath string, opts AnkiOptions) error {
+	// Prepare notes
+	var notes []ankiNote
+	var model ankiModel
+	var err error
+
+	switch opts.NoteKind {
+	case AnkiWordNote:
+		model = wordModel
+		notes, err = ankiWordNotes(db, opts.Scope)
+	case AnkiAyahNote:
+		model = ayahModel
+		notes, err = ankiAyahNotes(db, opts.Scope)
+	default:
+		return fmt.Errorf("note kind must be either %s or %s", AnkiWordNote, AnkiAyahNote)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to load notes: %w", err)
+	}
+
+	if len(notes) == 0 {
+		return fmt.Errorf("no %s to export", opts.NoteKind)
+	}
+
+	// Create collection in temporary dir
+	tmpDir, err := os.MkdirTemp("", "kalimah-anki-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	collectionPath := fp.Join(tmpDir, "collection.anki2")
+	err = writeAnkiCollection(collectionPath, opts.DeckName, model, notes)
+	if err != nil {
+		return fmt.Errorf("failed to create collection: %w", err)
+	}
+
+	// Package the collection and media
+	dst, err := os.Create(dstPath)
+	if err != nil {
+		return err
+	}
+	defer dst.Close()
+
+	zw := zip.NewWriter(dst)
+	err = addFileToZip(zw, "collection.anki2", collectionPath)
+	if err != nil {
+		return err
+	}
+
+	media := map[string]string{}
+	if len(opts.ArabicFont) > 0 {
+		media["0"] = ankiFontName
+		mw, err := zw.Create("0")
+		if err != nil {
+			return err
+		}
+
+		if _, err = mw.Write(opts.ArabicFont); err != nil {
+			return err
+		}
+	}
+
+	mw, err := zw.Create("media")
+	if err != nil {
+		return err
+	}
+
+	if err = json.NewEncoder(mw).Encode(media); err != nil {
+		return err
+	}
+
+	if err = zw.Close(); err != nil {
+		return err
+	}
+
+	return dst.Close()
+}
+
+var wordModel = ankiModel{
+	Name:   "Kalimah Word",
+	Fields: []string{"Arabic", "Gloss", "Context", "Reference", "Notes"},
+	RTL:    []bool{true, false, true, false, false},
+	Question: `<div class="arabic">{{Arabic}}</div>` +
+		`<div class="context">{{Context}}</div>`,
+	Answer: `{{FrontSide}}<hr id="answer">` +
+		`<div class="gloss">{{Gloss}}</div>` +
+		`<div class="reference">{{Reference}}</div>` +
+		`{{#Notes}}<div class="notes">{{Notes}}</div>{{/Notes}}`,
+}
+
+var ayahModel = ankiModel{
+	Name:     "Kalimah Ayah",
+	Fields:   []string{"Arabic", "Translation", "Tafsir", "Reference", "Notes"},
+	RTL:      []bool{true, false, false, false, false},
+	Question: `<div class="arabic">{{Arabic}}</div>`,
+	Answer: `{{FrontSide}}<hr id="answer">` +
+		`<div class="translation">{{Translation}}</div>` +
+		`<div class="reference">{{Reference}}</div>` +
+		`{{#Notes}}<div class="notes">{{Notes}}</div>{{/Notes}}` +
+		`<div class="tafsir">{{Tafsir}}</div>`,
+}
+
+const ankiCSS = `@font-face { font-family: "KFGQPC-HAFS"; src: url("` + ankiFontName + `"); }
+.card { font-family: sans-serif; font-size: 20px; text-align: center; }
+.arabic { font-family: "KFGQPC-HAFS", serif; font-size: 40px; direction: rtl; }
+.context { font-family: "KFGQPC-HAFS", serif; font-size: 24px; direction: rtl; color: #777; margin-top: 16px; }
+.context b { color: #000; }
+.reference { font-size: 14px; color: #777; margin-top: 8px; }
+.tafsir { font-size: 16px; text-align: left; margin-top: 16px; }
+.notes { font-size: 16px; text-align: left; margin-top: 16px; padding: 8px; background: #fff8dc; }`
+
+func ankiWordNotes(db *sqlx.DB, scope Scope) ([]ankiNote, error) {
+	ayahs, err := loadAyahs(db, Scope{FirstAyah: scope.FirstAyah, LastAyah: scope.LastAyah})
+	if err != nil {
+		return nil, err
+	}
+
+	var notes []ankiNote
+	for _, ayah := range ayahs {
+		for i, word := range ayah.Words {
+			if scope.OnlyLearned && !word.Learned {
+				continue
+			}
+
+			// Put the word within its ayah, with the word itself highlighted
+			context := make([]string, len(ayah.Words))
+			for j, w := range ayah.Words {
+				context[j] = html.EscapeString(w.Arabic)
+				if j == i {
+					context[j] = "<b>" + context[j] + "</b>"
+				}
+			}
+
+			personalNotes, err := renderNotes(word.Notes)
+			if err != nil {
+				return nil, err
+			}
+
+			notes = append(notes, ankiNote{
+				GUID: fmt.Sprintf("kalimah-word-%d", word.ID),
+				Fields: []string{
+					html.EscapeString(word.Arabic),
+					html.EscapeString(word.Translation),
+					strings.Join(context, " "),
+					fmt.Sprintf("%s %d:%d", html.EscapeString(ayah.SurahName), ayah.Surah, ayah.Ayah),
+					personalNotes,
+				},
+				Tags: ankiTags(ayah),
+			})
+		}
+	}
+
+	return notes, nil
+}
+
+func ankiAyahNotes(db *sqlx.DB, scope Scope) ([]ankiNote, error) {
+	ayahs, err := loadAyahs(db, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	var notes []ankiNote
+	for _, ayah := range ayahs {
+		if scope.OnlyLearned && !ayah.Learned {
+			continue
+		}
+
+		arabic := make([]string, len(ayah.Words))
+		for i, w := range ayah.Words {
+			arabic[i] = html.EscapeString(w.Arabic)
+		}
+
+		personalNotes, err := renderNotes(ayah.Notes)
+		if err != nil {
+			return nil, err
+		}
+
+		notes = append(notes, ankiNote{
+			GUID: fmt.Sprintf("kalimah-ayah-%d", ayah.ID),
+			Fields: []string{
+				strings.Join(arabic, " "),
+				html.EscapeString(ayah.Translation),
+				ayah.Tafsir,
+				fmt.Sprintf("%s %d:%d", html.EscapeString(ayah.SurahName), ayah.Surah, ayah.Ayah),
+				personalNotes,
+			},
+			Tags: ankiTags(ayah),
+		})
+	}
+
+	return notes, nil
+}
+
+// renderNotes renders the Markdown of personal notes into a single HTML.
+func renderNotes(notes []string) (string, error) {
+	rendered := make([]string, len(notes))
+	for i, note := range notes {
+		html, err := database.RenderMarkdown(note)
+		if err != nil {
+			return "", err
+		}
+		rendered[i] = html
+	}
+	return strings.Join(rendered, "\n"), nil
+}
+
+func ankiTags(ayah ayahRow) []string {
+	surahName := strings.ReplaceAll(ayah.SurahName, " ", "_")
+	return []string{
+		"kalimah",
+		fmt.Sprintf("surah::%03d_%s", ayah.Surah, surahName),
+		fmt.Sprintf("%d:%d", ayah.Surah, ayah.Ayah),
+	}
+}
+
+// writeAnkiCollection writes the notes into a new Anki collection (schema version 11).
+func writeAnkiCollection(path string, deckName string, model ankiModel, notes []ankiNote) (err error) {
+	col, err := sqlx.Connect("sqlite3", path)
+	if err != nil {
+		return err
+	}
+	defer col.Close()
+
+	tx, err := col.Beginx()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	for _, query := range ankiDDLQueries {
+		if _, err = tx.Exec(query); err != nil {
+			return err
+		}
+	}
+
+	// Prepare collection metadata
+	now := time.Now()
+	nowMs := now.UnixNano() / int64(time.Millisecond)
+	deckID := nowMs
+	modelID := nowMs + 1
+
+	modelsJSON, err := json.Marshal(map[string]interface{}{
+		fmt.Sprint(modelID): ankiModelJSON(modelID, deckID, model, now.Unix()),
+	})
+	if err != nil {
+		return err
+	}
+
+	decksJSON, err := json.Marshal(map[string]interface{}{
+		"1":                ankiDeckJSON(1, "Default", now.Unix()),
+		fmt.Sprint(deckID): ankiDeckJSON(deckID, deckName, now.Unix()),
+	})
+	if err != nil {
+		return err
+	}
+
+	confJSON, err := json.Marshal(map[string]interface{}{
+		"nextPos": len(notes) + 1, "estTimes": true, "activeDecks": []int64{deckID},
+		"sortType": "noteFld", "timeLim": 0, "sortBackwards": false, "addToCur": true,
+		"curDeck": deckID, "newBury": true, "newSpread": 0, "dueCounts": true,
+		"curModel": fmt.Sprint(modelID), "collapseTime": 1200,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`INSERT INTO col VALUES (1, ?, ?, ?, 11, 0, 0, 0, ?, ?, ?, ?, '{}')`,
+		now.Unix(), nowMs, nowMs, string(confJSON), string(modelsJSON),
+		string(decksJSON), ankiDeckConfJSON)
+	if err != nil {
+		return err
+	}
+
+	// Insert notes and its card
+	for i, note := range notes {
+		noteID := nowMs + int64(i)
+		sortField := stripHTML(note.Fields[0])
+
+		_, err = tx.Exec(`INSERT INTO notes VALUES (?, ?, ?, ?, -1, ?, ?, ?, ?, 0, '')`,
+			noteID, note.GUID, modelID, now.Unix(),
+			" "+strings.Join(note.Tags, " ")+" ",
+			strings.Join(note.Fields, "\x1f"),
+			sortField, fieldChecksum(sortField))
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(`INSERT INTO cards VALUES (?, ?, ?, 0, ?, -1, 0, 0, ?, 0, 0, 0, 0, 0, 0, 0, 0, '')`,
+			noteID, noteID, deckID, now.Unix(), i+1)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func ankiModelJSON(modelID, deckID int64, model ankiModel, mod int64) map[string]interface{} {
+	fields := make([]map[string]interface{}, len(model.Fields))
+	for i, name := range model.Fields {
+		fields[i] = map[string]interface{}{
+			"name": name, "ord": i, "sticky": false, "rtl": model.RTL[i],
+			"font": "Arial", "size": 20, "media": []string{},
+		}
+	}
+
+	return map[string]interface{}{
+		"id": modelID, "name": model.Name, "type": 0, "mod": mod, "usn": -1,
+		"sortf": 0, "did": deckID, "flds": fields, "css": ankiCSS,
+		"tmpls": []map[string]interface{}{{
+			"name": "Card 1", "ord": 0, "qfmt": model.Question, "afmt": model.Answer,
+			"did": nil, "bqfmt": "", "bafmt": "",
+		}},
+		"latexPre": "\\documentclass[12pt]{article}\n\\special{papersize=3in,5in}\n" +
+			"\\usepackage[utf8]{inputenc}\n\\usepackage{amssymb,amsmath}\n" +
+			"\\pagestyle{empty}\n\\setlength{\\parindent}{0in}\n\\begin{document}\n",
+		"latexPost": "\\end{document}",
+		"tags":      []string{},
+		"vers":      []string{},
+		"req":       []interface{}{[]interface{}{0, "all", []int{0}}},
+	}
+}
+
+func ankiDeckJSON(deckID int64, name string, mod int64) map[string]interface{} {
+	return map[string]interface{}{
+		"id": deckID, "name": name, "desc": "", "mod": mod, "usn": -1, "dyn": 0,
+		"conf": 1, "collapsed": false, "extendNew": 10, "extendRev": 50,
+		"newToday": []int{0, 0}, "revToday": []int{0, 0},
+		"lrnToday": []int{0, 0}, "timeToday": []int{0, 0},
+	}
+}
+
+const ankiDeckConfJSON = `{"1": {"id": 1, "name": "Default", "mod": 0, "usn": 0,
+"maxTaken": 60, "autoplay": true, "timer": 0, "replayq": true, "dyn": false,
+"new": {"delays": [1, 10], "ints": [1, 4, 7], "initialFactor": 2500, "order": 1, "perDay": 20, "bury": true, "separate": true},
+"lapse": {"delays": [10], "mult": 0, "minInt": 1, "leechFails": 8, "leechAction": 0},
+"rev": {"perDay": 100, "ease4": 1.3, "fuzz": 0.05, "maxIvl": 36500, "bury": true, "minSpace": 1}}}`
+
+var ankiDDLQueries = []string{
+	`CREATE TABLE col (
+		id integer primary key, crt integer not null, mod integer not null,
+		scm integer not null, ver integer not null, dty integer not null,
+		usn integer not null, ls integer not null, conf text not null,
+		models text not null, decks text not null, dconf text not null,
+		tags text not null)`,
+	`CREATE TABLE notes (
+		id integer primary key, guid text not null, mid integer not null,
+		mod integer not null, usn integer not null, tags text not null,
+		flds text not null, sfld integer not null, csum integer not null,
+		flags integer not null, data text not null)`,
+	`CREATE TABLE cards (
+		id integer primary key, nid integer not null, did integer not null,
+		ord integer not null, mod integer not null, usn integer not null,
+		type integer not null, queue integer not null, due integer not null,
+		ivl integer not null, factor integer not null, reps integer not null,
+		lapses integer not null, left integer not null, odue integer not null,
+		odid integer not null, flags integer not null, data text not null)`,
+	`CREATE TABLE revlog (
+		id integer primary key, cid integer not null, usn integer not null,
+		ease integer not null, ivl integer not null, lastIvl integer not null,
+		factor integer not null, time integer not null, type integer not null)`,
+	`CREATE TABLE graves (usn integer not null, oid integer not null, type integer not null)`,
+	`CREATE INDEX ix_notes_usn ON notes (usn)`,
+	`CREATE INDEX ix_cards_usn ON cards (usn)`,
+	`CREATE INDEX ix_revlog_usn ON revlog (usn)`,
+	`CREATE INDEX ix_cards_nid ON cards (nid)`,
+	`CREATE INDEX ix_cards_sched ON cards (did, queue, due)`,
+	`CREATE INDEX ix_revlog_cid ON revlog (cid)`,
+	`CREATE INDEX ix_notes_csum ON notes (csum)`,
+}
+
+func stripHTML(s string) string {
+	return html.UnescapeString(rxHTMLTag.ReplaceAllString(s, ""))
+}
+
+// fieldChecksum returns the first 8 digits of SHA1 hash of field, as used by Anki
+// to detect duplicate notes.
+func fieldChecksum(field string) int64 {
+	sum := sha1.Sum([]byte(field))
+	return int64(binary.BigEndian.Uint32(sum[:4]))
+}
+
+func addFileToZip(zw *zip.Writer, name string, srcPath string) error {
+	src, err := os.Open(srcPath)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(dst, src)
+	return err
+}
@@ -0,0 +1,243 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Format of exported corpus.
+const (
+	FormatCSV   = "csv"
+	FormatTSV   = "tsv"
+	FormatJSONL = "jsonl"
+)
+
+// Level of exported corpus, i.e. what each row represents.
+const (
+	LevelWord  = "word"
+	LevelAyah  = "ayah"
+	LevelSurah = "surah"
+)
+
+const (
+	// utf8BOM helps spreadsheet apps to detect the file as UTF-8.
+	utf8BOM = "\ufeff"
+
+	// firstStrongIsolate and popDirectionalIsolate wraps the Arabic text, so its
+	// right-to-left direction doesn't leak into the surrounding columns.
+	firstStrongIsolate    = "\u2068"
+	popDirectionalIsolate = "\u2069"
+)
+
+// CorpusOptions is the options for exporting corpus.
+type CorpusOptions struct {
+	Scope
+	Format        string
+	Level         string
+	Language      string
+	IncludeTafsir bool
+
+	// WriteBOM writes UTF-8 byte order mark at the start of CSV and TSV.
+	WriteBOM bool
+
+	// IsolateArabic wraps Arabic text with Unicode directional isolates.
+	IsolateArabic bool
+}
+
+// corpusColumn is a column in corpus. Arabic column is affected by IsolateArabic,
+// while Number column is written as number in JSONL.
+type corpusColumn struct {
+	Name   string
+	Arabic bool
+	Number bool
+}
+
+// ExportCorpus streams the corpus rows from database into the writer.
+func ExportCorpus(db *sqlx.DB, w io.Writer, opts CorpusOptions) error {
+	// Prepare query for the level
+	query, columns, err := corpusQuery(opts)
+	if err != nil {
+		return err
+	}
+
+	// Prepare row writer
+	var writeRow func(values []string) error
+	switch opts.Format {
+	case FormatCSV, FormatTSV:
+		if opts.WriteBOM {
+			if _, err = io.WriteString(w, utf8BOM); err != nil {
+				return err
+			}
+		}
+
+		cw := csv.NewWriter(w)
+		if opts.Format == FormatTSV {
+			cw.Comma = '\t'
+		}
+		defer cw.Flush()
+
+		header := make([]string, len(columns))
+		for i, col := range columns {
+			header[i] = col.Name
+		}
+
+		if err = cw.Write(header); err != nil {
+			return err
+		}
+
+		writeRow = cw.Write
+
+	case FormatJSONL:
+		enc := json.NewEncoder(w)
+		enc.SetEscapeHTML(false)
+		writeRow = func(values []string) error {
+			row := make(map[string]interface{}, len(values))
+			for i, col := range columns {
+				row[col.Name] = values[i]
+				if number, err := strconv.Atoi(values[i]); err == nil && col.Number {
+					row[col.Name] = number
+				}
+			}
+			return enc.Encode(row)
+		}
+
+	default:
+		return fmt.Errorf("format must be %s, %s or %s", FormatCSV, FormatTSV, FormatJSONL)
+	}
+
+	// Stream the rows
+	rows, err := db.Queryx(query, opts.FirstAyah, opts.LastAyah)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		values := make([]string, len(columns))
+		pointers := make([]interface{}, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+
+		if err = rows.Scan(pointers...); err != nil {
+			return err
+		}
+
+		if opts.IsolateArabic {
+			for i, col := range columns {
+				if col.Arabic && values[i] != "" {
+					values[i] = firstStrongIsolate + values[i] + popDirectionalIsolate
+				}
+			}
+		}
+
+		if err = writeRow(values); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func corpusQuery(opts CorpusOptions) (string, []corpusColumn, error) {
+	translation := "translation_" + opts.Language
+
+	switch opts.Level {
+	case LevelWord:
+		columns := []corpusColumn{
+			{Name: "surah", Number: true},
+			{Name: "ayah", Number: true},
+			{Name: "position", Number: true},
+			{Name: "word_id", Number: true},
+			{Name: "arabic_uthmani", Arabic: true},
+			{Name: "arabic_nastaliq", Arabic: true},
+			{Name: translation},
+			{Name: "notes"},
+		}
+
+		query := `SELECT s.id, w.ayah-s.start+1, w.position, w.id,
+			w.arabic, w.nastaliq, w.translation, ` + notesColumn("word", "w.id") + `
+		FROM word w
+		JOIN surah s ON w.ayah >= s.start AND w.ayah <= s.end
+		WHERE w.ayah >= ? AND w.ayah <= ? ` + learnedFilter(opts.OnlyLearned, "w.id") + `
+		ORDER BY w.id`
+		return query, columns, nil
+
+	case LevelAyah:
+		columns := []corpusColumn{
+			{Name: "surah", Number: true},
+			{Name: "ayah", Number: true},
+			{Name: "ayah_id", Number: true},
+			{Name: "arabic_uthmani", Arabic: true},
+			{Name: "arabic_nastaliq", Arabic: true},
+			{Name: translation},
+			{Name: "notes"},
+		}
+
+		tafsirColumn := ""
+		if opts.IncludeTafsir {
+			columns = append(columns, corpusColumn{Name: "tafsir_" + opts.Language})
+			tafsirColumn = ", a.tafsir"
+		}
+
+		query := `WITH ayah_text AS (
+			SELECT w.ayah, STRING_AGG(w.arabic, ' ') arabic,
+				STRING_AGG(w.nastaliq, ' ') nastaliq, MAX(w.id) last_word
+			FROM (SELECT * FROM word ORDER BY ayah, position) w
+			WHERE w.ayah >= ? AND w.ayah <= ?
+			GROUP BY w.ayah)
+		SELECT s.id, a.id-s.start+1, a.id, at.arabic, TRIM(at.nastaliq),
+			a.translation, ` + notesColumn("ayah", "a.id") + tafsirColumn + `
+		FROM ayah a
+		JOIN ayah_text at ON at.ayah = a.id
+		JOIN surah s ON a.id >= s.start AND a.id <= s.end
+		WHERE TRUE ` + learnedFilter(opts.OnlyLearned, "at.last_word") + `
+		ORDER BY a.id`
+		return query, columns, nil
+
+	case LevelSurah:
+		if opts.IncludeTafsir {
+			return "", nil, fmt.Errorf("tafsir is only available in %s level", LevelAyah)
+		}
+
+		columns := []corpusColumn{
+			{Name: "surah", Number: true},
+			{Name: "name"},
+			{Name: translation},
+			{Name: "ayah_count", Number: true},
+			{Name: "first_ayah_id", Number: true},
+			{Name: "last_ayah_id", Number: true},
+		}
+
+		query := `SELECT s.id, s.name, s.translation, s.end-s.start+1, s.start, s.end
+		FROM surah s
+		WHERE s.end >= ? AND s.start <= ? ` +
+			learnedFilter(opts.OnlyLearned, "(SELECT MAX(id) FROM word WHERE ayah = s.end)") + `
+		ORDER BY s.id`
+		return query, columns, nil
+
+	default:
+		return "", nil, fmt.Errorf("level must be %s, %s or %s", LevelWord, LevelAyah, LevelSurah)
+	}
+}
+
+// learnedFilter returns SQL condition that limits the rows to the learned ones,
+// i.e. the ones whose last word has been answered.
+func learnedFilter(onlyLearned bool, lastWordExpr string) string {
+	if !onlyLearned {
+		return ""
+	}
+	return `AND ` + lastWordExpr + ` <= (SELECT COALESCE(MAX(last_word), 0) FROM tracker WHERE id = 1)`
+}
+
+// notesColumn returns SQL expression that joins the Markdown content of personal
+// notes for the word or ayah, separated by blank line.
+func notesColumn(target string, idExpr string) string {
+	return `COALESCE((SELECT STRING_AGG(content, '` + "\n\n" + `')
+		FROM (SELECT content FROM note WHERE ` + target + ` = ` + idExpr + ` ORDER BY id) n), '')`
+}
@@ -0,0 +1,154 @@
+package export
+
+import (
+	"github.com/jmoiron/sqlx"
+)
+
+// Scope is the range of data to be exported.
+type Scope struct {
+	// FirstAyah and LastAyah is the absolute ID of ayah, i.e. 1-6236.
+	FirstAyah int
+	LastAyah  int
+
+	// OnlyLearned limits the data to words and ayahs that already answered.
+	OnlyLearned bool
+}
+
+type wordRow struct {
+	ID          int    `db:"id"`
+	AyahID      int    `db:"ayah_id"`
+	Surah       int    `db:"surah"`
+	SurahName   string `db:"surah_name"`
+	Ayah        int    `db:"ayah"`
+	Position    int    `db:"position"`
+	Arabic      string `db:"arabic"`
+	Translation string `db:"translation"`
+	Learned     bool   `db:"learned"`
+
+	Notes []string
+}
+
+type ayahRow struct {
+	ID          int    `db:"id"`
+	Surah       int    `db:"surah"`
+	SurahName   string `db:"surah_name"`
+	Ayah        int    `db:"ayah"`
+	Translation string `db:"translation"`
+	Tafsir      string `db:"tafsir"`
+	Learned     bool   `db:"learned"`
+
+	Words []wordRow
+	Notes []string
+}
+
+type noteRow struct {
+	WordID  int    `db:"word"`
+	AyahID  int    `db:"ayah"`
+	Content string `db:"content"`
+}
+
+// loadWords fetches words within the scope, ordered by its ID.
+func loadWords(db *sqlx.DB, scope Scope) ([]wordRow, error) {
+	var words []wordRow
+	err := db.Select(&words,
+		`WITH last_word AS (
+			SELECT COALESCE(MAX(last_word), 0) id FROM tracker WHERE id = 1)
+		SELECT w.id, w.ayah ayah_id, s.id surah, s.name surah_name,
+			w.ayah-s.start+1 ayah, w.position, w.arabic, w.translation,
+			w.id <= lw.id learned
+		FROM word w
+		JOIN surah s ON w.ayah >= s.start AND w.ayah <= s.end
+		CROSS JOIN last_word lw
+		WHERE w.ayah >= ? AND w.ayah <= ?
+		ORDER BY w.id`, scope.FirstAyah, scope.LastAyah)
+	if err != nil {
+		return nil, err
+	}
+
+	// Attach notes to its word
+	wordNotes, _, err := loadNotes(db, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, word := range words {
+		words[i].Notes = wordNotes[word.ID]
+	}
+
+	return words, nil
+}
+
+// loadAyahs fetches ayahs within the scope along with its words, ordered by its ID.
+// Ayah is considered learned when all of its words has been answered.
+func loadAyahs(db *sqlx.DB, scope Scope) ([]ayahRow, error) {
+	var ayahs []ayahRow
+	err := db.Select(&ayahs,
+		`WITH last_word AS (
+			SELECT COALESCE(MAX(last_word), 0) id FROM tracker WHERE id = 1),
+		ayah_last_word AS (
+			SELECT ayah, MAX(id) id FROM word
+			WHERE ayah >= ? AND ayah <= ?
+			GROUP BY ayah)
+		SELECT a.id, s.id surah, s.name surah_name, a.id-s.start+1 ayah,
+			a.translation, a.tafsir, alw.id <= lw.id learned
+		FROM ayah a
+		JOIN surah s ON a.id >= s.start AND a.id <= s.end
+		JOIN ayah_last_word alw ON alw.ayah = a.id
+		CROSS JOIN last_word lw
+		ORDER BY a.id`, scope.FirstAyah, scope.LastAyah)
+	if err != nil {
+		return nil, err
+	}
+
+	// Attach notes and words to its ayah
+	_, ayahNotes, err := loadNotes(db, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	words, err := loadWords(db, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	ayahIdx := map[int]int{}
+	for i, ayah := range ayahs {
+		ayahIdx[ayah.ID] = i
+		ayahs[i].Notes = ayahNotes[ayah.ID]
+	}
+
+	for _, word := range words {
+		if i, exist := ayahIdx[word.AyahID]; exist {
+			ayahs[i].Words = append(ayahs[i].Words, word)
+		}
+	}
+
+	return ayahs, nil
+}
+
+// loadNotes fetches the Markdown content of notes within the scope, grouped by
+// the ID of its word and ayah.
+func loadNotes(db *sqlx.DB, scope Scope) (map[int][]string, map[int][]string, error) {
+	var notes []noteRow
+	err := db.Select(&notes,
+		`SELECT COALESCE(n.word, 0) word, COALESCE(n.ayah, 0) ayah, n.content
+		FROM note n
+		LEFT JOIN word w ON w.id = n.word
+		WHERE COALESCE(n.ayah, w.ayah) >= ? AND COALESCE(n.ayah, w.ayah) <= ?
+		ORDER BY n.id`, scope.FirstAyah, scope.LastAyah)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	wordNotes := map[int][]string{}
+	ayahNotes := map[int][]string{}
+	for _, note := range notes {
+		if note.WordID != 0 {
+			wordNotes[note.WordID] = append(wordNotes[note.WordID], note.Content)
+		} else {
+			ayahNotes[note.AyahID] = append(ayahNotes[note.AyahID], note.Content)
+		}
+	}
+
+	return wordNotes, ayahNotes, nil
+}
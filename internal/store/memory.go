@@ -0,0 +1,1261 @@
+package store
+
+import (
+	"context"
+	"kalimah/internal/database"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Memory is the store that keeps everything in memory. It's meant as a fake
+// for testing, so it can be filled with only the few surah that needed.
+type Memory struct {
+	mu sync.RWMutex
+	memoryData
+
+	// txMu serializes the transactions, so the data read within a
+	// transaction isn't changed by the other transactions.
+	txMu sync.Mutex
+}
+
+// memoryData is the data of memory store, which copied as a whole
+// so it can be restored when a transaction is rolled back.
+type memoryData struct {
+	surahs      []Surah
+	ayahs       map[int]Ayah
+	words       []Word
+	lastWord    int
+	lastAyah    int
+	answers     []memoryAnswer
+	submissions map[string]memorySubmission
+	goal        Goal
+	lists       []List
+	bookmarks   []Bookmark
+	notes       []Note
+	sprints     []Sprint
+	attempts    []OrderAttempt
+	audio       map[string]map[int]string
+	language    string
+	suggestions []Suggestion
+	overrides   []Override
+	secret      string
+	lastIDs     map[string]int
+}
+
+// memoryAnswer is an answer recorded by the memory store.
+type memoryAnswer struct {
+	WordID  int
+	Mode    string
+	Correct bool
+	At      time.Time
+}
+
+// memorySubmission is a track submission processed by the memory store.
+type memorySubmission struct {
+	WordID int
+	Status string
+	At     time.Time
+}
+
+// memoryTx is the memory store within a transaction.
+type memoryTx struct {
+	*Memory
+}
+
+// NewMemory returns the memory store filled with the specified data.
+func NewMemory(surahs []Surah, ayahs []Ayah, words []Word) *Memory {
+	m := &Memory{memoryData: memoryData{
+		surahs:      append([]Surah{}, surahs...),
+		ayahs:       make(map[int]Ayah, len(ayahs)),
+		words:       append([]Word{}, words...),
+		submissions: map[string]memorySubmission{},
+		audio:       map[string]map[int]string{},
+		lastIDs:     map[string]int{},
+	}}
+
+	for _, ayah := range ayahs {
+		m.ayahs[ayah.ID] = ayah
+	}
+
+	sort.Slice(m.surahs, func(i, j int) bool {
+		return m.surahs[i].ID < m.surahs[j].ID
+	})
+
+	sort.Slice(m.words, func(i, j int) bool {
+		return m.words[i].ID < m.words[j].ID
+	})
+
+	return m
+}
+
+// SetLanguage sets the language of the translation in the memory store.
+func (m *Memory) SetLanguage(language string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.language = language
+}
+
+// AddAudio adds the recitation audio of the word into the memory store.
+func (m *Memory) AddAudio(reciter string, wordID int, path string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.audio[reciter] == nil {
+		m.audio[reciter] = map[int]string{}
+	}
+	m.audio[reciter][wordID] = path
+}
+
+func (m *Memory) Surahs() ([]Surah, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return append([]Surah{}, m.surahs...), nil
+}
+
+func (m *Memory) Surah(id int) (Surah, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.surah(id)
+}
+
+func (m *Memory) Ayah(id int) (Ayah, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	ayah, exist := m.ayahs[id]
+	if !exist {
+		return Ayah{}, ErrNotFound
+	}
+	return ayah, nil
+}
+
+func (m *Memory) Ayahs(first, last int) ([]Ayah, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	ayahs := []Ayah{}
+	for id := first; id <= last; id++ {
+		if ayah, exist := m.ayahs[id]; exist {
+			ayahs = append(ayahs, ayah)
+		}
+	}
+	return ayahs, nil
+}
+
+func (m *Memory) AyahsByID(ids []int) ([]Ayah, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	ayahs := []Ayah{}
+	for _, id := range distinctIDs(ids) {
+		if ayah, exist := m.ayahs[id]; exist {
+			ayahs = append(ayahs, ayah)
+		}
+	}
+	return ayahs, nil
+}
+
+func (m *Memory) Word(id int) (Word, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.word(id)
+}
+
+func (m *Memory) LastWordID() (int, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if len(m.words) == 0 {
+		return 0, nil
+	}
+	return m.words[len(m.words)-1].ID, nil
+}
+
+func (m *Memory) AyahWords(ayahID int) ([]Word, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.ayahWords(ayahID), nil
+}
+
+func (m *Memory) Words(firstAyah, lastAyah int) ([]Word, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	words := []Word{}
+	for _, word := range m.words {
+		if word.Ayah >= firstAyah && word.Ayah <= lastAyah {
+			words = append(words, word)
+		}
+	}
+	return words, nil
+}
+
+func (m *Memory) WordsByID(ids []int) ([]Word, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	words := []Word{}
+	for _, id := range distinctIDs(ids) {
+		if word, err := m.word(id); err == nil {
+			words = append(words, word)
+		}
+	}
+	return words, nil
+}
+
+func (m *Memory) Progress() (Progress, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	progress := Progress{LastWord: m.lastWord}
+	for _, word := range m.words {
+		if word.ID > m.lastWord+1 {
+			break
+		}
+
+		progress.NextAyah = word.Ayah
+		if word.ID != m.lastWord {
+			continue
+		}
+
+		if surah, err := m.surahOfAyah(word.Ayah); err == nil {
+			progress.Surah = surah.ID
+			progress.Ayah = word.Ayah - surah.Start + 1
+		}
+	}
+
+	return progress, nil
+}
+
+func (m *Memory) SetLastWord(wordID int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.lastWord = wordID
+	return nil
+}
+
+func (m *Memory) AyahProgress() (AyahProgress, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	progress := AyahProgress{LastAyah: m.lastAyah}
+	if surah, err := m.surahOfAyah(m.lastAyah); err == nil {
+		progress.Surah = surah.ID
+		progress.Ayah = m.lastAyah - surah.Start + 1
+	}
+
+	return progress, nil
+}
+
+func (m *Memory) SetLastAyah(ayahID int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.lastAyah = ayahID
+	return nil
+}
+
+func (m *Memory) LogAnswer(wordID int, mode string, correct bool, at time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.answers = append(m.answers, memoryAnswer{
+		WordID:  wordID,
+		Mode:    mode,
+		Correct: correct,
+		At:      at,
+	})
+	return nil
+}
+
+func (m *Memory) AnsweredWords(from, to time.Time) (map[int]int, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	// Find the surah of each word that correctly answered
+	answeredWords := map[int]int{}
+	for _, answer := range m.answers {
+		if !answer.Correct || answer.At.Before(from) || !answer.At.Before(to) {
+			continue
+		}
+
+		word, err := m.word(answer.WordID)
+		if err != nil {
+			continue
+		}
+
+		if surah, err := m.surahOfAyah(word.Ayah); err == nil {
+			answeredWords[word.ID] = surah.ID
+		}
+	}
+
+	answered := map[int]int{}
+	for _, surah := range answeredWords {
+		answered[surah]++
+	}
+
+	return answered, nil
+}
+
+func (m *Memory) DailyActivity(t time.Time) (int, time.Duration, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	start := database.StartOfDay(t)
+	end := start.AddDate(0, 0, 1)
+
+	answeredWords := map[int]struct{}{}
+	var timestamps []int64
+	for _, answer := range m.answers {
+		if answer.At.Before(start) || !answer.At.Before(end) {
+			continue
+		}
+
+		timestamps = append(timestamps, answer.At.Unix())
+		if answer.Correct {
+			answeredWords[answer.WordID] = struct{}{}
+		}
+	}
+
+	sort.Slice(timestamps, func(i, j int) bool {
+		return timestamps[i] < timestamps[j]
+	})
+
+	var active time.Duration
+	for i := 1; i < len(timestamps); i++ {
+		gap := time.Duration(timestamps[i]-timestamps[i-1]) * time.Second
+		if gap <= database.ActivityIdleLimit {
+			active += gap
+		}
+	}
+
+	return len(answeredWords), active, nil
+}
+
+func (m *Memory) AnswerStats(wordIDs []int) (map[int]AnswerStats, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	requested := map[int]struct{}{}
+	for _, id := range wordIDs {
+		requested[id] = struct{}{}
+	}
+
+	stats := map[int]AnswerStats{}
+	for _, answer := range m.answers {
+		if _, exist := requested[answer.WordID]; !exist {
+			continue
+		}
+
+		stat := stats[answer.WordID]
+		stat.Attempts++
+		if answer.Correct {
+			stat.Correct++
+		}
+		stats[answer.WordID] = stat
+	}
+
+	return stats, nil
+}
+
+func (m *Memory) DueWords(limit int) ([]Word, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	lastSeen := map[int]int64{}
+	for _, answer := range m.answers {
+		if seen := answer.At.Unix(); seen > lastSeen[answer.WordID] {
+			lastSeen[answer.WordID] = seen
+		}
+	}
+
+	words := []Word{}
+	for _, word := range m.words {
+		if word.ID <= m.lastWord {
+			words = append(words, word)
+		}
+	}
+
+	rand.Shuffle(len(words), func(i, j int) {
+		words[i], words[j] = words[j], words[i]
+	})
+
+	sort.SliceStable(words, func(i, j int) bool {
+		return lastSeen[words[i].ID] < lastSeen[words[j].ID]
+	})
+
+	if len(words) > limit {
+		words = words[:limit]
+	}
+	return words, nil
+}
+
+func (m *Memory) Submission(clientID string) (string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	submission, exist := m.submissions[clientID]
+	if !exist {
+		return "", ErrNotFound
+	}
+	return submission.Status, nil
+}
+
+func (m *Memory) SaveSubmission(clientID string, wordID int, status string, at time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.submissions[clientID] = memorySubmission{WordID: wordID, Status: status, At: at}
+	return nil
+}
+
+func (m *Memory) ForgetSubmissions(before time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for clientID, submission := range m.submissions {
+		if submission.At.Before(before) {
+			delete(m.submissions, clientID)
+		}
+	}
+	return nil
+}
+
+func (m *Memory) Goal() (Goal, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.goal, nil
+}
+
+func (m *Memory) SetGoal(goal Goal) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.goal = goal
+	return nil
+}
+
+func (m *Memory) Lists() ([]List, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	lists := make([]List, len(m.lists))
+	for i, list := range m.lists {
+		lists[i] = m.countBookmarks(list)
+	}
+
+	sort.SliceStable(lists, func(i, j int) bool {
+		return lists[i].Name < lists[j].Name
+	})
+
+	return lists, nil
+}
+
+func (m *Memory) List(id int) (List, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, list := range m.lists {
+		if list.ID == id {
+			return m.countBookmarks(list), nil
+		}
+	}
+	return List{}, ErrNotFound
+}
+
+func (m *Memory) CreateList(name string, at time.Time) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	id := m.nextID("list")
+	m.lists = append(m.lists, List{ID: id, Name: name, CreatedAt: at.Unix()})
+	return id, nil
+}
+
+func (m *Memory) RenameList(id int, name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i := range m.lists {
+		if m.lists[i].ID == id {
+			m.lists[i].Name = name
+			return nil
+		}
+	}
+	return ErrNotFound
+}
+
+func (m *Memory) DeleteList(id int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i := range m.lists {
+		if m.lists[i].ID != id {
+			continue
+		}
+
+		m.lists = append(m.lists[:i:i], m.lists[i+1:]...)
+
+		bookmarks := []Bookmark{}
+		for _, bookmark := range m.bookmarks {
+			if bookmark.List != id {
+				bookmarks = append(bookmarks, bookmark)
+			}
+		}
+		m.bookmarks = bookmarks
+		return nil
+	}
+	return ErrNotFound
+}
+
+func (m *Memory) Bookmarks(listID int) ([]Bookmark, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	type sortKey struct{ ayah, position int }
+	keys := map[int]sortKey{}
+	bookmarks := []Bookmark{}
+	for _, bookmark := range m.bookmarks {
+		if bookmark.List != listID {
+			continue
+		}
+
+		bookmark, key := m.locateBookmark(bookmark), sortKey{}
+		if bookmark.Word != nil {
+			word, _ := m.word(*bookmark.Word)
+			key = sortKey{word.Ayah, word.Position}
+		} else {
+			key = sortKey{*bookmark.Ayah, 0}
+		}
+
+		keys[bookmark.ID] = key
+		bookmarks = append(bookmarks, bookmark)
+	}
+
+	sort.SliceStable(bookmarks, func(i, j int) bool {
+		a, b := keys[bookmarks[i].ID], keys[bookmarks[j].ID]
+		if a.ayah != b.ayah {
+			return a.ayah < b.ayah
+		}
+		return a.position < b.position
+	})
+
+	return bookmarks, nil
+}
+
+func (m *Memory) Bookmark(listID, id int) (Bookmark, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, bookmark := range m.bookmarks {
+		if bookmark.List == listID && bookmark.ID == id {
+			return m.locateBookmark(bookmark), nil
+		}
+	}
+	return Bookmark{}, ErrNotFound
+}
+
+func (m *Memory) AddBookmark(listID int, wordID, ayahID *int, at time.Time) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	id := m.nextID("bookmark")
+	m.bookmarks = append(m.bookmarks, Bookmark{
+		ID:        id,
+		List:      listID,
+		Word:      wordID,
+		Ayah:      ayahID,
+		CreatedAt: at.Unix(),
+	})
+	return id, nil
+}
+
+func (m *Memory) RemoveBookmark(listID, id int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i, bookmark := range m.bookmarks {
+		if bookmark.List == listID && bookmark.ID == id {
+			m.bookmarks = append(m.bookmarks[:i:i], m.bookmarks[i+1:]...)
+			return nil
+		}
+	}
+	return ErrNotFound
+}
+
+func (m *Memory) ListWords(listID int) ([]Word, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	bookmarkedWords := map[int]struct{}{}
+	bookmarkedAyahs := map[int]struct{}{}
+	for _, bookmark := range m.bookmarks {
+		switch {
+		case bookmark.List != listID:
+		case bookmark.Word != nil:
+			bookmarkedWords[*bookmark.Word] = struct{}{}
+		case bookmark.Ayah != nil:
+			bookmarkedAyahs[*bookmark.Ayah] = struct{}{}
+		}
+	}
+
+	words := []Word{}
+	for _, word := range m.words {
+		_, wordExist := bookmarkedWords[word.ID]
+		_, ayahExist := bookmarkedAyahs[word.Ayah]
+		if wordExist || ayahExist {
+			words = append(words, word)
+		}
+	}
+
+	return words, nil
+}
+
+func (m *Memory) Notes(filter NoteFilter) ([]Note, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	notes := []Note{}
+	for _, note := range m.notes {
+		if filter.Word != nil && (note.Word == nil || *note.Word != *filter.Word) {
+			continue
+		}
+
+		if filter.Ayah != nil && (note.Ayah == nil || *note.Ayah != *filter.Ayah) {
+			continue
+		}
+
+		notes = append(notes, note)
+	}
+
+	return notes, nil
+}
+
+func (m *Memory) AyahNotes(ayahID int) ([]Note, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	positions := map[int]int{}
+	notes := []Note{}
+	for _, note := range m.notes {
+		if note.Ayah != nil && *note.Ayah == ayahID {
+			notes = append(notes, note)
+			continue
+		}
+
+		if note.Word == nil {
+			continue
+		}
+
+		if word, err := m.word(*note.Word); err == nil && word.Ayah == ayahID {
+			positions[note.ID] = word.Position
+			notes = append(notes, note)
+		}
+	}
+
+	// Notes are kept in the order of its ID, so stable sort is enough
+	sort.SliceStable(notes, func(i, j int) bool {
+		return positions[notes[i].ID] < positions[notes[j].ID]
+	})
+
+	return notes, nil
+}
+
+func (m *Memory) Note(id int) (Note, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, note := range m.notes {
+		if note.ID == id {
+			return note, nil
+		}
+	}
+	return Note{}, ErrNotFound
+}
+
+func (m *Memory) CreateNote(wordID, ayahID *int, content string, at time.Time) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	id := m.nextID("note")
+	m.notes = append(m.notes, Note{
+		ID:        id,
+		Word:      wordID,
+		Ayah:      ayahID,
+		Content:   content,
+		CreatedAt: at.Unix(),
+		UpdatedAt: at.Unix(),
+	})
+	return id, nil
+}
+
+func (m *Memory) UpdateNote(id int, content string, at time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i := range m.notes {
+		if m.notes[i].ID == id {
+			m.notes[i].Content = content
+			m.notes[i].UpdatedAt = at.Unix()
+			return nil
+		}
+	}
+	return ErrNotFound
+}
+
+func (m *Memory) DeleteNote(id int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i := range m.notes {
+		if m.notes[i].ID == id {
+			m.notes = append(m.notes[:i:i], m.notes[i+1:]...)
+			return nil
+		}
+	}
+	return ErrNotFound
+}
+
+func (m *Memory) CreateSprint(duration int, startedAt, endsAt time.Time) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	id := m.nextID("sprint")
+	m.sprints = append(m.sprints, Sprint{
+		ID:        id,
+		Duration:  duration,
+		StartedAt: startedAt.Unix(),
+		EndsAt:    endsAt.Unix(),
+	})
+	return id, nil
+}
+
+func (m *Memory) Sprint(id int) (Sprint, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, sprint := range m.sprints {
+		if sprint.ID == id {
+			return sprint, nil
+		}
+	}
+	return Sprint{}, ErrNotFound
+}
+
+func (m *Memory) AddSprintAnswer(id int, correct bool) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i := range m.sprints {
+		if m.sprints[i].ID != id {
+			continue
+		}
+
+		m.sprints[i].Answered++
+		if correct {
+			m.sprints[i].Correct++
+		}
+		return nil
+	}
+	return ErrNotFound
+}
+
+func (m *Memory) LogOrderAttempt(attempt OrderAttempt) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.attempts = append(m.attempts, attempt)
+	return nil
+}
+
+func (m *Memory) OrderStats(surah int) ([]OrderStat, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	statIdx := map[int]int{}
+	stats := []OrderStat{}
+	for _, attempt := range m.attempts {
+		surahData, err := m.surahOfAyah(attempt.Ayah)
+		if err != nil || (surah != 0 && surahData.ID != surah) {
+			continue
+		}
+
+		idx, exist := statIdx[attempt.Ayah]
+		if !exist {
+			idx = len(stats)
+			statIdx[attempt.Ayah] = idx
+			stats = append(stats, OrderStat{
+				AyahID: attempt.Ayah,
+				Surah:  surahData.ID,
+				Ayah:   attempt.Ayah - surahData.Start + 1,
+			})
+		}
+
+		// Attempts are kept in the order they are logged, so the last one wins
+		stat := &stats[idx]
+		stat.Attempts++
+		stat.Mistakes += attempt.Mistakes
+		stat.Solved = stat.Solved || attempt.Correct
+		stat.LastCorrect = attempt.Correct
+		stat.TotalDuration += attempt.Duration
+		if at := attempt.At.Unix(); at > stat.LastAttemptAt {
+			stat.LastAttemptAt = at
+		}
+
+		if attempt.Correct && (stat.BestDuration == nil || attempt.Duration < *stat.BestDuration) {
+			duration := attempt.Duration
+			stat.BestDuration = &duration
+		}
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].AyahID < stats[j].AyahID
+	})
+
+	return stats, nil
+}
+
+func (m *Memory) Reciters() ([]Reciter, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	reciters := []Reciter{}
+	for name, words := range m.audio {
+		reciters = append(reciters, Reciter{Name: name, WordCount: len(words)})
+	}
+
+	sort.Slice(reciters, func(i, j int) bool {
+		return reciters[i].Name < reciters[j].Name
+	})
+
+	return reciters, nil
+}
+
+func (m *Memory) AudioPath(reciter string, wordID int) (string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	path, exist := m.audio[reciter][wordID]
+	if !exist {
+		return "", ErrNotFound
+	}
+	return path, nil
+}
+
+func (m *Memory) RecitedWord(reciter string, surah int) (Word, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var learned, others []Word
+	for wordID := range m.audio[reciter] {
+		word, err := m.word(wordID)
+		if err != nil {
+			continue
+		}
+
+		if surahData, err := m.surahOfAyah(word.Ayah); err != nil || (surah != 0 && surahData.ID != surah) {
+			continue
+		}
+
+		if word.ID <= m.lastWord {
+			learned = append(learned, word)
+		} else {
+			others = append(others, word)
+		}
+	}
+
+	switch {
+	case len(learned) > 0:
+		return learned[rand.Intn(len(learned))], nil
+	case len(others) > 0:
+		return others[rand.Intn(len(others))], nil
+	default:
+		return Word{}, ErrNotFound
+	}
+}
+
+func (m *Memory) Language() (string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.language, nil
+}
+
+func (m *Memory) Suggestions(status string) ([]Suggestion, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	suggestions := []Suggestion{}
+	for _, suggestion := range m.suggestions {
+		if status == "" || suggestion.Status == status {
+			suggestions = append(suggestions, m.locateSuggestion(suggestion))
+		}
+	}
+	return suggestions, nil
+}
+
+func (m *Memory) Suggestion(id int) (Suggestion, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, suggestion := range m.suggestions {
+		if suggestion.ID == id {
+			return m.locateSuggestion(suggestion), nil
+		}
+	}
+	return Suggestion{}, ErrNotFound
+}
+
+func (m *Memory) CreateSuggestion(wordID int, language, translation, reason string, at time.Time) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	id := m.nextID("suggestion")
+	m.suggestions = append(m.suggestions, Suggestion{
+		ID:          id,
+		Word:        wordID,
+		Language:    language,
+		Translation: translation,
+		Reason:      reason,
+		Status:      "pending",
+		CreatedAt:   at.Unix(),
+	})
+	return id, nil
+}
+
+func (m *Memory) AcceptSuggestion(id int, translation string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	suggestion, err := m.pendingSuggestion(id)
+	if err != nil {
+		return err
+	}
+
+	if translation == "" {
+		translation = suggestion.Translation
+	}
+
+	now := time.Now().Unix()
+	suggestion.Status = "accepted"
+	suggestion.Translation = translation
+	suggestion.ReviewedAt = &now
+
+	// Save the override, keeping the original translation of the existing one
+	override := Override{
+		Word:        suggestion.Word,
+		Language:    suggestion.Language,
+		Translation: translation,
+		Suggestion:  &suggestion.ID,
+		UpdatedAt:   now,
+	}
+
+	idx := m.overrideIndex(suggestion.Word, suggestion.Language)
+	if idx >= 0 {
+		override.Original = m.overrides[idx].Original
+		m.overrides[idx] = override
+	} else {
+		if word, err := m.word(suggestion.Word); err == nil && suggestion.Language == m.language {
+			override.Original = word.Translation
+		}
+		m.overrides = append(m.overrides, override)
+	}
+
+	if suggestion.Language == m.language {
+		m.setTranslation(suggestion.Word, translation)
+	}
+
+	return nil
+}
+
+func (m *Memory) RejectSuggestion(id int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	suggestion, err := m.pendingSuggestion(id)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().Unix()
+	suggestion.Status = "rejected"
+	suggestion.ReviewedAt = &now
+	return nil
+}
+
+func (m *Memory) Overrides() ([]Override, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	overrides := make([]Override, len(m.overrides))
+	for i, override := range m.overrides {
+		if word, err := m.word(override.Word); err == nil {
+			override.Ayah = word.Ayah
+			override.Position = word.Position
+			override.Arabic = word.Arabic
+			if surah, err := m.surahOfAyah(word.Ayah); err == nil {
+				override.Surah = surah.ID
+				override.Ayah = word.Ayah - surah.Start + 1
+			}
+		}
+		overrides[i] = override
+	}
+
+	sort.Slice(overrides, func(i, j int) bool {
+		if overrides[i].Language != overrides[j].Language {
+			return overrides[i].Language < overrides[j].Language
+		}
+		return overrides[i].Word < overrides[j].Word
+	})
+
+	return overrides, nil
+}
+
+func (m *Memory) RemoveOverride(wordID int, language string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	idx := m.overrideIndex(wordID, language)
+	if idx < 0 {
+		return database.ErrOverrideNotFound
+	}
+
+	if language == m.language {
+		m.setTranslation(wordID, m.overrides[idx].Original)
+	}
+
+	m.overrides = append(m.overrides[:idx:idx], m.overrides[idx+1:]...)
+	return nil
+}
+
+func (m *Memory) ChoiceSecret() (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.secret == "" {
+		m.secret = "memory-secret"
+	}
+	return m.secret, nil
+}
+
+func (m *Memory) Ping(ctx context.Context) (int, error) {
+	return database.SchemaVersion, ctx.Err()
+}
+
+func (m *Memory) Tx(fn func(tx Store) error) error {
+	m.txMu.Lock()
+	defer m.txMu.Unlock()
+
+	m.mu.RLock()
+	snapshot := m.memoryData.clone()
+	m.mu.RUnlock()
+
+	if err := fn(memoryTx{m}); err != nil {
+		m.mu.Lock()
+		m.memoryData = snapshot
+		m.mu.Unlock()
+		return err
+	}
+
+	return nil
+}
+
+// Tx runs the function within the current transaction.
+func (tx memoryTx) Tx(fn func(tx Store) error) error {
+	return fn(tx)
+}
+
+// clone returns the deep copy of the data.
+func (d memoryData) clone() memoryData {
+	c := d
+	c.answers = append([]memoryAnswer{}, d.answers...)
+	c.lists = append([]List{}, d.lists...)
+	c.bookmarks = append([]Bookmark{}, d.bookmarks...)
+	c.notes = append([]Note{}, d.notes...)
+	c.sprints = append([]Sprint{}, d.sprints...)
+	c.attempts = append([]OrderAttempt{}, d.attempts...)
+	c.suggestions = append([]Suggestion{}, d.suggestions...)
+	c.overrides = append([]Override{}, d.overrides...)
+	c.words = append([]Word{}, d.words...)
+
+	c.submissions = make(map[string]memorySubmission, len(d.submissions))
+	for clientID, submission := range d.submissions {
+		c.submissions[clientID] = submission
+	}
+
+	c.lastIDs = make(map[string]int, len(d.lastIDs))
+	for kind, id := range d.lastIDs {
+		c.lastIDs[kind] = id
+	}
+
+	c.audio = make(map[string]map[int]string, len(d.audio))
+	for reciter, paths := range d.audio {
+		c.audio[reciter] = make(map[int]string, len(paths))
+		for wordID, path := range paths {
+			c.audio[reciter][wordID] = path
+		}
+	}
+
+	return c
+}
+
+// nextID returns the next ID for the kind of data. The caller must hold the lock.
+func (m *Memory) nextID(kind string) int {
+	m.lastIDs[kind]++
+	return m.lastIDs[kind]
+}
+
+// surah returns the surah by its number. The caller must hold the lock.
+func (m *Memory) surah(id int) (Surah, error) {
+	for _, surah := range m.surahs {
+		if surah.ID == id {
+			return surah, nil
+		}
+	}
+	return Surah{}, ErrNotFound
+}
+
+// surahOfAyah returns the surah that contains the ayah. The caller must hold the lock.
+func (m *Memory) surahOfAyah(ayahID int) (Surah, error) {
+	for _, surah := range m.surahs {
+		if ayahID >= surah.Start && ayahID <= surah.End {
+			return surah, nil
+		}
+	}
+	return Surah{}, ErrNotFound
+}
+
+// word returns the word by its ID. The caller must hold the lock.
+func (m *Memory) word(id int) (Word, error) {
+	idx := sort.Search(len(m.words), func(i int) bool {
+		return m.words[i].ID >= id
+	})
+
+	if idx >= len(m.words) || m.words[idx].ID != id {
+		return Word{}, ErrNotFound
+	}
+	return m.words[idx], nil
+}
+
+// ayahWords returns the words within the ayah ordered by its position.
+// The caller must hold the lock.
+func (m *Memory) ayahWords(ayahID int) []Word {
+	words := []Word{}
+	for _, word := range m.words {
+		if word.Ayah == ayahID {
+			words = append(words, word)
+		}
+	}
+
+	sort.Slice(words, func(i, j int) bool {
+		return words[i].Position < words[j].Position
+	})
+
+	return words
+}
+
+// setTranslation replaces the translation of the word. The caller must hold the lock.
+func (m *Memory) setTranslation(wordID int, translation string) {
+	for i := range m.words {
+		if m.words[i].ID == wordID {
+			m.words[i].Translation = translation
+			return
+		}
+	}
+}
+
+// countBookmarks fills the number of bookmarks of the list. The caller must hold the lock.
+func (m *Memory) countBookmarks(list List) List {
+	list.WordCount, list.AyahCount = 0, 0
+	for _, bookmark := range m.bookmarks {
+		switch {
+		case bookmark.List != list.ID:
+		case bookmark.Word != nil:
+			list.WordCount++
+		case bookmark.Ayah != nil:
+			list.AyahCount++
+		}
+	}
+	return list
+}
+
+// locateBookmark fills the location and Arabic text of the bookmark.
+// The caller must hold the lock.
+func (m *Memory) locateBookmark(bookmark Bookmark) Bookmark {
+	var ayahID int
+	if bookmark.Word != nil {
+		word, _ := m.word(*bookmark.Word)
+		ayahID, bookmark.Arabic = word.Ayah, word.Arabic
+	} else if bookmark.Ayah != nil {
+		var arabic []string
+		for _, word := range m.ayahWords(*bookmark.Ayah) {
+			arabic = append(arabic, word.Arabic)
+		}
+		ayahID, bookmark.Arabic = *bookmark.Ayah, strings.Join(arabic, " ")
+	}
+
+	if surah, err := m.surahOfAyah(ayahID); err == nil {
+		bookmark.Surah = surah.ID
+		bookmark.SurahAyah = ayahID - surah.Start + 1
+	}
+
+	return bookmark
+}
+
+// locateSuggestion fills the location and current translation of the suggested
+// word. The caller must hold the lock.
+func (m *Memory) locateSuggestion(suggestion Suggestion) Suggestion {
+	word, err := m.word(suggestion.Word)
+	if err != nil {
+		return suggestion
+	}
+
+	suggestion.Position = word.Position
+	suggestion.Arabic = word.Arabic
+	suggestion.Current = word.Translation
+	if surah, err := m.surahOfAyah(word.Ayah); err == nil {
+		suggestion.Surah = surah.ID
+		suggestion.Ayah = word.Ayah - surah.Start + 1
+	}
+
+	return suggestion
+}
+
+// pendingSuggestion returns the suggestion and make sure it's still pending.
+// The caller must hold the lock.
+func (m *Memory) pendingSuggestion(id int) (*Suggestion, error) {
+	for i := range m.suggestions {
+		if m.suggestions[i].ID != id {
+			continue
+		}
+
+		if m.suggestions[i].Status != "pending" {
+			return nil, database.ErrSuggestionReviewed
+		}
+		return &m.suggestions[i], nil
+	}
+	return nil, database.ErrSuggestionNotFound
+}
+
+// overrideIndex returns the index of override for the word in the language,
+// or -1 if it doesn't exist. The caller must hold the lock.
+func (m *Memory) overrideIndex(wordID int, language string) int {
+	for i, override := range m.overrides {
+		if override.Word == wordID && override.Language == language {
+			return i
+		}
+	}
+	return -1
+}
+
+// distinctIDs returns the distinct IDs in ascending order.
+func distinctIDs(ids []int) []int {
+	seen := map[int]struct{}{}
+	distinct := []int{}
+	for _, id := range ids {
+		if _, exist := seen[id]; !exist {
+			seen[id] = struct{}{}
+			distinct = append(distinct, id)
+		}
+	}
+
+	sort.Ints(distinct)
+	return distinct
+}
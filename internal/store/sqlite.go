@@ -0,0 +1,695 @@
+package store
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"kalimah/internal/database"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// maxQueryIDs is the max number of IDs within a single `IN` query, to keep it
+// below the variable limit of SQLite.
+const maxQueryIDs = 500
+
+// SQLite is the store backed by kalimah's database. Despite its name, it works for
+// every dialect supported by the database package, since the queries are written
+// in the subset of SQL that understood by all of them.
+type SQLite struct {
+	db *sqlx.DB
+
+	// q is either the database or the running transaction.
+	q sqlx.Ext
+}
+
+// NewSQLite returns the store that uses the database.
+func NewSQLite(db *sqlx.DB) *SQLite {
+	return &SQLite{db: db, q: db}
+}
+
+func (st *SQLite) Surahs() ([]Surah, error) {
+	surahs := []Surah{}
+	err := sqlx.Select(st.q, &surahs,
+		`SELECT id, name, translation, start, "end" FROM surah ORDER BY id`)
+	return surahs, err
+}
+
+func (st *SQLite) Surah(id int) (Surah, error) {
+	var surah Surah
+	err := sqlx.Get(st.q, &surah,
+		`SELECT id, name, translation, start, "end" FROM surah WHERE id = ?`, id)
+	if err == sql.ErrNoRows {
+		return Surah{}, ErrNotFound
+	}
+	return surah, err
+}
+
+func (st *SQLite) Ayah(id int) (Ayah, error) {
+	var ayah Ayah
+	err := sqlx.Get(st.q, &ayah,
+		`SELECT id, translation, tafsir FROM ayah WHERE id = ?`, id)
+	if err == sql.ErrNoRows {
+		return Ayah{}, ErrNotFound
+	}
+	return ayah, err
+}
+
+func (st *SQLite) Ayahs(first, last int) ([]Ayah, error) {
+	ayahs := []Ayah{}
+	err := sqlx.Select(st.q, &ayahs,
+		`SELECT id, translation, tafsir FROM ayah
+		WHERE id >= ? AND id <= ? ORDER BY id`, first, last)
+	return ayahs, err
+}
+
+func (st *SQLite) AyahsByID(ids []int) ([]Ayah, error) {
+	ayahs := []Ayah{}
+	err := st.selectByID(&ayahs,
+		`SELECT id, translation, tafsir FROM ayah WHERE id IN (?) ORDER BY id`, ids)
+	return ayahs, err
+}
+
+func (st *SQLite) Word(id int) (Word, error) {
+	var word Word
+	err := sqlx.Get(st.q, &word,
+		`SELECT id, ayah, position, arabic, translation FROM word WHERE id = ?`, id)
+	if err == sql.ErrNoRows {
+		return Word{}, ErrNotFound
+	}
+	return word, err
+}
+
+func (st *SQLite) LastWordID() (int, error) {
+	var id int
+	err := sqlx.Get(st.q, &id, `SELECT COALESCE(MAX(id), 0) FROM word`)
+	return id, err
+}
+
+func (st *SQLite) AyahWords(ayahID int) ([]Word, error) {
+	words := []Word{}
+	err := sqlx.Select(st.q, &words,
+		`SELECT id, ayah, position, arabic, translation FROM word
+		WHERE ayah = ? ORDER BY position`, ayahID)
+	return words, err
+}
+
+func (st *SQLite) Words(firstAyah, lastAyah int) ([]Word, error) {
+	words := []Word{}
+	err := sqlx.Select(st.q, &words,
+		`SELECT id, ayah, position, arabic, translation FROM word
+		WHERE ayah >= ? AND ayah <= ? ORDER BY id`, firstAyah, lastAyah)
+	return words, err
+}
+
+func (st *SQLite) WordsByID(ids []int) ([]Word, error) {
+	words := []Word{}
+	err := st.selectByID(&words,
+		`SELECT id, ayah, position, arabic, translation FROM word
+		WHERE id IN (?) ORDER BY id`, ids)
+	return words, err
+}
+
+func (st *SQLite) Progress() (Progress, error) {
+	var progress Progress
+	err := sqlx.Get(st.q, &progress,
+		`WITH last_word AS (
+			SELECT COALESCE(MAX(last_word), 0) id FROM tracker WHERE id = 1)
+		SELECT lw.id last_word, COALESCE(s.id, 0) surah,
+			COALESCE(w.ayah-s.start+1, 0) ayah,
+			(SELECT COALESCE(MAX(ayah), 0) FROM word WHERE id <= lw.id+1) next_ayah
+		FROM last_word lw
+		LEFT JOIN word w ON w.id = lw.id
+		LEFT JOIN surah s ON w.ayah >= s.start AND w.ayah <= s.end`)
+	return progress, err
+}
+
+func (st *SQLite) SetLastWord(wordID int) error {
+	_, err := st.q.Exec(
+		`INSERT INTO tracker (id, last_word) VALUES (1, ?)
+		ON CONFLICT (id) DO UPDATE SET last_word = excluded.last_word`,
+		wordID)
+	return err
+}
+
+func (st *SQLite) AyahProgress() (AyahProgress, error) {
+	var progress AyahProgress
+	err := sqlx.Get(st.q, &progress,
+		`WITH last_ayah AS (
+			SELECT COALESCE(MAX(last_ayah), 0) id FROM ayah_tracker WHERE id = 1)
+		SELECT la.id last_ayah, COALESCE(s.id, 0) surah,
+			COALESCE(la.id-s.start+1, 0) ayah
+		FROM last_ayah la
+		LEFT JOIN surah s ON la.id >= s.start AND la.id <= s.end`)
+	return progress, err
+}
+
+func (st *SQLite) SetLastAyah(ayahID int) error {
+	// The row might not exist yet in database that populated
+	// before ayah quiz exists, so upsert it.
+	_, err := st.q.Exec(
+		`INSERT INTO ayah_tracker (id, last_ayah) VALUES (1, ?)
+		ON CONFLICT (id) DO UPDATE SET last_ayah = excluded.last_ayah`,
+		ayahID)
+	return err
+}
+
+func (st *SQLite) LogAnswer(wordID int, mode string, correct bool, at time.Time) error {
+	return database.LogActivity(st.q, wordID, mode, correct, at)
+}
+
+func (st *SQLite) AnsweredWords(from, to time.Time) (map[int]int, error) {
+	var rows []struct {
+		Surah int `db:"surah"`
+		NWord int `db:"n_word"`
+	}
+
+	err := sqlx.Select(st.q, &rows,
+		`SELECT s.id surah, COUNT(DISTINCT a.word) n_word
+		FROM activity a
+		JOIN word w ON w.id = a.word
+		JOIN surah s ON w.ayah >= s.start AND w.ayah <= s.end
+		WHERE a.correct = 1 AND a.created_at >= ? AND a.created_at < ?
+		GROUP BY s.id`, from.Unix(), to.Unix())
+	if err != nil {
+		return nil, err
+	}
+
+	answered := make(map[int]int, len(rows))
+	for _, row := range rows {
+		answered[row.Surah] = row.NWord
+	}
+
+	return answered, nil
+}
+
+func (st *SQLite) DailyActivity(t time.Time) (int, time.Duration, error) {
+	return database.DailyActivity(st.q, t)
+}
+
+func (st *SQLite) AnswerStats(wordIDs []int) (map[int]AnswerStats, error) {
+	var rows []struct {
+		AnswerStats
+		Word int `db:"word"`
+	}
+
+	err := st.selectByID(&rows,
+		`SELECT word, COUNT(*) attempts, SUM(correct) correct
+		FROM activity WHERE word IN (?)
+		GROUP BY word`, wordIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make(map[int]AnswerStats, len(rows))
+	for _, row := range rows {
+		stats[row.Word] = row.AnswerStats
+	}
+
+	return stats, nil
+}
+
+func (st *SQLite) DueWords(limit int) ([]Word, error) {
+	words := []Word{}
+	err := sqlx.Select(st.q, &words,
+		`WITH last_word AS (
+			SELECT COALESCE(MAX(last_word), 0) id FROM tracker WHERE id = 1),
+		last_seen AS (
+			SELECT word, MAX(created_at) seen FROM activity GROUP BY word)
+		SELECT w.id, w.ayah, w.position, w.arabic, w.translation
+		FROM word w
+		CROSS JOIN last_word lw
+		LEFT JOIN last_seen ls ON ls.word = w.id
+		WHERE w.id <= lw.id
+		ORDER BY COALESCE(ls.seen, 0), RANDOM()
+		LIMIT ?`, limit)
+	return words, err
+}
+
+func (st *SQLite) Submission(clientID string) (string, error) {
+	var status string
+	err := sqlx.Get(st.q, &status,
+		`SELECT status FROM track_submission WHERE client_id = ?`, clientID)
+	if err == sql.ErrNoRows {
+		return "", ErrNotFound
+	}
+	return status, err
+}
+
+func (st *SQLite) SaveSubmission(clientID string, wordID int, status string, at time.Time) error {
+	_, err := st.q.Exec(
+		`INSERT INTO track_submission (client_id, word, status, created_at)
+		VALUES (?, ?, ?, ?)`, clientID, wordID, status, at.Unix())
+	return err
+}
+
+func (st *SQLite) ForgetSubmissions(before time.Time) error {
+	_, err := st.q.Exec(
+		`DELETE FROM track_submission WHERE created_at < ?`, before.Unix())
+	return err
+}
+
+func (st *SQLite) Goal() (Goal, error) {
+	var goal Goal
+	err := sqlx.Get(st.q, &goal,
+		`SELECT words_per_day, minutes_per_day FROM goal WHERE id = 1`)
+	if err == sql.ErrNoRows {
+		return Goal{}, nil
+	}
+	return goal, err
+}
+
+func (st *SQLite) SetGoal(goal Goal) error {
+	_, err := st.q.Exec(
+		`INSERT INTO goal (id, words_per_day, minutes_per_day, updated_at)
+		VALUES (1, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET
+			words_per_day = excluded.words_per_day,
+			minutes_per_day = excluded.minutes_per_day,
+			updated_at = excluded.updated_at`,
+		goal.WordsPerDay, goal.MinutesPerDay, time.Now().Unix())
+	return err
+}
+
+func (st *SQLite) Lists() ([]List, error) {
+	lists := []List{}
+	err := sqlx.Select(st.q, &lists,
+		`SELECT l.id, l.name, l.created_at,
+			COUNT(b.word) word_count, COUNT(b.ayah) ayah_count
+		FROM list l
+		LEFT JOIN bookmark b ON b.list = l.id
+		GROUP BY l.id
+		ORDER BY l.name`)
+	return lists, err
+}
+
+func (st *SQLite) List(id int) (List, error) {
+	var list List
+	err := sqlx.Get(st.q, &list,
+		`SELECT l.id, l.name, l.created_at,
+			COUNT(b.word) word_count, COUNT(b.ayah) ayah_count
+		FROM list l
+		LEFT JOIN bookmark b ON b.list = l.id
+		WHERE l.id = ?
+		GROUP BY l.id`, id)
+	if err == sql.ErrNoRows {
+		return List{}, ErrNotFound
+	}
+	return list, err
+}
+
+func (st *SQLite) CreateList(name string, at time.Time) (int, error) {
+	var id int
+	err := sqlx.Get(st.q, &id,
+		`INSERT INTO list (name, created_at) VALUES (?, ?) RETURNING id`,
+		name, at.Unix())
+	return id, err
+}
+
+func (st *SQLite) RenameList(id int, name string) error {
+	return st.execOne(`UPDATE list SET name = ? WHERE id = ?`, name, id)
+}
+
+func (st *SQLite) DeleteList(id int) error {
+	return st.execOne(`DELETE FROM list WHERE id = ?`, id)
+}
+
+func (st *SQLite) Bookmarks(listID int) ([]Bookmark, error) {
+	return st.selectBookmarks(listID, 0)
+}
+
+func (st *SQLite) Bookmark(listID, id int) (Bookmark, error) {
+	bookmarks, err := st.selectBookmarks(listID, id)
+	if err != nil {
+		return Bookmark{}, err
+	}
+
+	if len(bookmarks) == 0 {
+		return Bookmark{}, ErrNotFound
+	}
+	return bookmarks[0], nil
+}
+
+func (st *SQLite) AddBookmark(listID int, wordID, ayahID *int, at time.Time) (int, error) {
+	var id int
+	err := sqlx.Get(st.q, &id,
+		`INSERT INTO bookmark (list, word, ayah, created_at) VALUES (?, ?, ?, ?) RETURNING id`,
+		listID, wordID, ayahID, at.Unix())
+	return id, err
+}
+
+func (st *SQLite) RemoveBookmark(listID, id int) error {
+	return st.execOne(`DELETE FROM bookmark WHERE id = ? AND list = ?`, id, listID)
+}
+
+func (st *SQLite) ListWords(listID int) ([]Word, error) {
+	words := []Word{}
+	err := sqlx.Select(st.q, &words,
+		`WITH list_word AS (
+			SELECT w.id FROM bookmark b JOIN word w ON w.id = b.word WHERE b.list = ?
+			UNION
+			SELECT w.id FROM bookmark b JOIN word w ON w.ayah = b.ayah WHERE b.list = ?)
+		SELECT w.id, w.ayah, w.position, w.arabic, w.translation
+		FROM word w
+		JOIN list_word lw ON lw.id = w.id
+		ORDER BY w.id`, listID, listID)
+	return words, err
+}
+
+func (st *SQLite) Notes(filter NoteFilter) ([]Note, error) {
+	var conditions []string
+	var args []interface{}
+	if filter.Word != nil {
+		conditions = append(conditions, "word = ?")
+		args = append(args, *filter.Word)
+	}
+
+	if filter.Ayah != nil {
+		conditions = append(conditions, "ayah = ?")
+		args = append(args, *filter.Ayah)
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	notes := []Note{}
+	err := sqlx.Select(st.q, &notes,
+		`SELECT id, word, ayah, content, created_at, updated_at
+		FROM note `+where+` ORDER BY id`, args...)
+	return notes, err
+}
+
+func (st *SQLite) AyahNotes(ayahID int) ([]Note, error) {
+	notes := []Note{}
+	err := sqlx.Select(st.q, &notes,
+		`SELECT n.id, n.word, n.ayah, n.content, n.created_at, n.updated_at
+		FROM note n
+		LEFT JOIN word w ON w.id = n.word
+		WHERE n.ayah = ? OR w.ayah = ?
+		ORDER BY w.position NULLS FIRST, n.id`, ayahID, ayahID)
+	return notes, err
+}
+
+func (st *SQLite) Note(id int) (Note, error) {
+	var note Note
+	err := sqlx.Get(st.q, &note,
+		`SELECT id, word, ayah, content, created_at, updated_at
+		FROM note WHERE id = ?`, id)
+	if err == sql.ErrNoRows {
+		return Note{}, ErrNotFound
+	}
+	return note, err
+}
+
+func (st *SQLite) CreateNote(wordID, ayahID *int, content string, at time.Time) (int, error) {
+	var id int
+	err := sqlx.Get(st.q, &id,
+		`INSERT INTO note (word, ayah, content, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?) RETURNING id`,
+		wordID, ayahID, content, at.Unix(), at.Unix())
+	return id, err
+}
+
+func (st *SQLite) UpdateNote(id int, content string, at time.Time) error {
+	return st.execOne(
+		`UPDATE note SET content = ?, updated_at = ? WHERE id = ?`,
+		content, at.Unix(), id)
+}
+
+func (st *SQLite) DeleteNote(id int) error {
+	return st.execOne(`DELETE FROM note WHERE id = ?`, id)
+}
+
+func (st *SQLite) CreateSprint(duration int, startedAt, endsAt time.Time) (int, error) {
+	var id int
+	err := sqlx.Get(st.q, &id,
+		`INSERT INTO sprint (duration, started_at, ends_at) VALUES (?, ?, ?) RETURNING id`,
+		duration, startedAt.Unix(), endsAt.Unix())
+	return id, err
+}
+
+func (st *SQLite) Sprint(id int) (Sprint, error) {
+	var sprint Sprint
+	err := sqlx.Get(st.q, &sprint,
+		`SELECT id, duration, started_at, ends_at, answered, correct
+		FROM sprint WHERE id = ?`, id)
+	if err == sql.ErrNoRows {
+		return Sprint{}, ErrNotFound
+	}
+	return sprint, err
+}
+
+func (st *SQLite) AddSprintAnswer(id int, correct bool) error {
+	return st.execOne(
+		`UPDATE sprint SET answered = answered + 1, correct = correct + ? WHERE id = ?`,
+		correct, id)
+}
+
+func (st *SQLite) LogOrderAttempt(attempt OrderAttempt) error {
+	_, err := st.q.Exec(
+		`INSERT INTO order_attempt (ayah, correct, mistakes, duration, created_at)
+		VALUES (?, ?, ?, ?, ?)`,
+		attempt.Ayah, attempt.Correct, attempt.Mistakes, attempt.Duration, attempt.At.Unix())
+	return err
+}
+
+func (st *SQLite) OrderStats(surah int) ([]OrderStat, error) {
+	stats := []OrderStat{}
+	err := sqlx.Select(st.q, &stats,
+		`SELECT o.ayah ayah_id, s.id surah, o.ayah-s.start+1 ayah,
+			COUNT(*) attempts, SUM(o.mistakes) mistakes,
+			MAX(o.correct) solved,
+			(SELECT correct FROM order_attempt
+				WHERE ayah = o.ayah ORDER BY id DESC LIMIT 1) last_correct,
+			MIN(CASE WHEN o.correct = 1 THEN o.duration END) best_duration,
+			SUM(o.duration) total_duration,
+			MAX(o.created_at) last_attempt_at
+		FROM order_attempt o
+		JOIN surah s ON o.ayah >= s.start AND o.ayah <= s.end
+		WHERE ? = 0 OR s.id = ?
+		GROUP BY o.ayah, s.id, s.start
+		ORDER BY o.ayah`, surah, surah)
+	return stats, err
+}
+
+func (st *SQLite) Reciters() ([]Reciter, error) {
+	reciters := []Reciter{}
+	err := sqlx.Select(st.q, &reciters,
+		`SELECT reciter, COUNT(*) word_count FROM word_audio
+		GROUP BY reciter ORDER BY reciter`)
+	return reciters, err
+}
+
+func (st *SQLite) AudioPath(reciter string, wordID int) (string, error) {
+	var path string
+	err := sqlx.Get(st.q, &path,
+		`SELECT path FROM word_audio WHERE reciter = ? AND word = ?`,
+		reciter, wordID)
+	if err == sql.ErrNoRows {
+		return "", ErrNotFound
+	}
+	return path, err
+}
+
+func (st *SQLite) RecitedWord(reciter string, surah int) (Word, error) {
+	var word Word
+	err := sqlx.Get(st.q, &word,
+		`WITH last_word AS (
+			SELECT COALESCE(MAX(last_word), 0) id FROM tracker WHERE id = 1)
+		SELECT w.id, w.ayah, w.position, w.arabic, w.translation
+		FROM word_audio wa
+		JOIN word w ON w.id = wa.word
+		JOIN surah s ON w.ayah >= s.start AND w.ayah <= s.end
+		CROSS JOIN last_word lw
+		WHERE wa.reciter = ? AND (? = 0 OR s.id = ?)
+		ORDER BY w.id <= lw.id DESC, RANDOM()
+		LIMIT 1`, reciter, surah, surah)
+	if err == sql.ErrNoRows {
+		return Word{}, ErrNotFound
+	}
+	return word, err
+}
+
+func (st *SQLite) Language() (string, error) {
+	return database.Language(st.q)
+}
+
+func (st *SQLite) Suggestions(status string) ([]Suggestion, error) {
+	return st.selectSuggestions(status, 0)
+}
+
+func (st *SQLite) Suggestion(id int) (Suggestion, error) {
+	suggestions, err := st.selectSuggestions("", id)
+	if err != nil {
+		return Suggestion{}, err
+	}
+
+	if len(suggestions) == 0 {
+		return Suggestion{}, ErrNotFound
+	}
+	return suggestions[0], nil
+}
+
+func (st *SQLite) CreateSuggestion(wordID int, language, translation, reason string, at time.Time) (int, error) {
+	var id int
+	err := sqlx.Get(st.q, &id,
+		`INSERT INTO gloss_suggestion (word, language, translation, reason, created_at)
+		VALUES (?, ?, ?, ?, ?) RETURNING id`,
+		wordID, language, translation, reason, at.Unix())
+	return id, err
+}
+
+func (st *SQLite) AcceptSuggestion(id int, translation string) error {
+	return database.AcceptSuggestion(st.q, id, translation)
+}
+
+func (st *SQLite) RejectSuggestion(id int) error {
+	return database.RejectSuggestion(st.q, id)
+}
+
+func (st *SQLite) Overrides() ([]Override, error) {
+	overrides := []Override{}
+	err := sqlx.Select(st.q, &overrides,
+		`SELECT o.word, o.language, o.original, o.translation, o.suggestion, o.updated_at,
+			s.id surah, w.ayah-s.start+1 ayah, w.position, w.arabic
+		FROM gloss_override o
+		JOIN word w ON w.id = o.word
+		JOIN surah s ON w.ayah >= s.start AND w.ayah <= s.end
+		ORDER BY o.language, o.word`)
+	return overrides, err
+}
+
+func (st *SQLite) RemoveOverride(wordID int, language string) error {
+	return database.RemoveOverride(st.q, wordID, language)
+}
+
+func (st *SQLite) ChoiceSecret() (string, error) {
+	var secret string
+	err := sqlx.Get(st.q, &secret, `SELECT value FROM metadata WHERE key = 'choice_secret'`)
+	if err != sql.ErrNoRows {
+		return secret, err
+	}
+
+	bt := make([]byte, 16)
+	if _, err = rand.Read(bt); err != nil {
+		return "", err
+	}
+
+	_, err = st.q.Exec(`INSERT INTO metadata (key, value) VALUES ('choice_secret', ?)
+		ON CONFLICT DO NOTHING`, hex.EncodeToString(bt))
+	if err != nil {
+		return "", err
+	}
+
+	err = sqlx.Get(st.q, &secret, `SELECT value FROM metadata WHERE key = 'choice_secret'`)
+	return secret, err
+}
+
+func (st *SQLite) Ping(ctx context.Context) (int, error) {
+	if err := st.db.PingContext(ctx); err != nil {
+		return 0, err
+	}
+	return database.SchemaVersionOf(st.db)
+}
+
+func (st *SQLite) Tx(fn func(tx Store) error) error {
+	if _, inTx := st.q.(*sqlx.Tx); inTx {
+		return fn(st)
+	}
+
+	tx, err := st.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err = fn(&SQLite{db: st.db, q: tx}); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// selectByID runs the `IN` query for the distinct IDs in ascending order, split into
+// chunks of maxQueryIDs. The rows of every chunk are appended into dest, which must
+// be pointer to slice.
+func (st *SQLite) selectByID(dest interface{}, query string, ids []int) error {
+	// The IDs often repeat the same one, so only ask for the distinct ones
+	ids = distinctIDs(ids)
+	for start := 0; start < len(ids); start += maxQueryIDs {
+		end := start + maxQueryIDs
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		chunkQuery, args, err := sqlx.In(query, ids[start:end])
+		if err != nil {
+			return err
+		}
+
+		if err = sqlx.Select(st.q, dest, chunkQuery, args...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// execOne runs the query that expected to affect a single row,
+// returning ErrNotFound if nothing is affected.
+func (st *SQLite) execOne(query string, args ...interface{}) error {
+	res, err := st.q.Exec(query, args...)
+	if err != nil {
+		return err
+	}
+
+	nAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if nAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// selectBookmarks selects bookmarks within the word list along with the location and
+// Arabic text of the bookmarked word or ayah. If id is not zero, only that bookmark
+// is selected.
+func (st *SQLite) selectBookmarks(listID, id int) ([]Bookmark, error) {
+	bookmarks := []Bookmark{}
+	err := sqlx.Select(st.q, &bookmarks,
+		`SELECT b.id, b.list, b.word, b.ayah, b.created_at,
+			s.id surah, a.id-s.start+1 surah_ayah,
+			COALESCE(w.arabic, (SELECT STRING_AGG(arabic, ' ') FROM
+				(SELECT arabic FROM word WHERE ayah = a.id ORDER BY position) aw)) arabic
+		FROM bookmark b
+		LEFT JOIN word w ON w.id = b.word
+		JOIN ayah a ON a.id = COALESCE(b.ayah, w.ayah)
+		JOIN surah s ON a.id >= s.start AND a.id <= s.end
+		WHERE b.list = ? AND (? = 0 OR b.id = ?)
+		ORDER BY a.id, w.position NULLS FIRST`, listID, id, id)
+	return bookmarks, err
+}
+
+// selectSuggestions selects the gloss suggestions with the specified status, or
+// all of them if status is empty. If id is not zero, only that suggestion is selected.
+func (st *SQLite) selectSuggestions(status string, id int) ([]Suggestion, error) {
+	suggestions := []Suggestion{}
+	err := sqlx.Select(st.q, &suggestions,
+		`SELECT g.id, g.word, g.translation, g.reason, g.language, g.status,
+			g.created_at, g.reviewed_at, s.id surah, w.ayah-s.start+1 ayah,
+			w.position, w.arabic, w.translation AS current
+		FROM gloss_suggestion g
+		JOIN word w ON w.id = g.word
+		JOIN surah s ON w.ayah >= s.start AND w.ayah <= s.end
+		WHERE (? = '' OR g.status = ?) AND (? = 0 OR g.id = ?)
+		ORDER BY g.id`, status, status, id, id)
+	return suggestions, err
+}
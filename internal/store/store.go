@@ -0,0 +1,405 @@
+// Package store provides the access to the learning data behind interfaces, so the
+// HTTP handlers and CLI commands share the same logic regardless of the storage.
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrNotFound is returned when the requested data doesn't exist.
+var ErrNotFound = errors.New("not found")
+
+// Surah is a chapter of Quran, which covers the ayah from Start to End.
+type Surah struct {
+	ID          int    `db:"id"`
+	Name        string `db:"name"`
+	Translation string `db:"translation"`
+	Start       int    `db:"start"`
+	End         int    `db:"end"`
+}
+
+// Ayah is a verse of Quran, identified by its absolute ID.
+type Ayah struct {
+	ID          int    `db:"id"`
+	Translation string `db:"translation"`
+	Tafsir      string `db:"tafsir"`
+}
+
+// Word is a word of Quran, placed in the ayah with absolute ID Ayah.
+type Word struct {
+	ID          int    `db:"id"`
+	Ayah        int    `db:"ayah"`
+	Position    int    `db:"position"`
+	Arabic      string `db:"arabic"`
+	Translation string `db:"translation"`
+}
+
+// Progress is the learning progress. Surah and Ayah are the location of the last
+// answered word, while NextAyah is the absolute ID of ayah that contains the next
+// word to answer, or the last ayah when every word has been answered.
+type Progress struct {
+	LastWord int `db:"last_word"`
+	Surah    int `db:"surah"`
+	Ayah     int `db:"ayah"`
+	NextAyah int `db:"next_ayah"`
+}
+
+// Goal is the daily goals, where zero means the goal is disabled.
+type Goal struct {
+	WordsPerDay   int `db:"words_per_day"`
+	MinutesPerDay int `db:"minutes_per_day"`
+}
+
+// AyahProgress is the progress of ayah quiz. Surah and Ayah are the location of
+// the last answered ayah.
+type AyahProgress struct {
+	LastAyah int `db:"last_ayah"`
+	Surah    int `db:"surah"`
+	Ayah     int `db:"ayah"`
+}
+
+// AnswerStats is the answer history of a word.
+type AnswerStats struct {
+	Attempts int `db:"attempts"`
+	Correct  int `db:"correct"`
+}
+
+// List is a word list, along with the number of its bookmarks.
+type List struct {
+	ID        int    `db:"id"`
+	Name      string `db:"name"`
+	WordCount int    `db:"word_count"`
+	AyahCount int    `db:"ayah_count"`
+	CreatedAt int64  `db:"created_at"`
+}
+
+// Bookmark is a word or an ayah within a word list, along with its location
+// and its Arabic text. SurahAyah is the ayah number within the surah.
+type Bookmark struct {
+	ID        int    `db:"id"`
+	List      int    `db:"list"`
+	Word      *int   `db:"word"`
+	Ayah      *int   `db:"ayah"`
+	Surah     int    `db:"surah"`
+	SurahAyah int    `db:"surah_ayah"`
+	Arabic    string `db:"arabic"`
+	CreatedAt int64  `db:"created_at"`
+}
+
+// Note is a personal note for either a word or an ayah.
+type Note struct {
+	ID        int    `db:"id"`
+	Word      *int   `db:"word"`
+	Ayah      *int   `db:"ayah"`
+	Content   string `db:"content"`
+	CreatedAt int64  `db:"created_at"`
+	UpdatedAt int64  `db:"updated_at"`
+}
+
+// NoteFilter limits the listed notes. Nil field means it's not filtered.
+type NoteFilter struct {
+	Word *int
+	Ayah *int
+}
+
+// Sprint is a timed sprint along with its score.
+type Sprint struct {
+	ID        int   `db:"id"`
+	Duration  int   `db:"duration"`
+	StartedAt int64 `db:"started_at"`
+	EndsAt    int64 `db:"ends_at"`
+	Answered  int   `db:"answered"`
+	Correct   int   `db:"correct"`
+}
+
+// OrderAttempt is an attempt of word-order exercise for an ayah.
+type OrderAttempt struct {
+	Ayah     int
+	Correct  bool
+	Mistakes int
+	Duration int
+	At       time.Time
+}
+
+// OrderStat is the summary of word-order attempts for an ayah, where AyahID is the
+// absolute ID of ayah while Ayah is its number within the surah.
+type OrderStat struct {
+	AyahID        int   `db:"ayah_id"`
+	Surah         int   `db:"surah"`
+	Ayah          int   `db:"ayah"`
+	Attempts      int   `db:"attempts"`
+	Mistakes      int   `db:"mistakes"`
+	Solved        bool  `db:"solved"`
+	LastCorrect   bool  `db:"last_correct"`
+	BestDuration  *int  `db:"best_duration"`
+	TotalDuration int   `db:"total_duration"`
+	LastAttemptAt int64 `db:"last_attempt_at"`
+}
+
+// Reciter is a reciter whose audio has been imported.
+type Reciter struct {
+	Name      string `db:"reciter"`
+	WordCount int    `db:"word_count"`
+}
+
+// Suggestion is a gloss suggestion, along with the location and the current
+// translation of its word.
+type Suggestion struct {
+	ID          int    `db:"id"`
+	Word        int    `db:"word"`
+	Language    string `db:"language"`
+	Translation string `db:"translation"`
+	Reason      string `db:"reason"`
+	Status      string `db:"status"`
+	CreatedAt   int64  `db:"created_at"`
+	ReviewedAt  *int64 `db:"reviewed_at"`
+	Surah       int    `db:"surah"`
+	Ayah        int    `db:"ayah"`
+	Position    int    `db:"position"`
+	Arabic      string `db:"arabic"`
+	Current     string `db:"current"`
+}
+
+// Override is an accepted gloss override, along with the location of its word.
+type Override struct {
+	Word        int    `db:"word"`
+	Language    string `db:"language"`
+	Original    string `db:"original"`
+	Translation string `db:"translation"`
+	Suggestion  *int   `db:"suggestion"`
+	UpdatedAt   int64  `db:"updated_at"`
+	Surah       int    `db:"surah"`
+	Ayah        int    `db:"ayah"`
+	Position    int    `db:"position"`
+	Arabic      string `db:"arabic"`
+}
+
+// SurahStore provides the surah and its ayah.
+type SurahStore interface {
+	// Surahs returns every surah ordered by its number.
+	Surahs() ([]Surah, error)
+
+	// Surah returns the surah by its number.
+	Surah(id int) (Surah, error)
+
+	// Ayah returns the ayah by its absolute ID.
+	Ayah(id int) (Ayah, error)
+
+	// Ayahs returns the ayahs with absolute ID within first and last, ordered by its ID.
+	Ayahs(first, last int) ([]Ayah, error)
+
+	// AyahsByID returns the existing ayahs among the IDs, ordered by its ID.
+	AyahsByID(ids []int) ([]Ayah, error)
+}
+
+// WordStore provides the words of Quran.
+type WordStore interface {
+	// Word returns the word by its ID.
+	Word(id int) (Word, error)
+
+	// LastWordID returns the largest word ID, or zero when there are no words.
+	LastWordID() (int, error)
+
+	// AyahWords returns the words within the ayah ordered by its position.
+	AyahWords(ayahID int) ([]Word, error)
+
+	// Words returns the words within ayah first until last, ordered by its ID.
+	Words(firstAyah, lastAyah int) ([]Word, error)
+
+	// WordsByID returns the existing words among the IDs, ordered by its ID.
+	WordsByID(ids []int) ([]Word, error)
+}
+
+// ProgressStore provides the learning progress and the history of answers.
+type ProgressStore interface {
+	// Progress returns the current learning progress.
+	Progress() (Progress, error)
+
+	// SetLastWord moves the progress to the specified word.
+	SetLastWord(wordID int) error
+
+	// AyahProgress returns the current progress of ayah quiz.
+	AyahProgress() (AyahProgress, error)
+
+	// SetLastAyah moves the progress of ayah quiz to the specified ayah.
+	SetLastAyah(ayahID int) error
+
+	// LogAnswer records an answer of the word in the specified mode.
+	LogAnswer(wordID int, mode string, correct bool, at time.Time) error
+
+	// AnsweredWords returns the number of distinct words correctly answered
+	// within the time range, mapped by its surah.
+	AnsweredWords(from, to time.Time) (map[int]int, error)
+
+	// DailyActivity returns the number of distinct words correctly answered within
+	// the day of t, along with the active learning time.
+	DailyActivity(t time.Time) (int, time.Duration, error)
+
+	// AnswerStats returns the answer history of the words that have been
+	// answered, mapped by its ID.
+	AnswerStats(wordIDs []int) (map[int]AnswerStats, error)
+
+	// DueWords returns the answered words that least recently practiced, where
+	// words practiced at the same time are in random order.
+	DueWords(limit int) ([]Word, error)
+
+	// Submission returns the status of the processed track submission,
+	// or ErrNotFound if it has never been processed.
+	Submission(clientID string) (string, error)
+
+	// SaveSubmission records the status of the processed track submission.
+	SaveSubmission(clientID string, wordID int, status string, at time.Time) error
+
+	// ForgetSubmissions removes the track submissions processed before the time.
+	ForgetSubmissions(before time.Time) error
+
+	// Goal returns the daily goals. When it's never set, all goals are disabled.
+	Goal() (Goal, error)
+
+	// SetGoal replaces the daily goals.
+	SetGoal(goal Goal) error
+}
+
+// ListStore provides the word lists and its bookmarks.
+type ListStore interface {
+	// Lists returns every word list ordered by its name.
+	Lists() ([]List, error)
+
+	// List returns the word list by its ID.
+	List(id int) (List, error)
+
+	// CreateList saves a new word list, then returns its ID.
+	CreateList(name string, at time.Time) (int, error)
+
+	// RenameList changes the name of the word list.
+	RenameList(id int, name string) error
+
+	// DeleteList removes the word list along with its bookmarks.
+	DeleteList(id int) error
+
+	// Bookmarks returns the bookmarks within the word list, ordered by its location.
+	Bookmarks(listID int) ([]Bookmark, error)
+
+	// Bookmark returns the bookmark by its ID within the word list.
+	Bookmark(listID, id int) (Bookmark, error)
+
+	// AddBookmark saves a bookmark for either a word or an ayah, then returns its ID.
+	AddBookmark(listID int, wordID, ayahID *int, at time.Time) (int, error)
+
+	// RemoveBookmark removes the bookmark from the word list.
+	RemoveBookmark(listID, id int) error
+
+	// ListWords returns the bookmarked words along with the words of the
+	// bookmarked ayahs, ordered by its ID.
+	ListWords(listID int) ([]Word, error)
+}
+
+// NoteStore provides the personal notes.
+type NoteStore interface {
+	// Notes returns the notes that match the filter, ordered by its ID.
+	Notes(filter NoteFilter) ([]Note, error)
+
+	// AyahNotes returns the notes for the ayah and for the words within it,
+	// with the ayah notes first followed by the word notes in the word order.
+	AyahNotes(ayahID int) ([]Note, error)
+
+	// Note returns the note by its ID.
+	Note(id int) (Note, error)
+
+	// CreateNote saves a note for either a word or an ayah, then returns its ID.
+	CreateNote(wordID, ayahID *int, content string, at time.Time) (int, error)
+
+	// UpdateNote replaces the content of the note.
+	UpdateNote(id int, content string, at time.Time) error
+
+	// DeleteNote removes the note.
+	DeleteNote(id int) error
+}
+
+// ExerciseStore provides the timed sprints and the word-order attempts.
+type ExerciseStore interface {
+	// CreateSprint saves a new sprint, then returns its ID.
+	CreateSprint(duration int, startedAt, endsAt time.Time) (int, error)
+
+	// Sprint returns the sprint by its ID.
+	Sprint(id int) (Sprint, error)
+
+	// AddSprintAnswer adds an answer to the score of the sprint.
+	AddSprintAnswer(id int, correct bool) error
+
+	// LogOrderAttempt records an attempt of word-order exercise.
+	LogOrderAttempt(attempt OrderAttempt) error
+
+	// OrderStats returns the summary of word-order attempts for each attempted
+	// ayah ordered by its ID. If surah is not zero, only ayahs within it are used.
+	OrderStats(surah int) ([]OrderStat, error)
+}
+
+// AudioStore provides the imported word recitations.
+type AudioStore interface {
+	// Reciters returns the reciters whose audio has been imported, ordered by its name.
+	Reciters() ([]Reciter, error)
+
+	// AudioPath returns the path of the recitation audio of the word.
+	AudioPath(reciter string, wordID int) (string, error)
+
+	// RecitedWord returns a random word that recited by the reciter, preferring
+	// the words that have been answered. If surah is not zero, only words
+	// within it are used.
+	RecitedWord(reciter string, surah int) (Word, error)
+}
+
+// GlossStore provides the gloss suggestions and the accepted overrides.
+type GlossStore interface {
+	// Language returns the language of the populated translation.
+	Language() (string, error)
+
+	// Suggestions returns the gloss suggestions with the status, or all of
+	// them if status is empty, ordered by its ID.
+	Suggestions(status string) ([]Suggestion, error)
+
+	// Suggestion returns the gloss suggestion by its ID.
+	Suggestion(id int) (Suggestion, error)
+
+	// CreateSuggestion saves a pending gloss suggestion, then returns its ID.
+	CreateSuggestion(wordID int, language, translation, reason string, at time.Time) (int, error)
+
+	// AcceptSuggestion accepts the pending suggestion and saves it as override,
+	// using the translation instead of the suggested one if it's not empty.
+	AcceptSuggestion(id int, translation string) error
+
+	// RejectSuggestion rejects the pending suggestion.
+	RejectSuggestion(id int) error
+
+	// Overrides returns the accepted overrides ordered by its language and word.
+	Overrides() ([]Override, error)
+
+	// RemoveOverride removes the override and restores the original translation.
+	RemoveOverride(wordID int, language string) error
+}
+
+// Store provides all kind of data used by kalimah.
+type Store interface {
+	SurahStore
+	WordStore
+	ProgressStore
+	ListStore
+	NoteStore
+	ExerciseStore
+	AudioStore
+	GlossStore
+
+	// ChoiceSecret returns the random secret of the learner that used to derive
+	// the choice seed, generating it first when it doesn't exist yet.
+	ChoiceSecret() (string, error)
+
+	// Ping checks whether the store is usable, then returns its schema version.
+	Ping(ctx context.Context) (int, error)
+
+	// Tx runs the function within transaction, which is committed when the
+	// function succeed or rolled back otherwise. Calling Tx within the
+	// function simply runs the inner function within the same transaction.
+	Tx(fn func(tx Store) error) error
+}
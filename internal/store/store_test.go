@@ -0,0 +1,283 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"kalimah/internal/database"
+	fp "path/filepath"
+	"testing"
+	"time"
+)
+
+var (
+	testSurahs = []Surah{
+		{ID: 1, Name: "Al-Fatihah", Translation: "The Opening", Start: 1, End: 3},
+		{ID: 2, Name: "Al-Baqarah", Translation: "The Cow", Start: 4, End: 5},
+	}
+
+	testAyahs = []Ayah{
+		{ID: 1, Translation: "In the name of Allah"},
+		{ID: 2, Translation: "All praise is for Allah"},
+		{ID: 3, Translation: "The Most Merciful"},
+		{ID: 4, Translation: "Alif Lam Mim"},
+		{ID: 5, Translation: "This is the Book"},
+	}
+
+	testWords = []Word{
+		{ID: 1, Ayah: 1, Position: 1, Arabic: "بِسْمِ", Translation: "in the name"},
+		{ID: 2, Ayah: 1, Position: 2, Arabic: "اللَّهِ", Translation: "of Allah"},
+		{ID: 3, Ayah: 2, Position: 1, Arabic: "الْحَمْدُ", Translation: "all praise"},
+		{ID: 4, Ayah: 2, Position: 2, Arabic: "لِلَّهِ", Translation: "is for Allah"},
+		{ID: 5, Ayah: 3, Position: 1, Arabic: "الرَّحِيمِ", Translation: "the most merciful"},
+		{ID: 6, Ayah: 4, Position: 1, Arabic: "الم", Translation: "alif lam mim"},
+		{ID: 7, Ayah: 5, Position: 1, Arabic: "ذَٰلِكَ", Translation: "this"},
+		{ID: 8, Ayah: 5, Position: 2, Arabic: "الْكِتَابُ", Translation: "the book"},
+	}
+)
+
+// testStores returns every store implementation filled with the test data.
+func testStores(t *testing.T) map[string]Store {
+	t.Helper()
+
+	memory := NewMemory(testSurahs, testAyahs, testWords)
+	memory.SetLanguage("en")
+
+	db, err := database.Open(fp.Join(t.TempDir(), "kalimah.db"))
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	tx := db.MustBegin()
+	for _, s := range testSurahs {
+		tx.MustExec(`INSERT INTO surah (id, name, translation, start, "end") VALUES (?, ?, ?, ?, ?)`,
+			s.ID, s.Name, s.Translation, s.Start, s.End)
+	}
+	for _, a := range testAyahs {
+		tx.MustExec(`INSERT INTO ayah (id, translation, tafsir) VALUES (?, ?, '')`, a.ID, a.Translation)
+	}
+	for _, w := range testWords {
+		tx.MustExec(`INSERT INTO word (id, ayah, position, arabic, translation) VALUES (?, ?, ?, ?, ?)`,
+			w.ID, w.Ayah, w.Position, w.Arabic, w.Translation)
+	}
+	tx.MustExec(`INSERT INTO metadata (key, value) VALUES ('language', 'en')`)
+	if err = tx.Commit(); err != nil {
+		t.Fatalf("failed to fill database: %v", err)
+	}
+
+	return map[string]Store{
+		"memory": memory,
+		"sqlite": NewSQLite(db),
+	}
+}
+
+func TestProgress(t *testing.T) {
+	for name, st := range testStores(t) {
+		t.Run(name, func(t *testing.T) {
+			progress, err := st.Progress()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if progress != (Progress{NextAyah: 1}) {
+				t.Fatalf("unexpected initial progress: %+v", progress)
+			}
+
+			if err = st.SetLastWord(3); err != nil {
+				t.Fatal(err)
+			}
+
+			progress, err = st.Progress()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if progress != (Progress{LastWord: 3, Surah: 1, Ayah: 2, NextAyah: 2}) {
+				t.Fatalf("unexpected progress: %+v", progress)
+			}
+
+			// Failed transaction doesn't change anything
+			errFailed := errors.New("failed")
+			err = st.Tx(func(tx Store) error {
+				if err := tx.SetLastWord(5); err != nil {
+					return err
+				}
+				return errFailed
+			})
+			if err != errFailed {
+				t.Fatalf("expected error from transaction, got %v", err)
+			}
+
+			progress, err = st.Progress()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if progress.LastWord != 3 {
+				t.Fatalf("failed transaction is not rolled back: %+v", progress)
+			}
+		})
+	}
+}
+
+func TestWords(t *testing.T) {
+	for name, st := range testStores(t) {
+		t.Run(name, func(t *testing.T) {
+			words, err := st.Words(2, 4)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if ids := wordIDs(words); ids != "[3 4 5 6]" {
+				t.Fatalf("unexpected words of ayah 2-4: %s", ids)
+			}
+
+			words, err = st.WordsByID([]int{7, 1, 7, 99})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if ids := wordIDs(words); ids != "[1 7]" {
+				t.Fatalf("unexpected words by ID: %s", ids)
+			}
+
+			lastWordID, err := st.LastWordID()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if lastWordID != 8 {
+				t.Fatalf("expected last word 8, got %d", lastWordID)
+			}
+
+			if _, err = st.Word(99); err != ErrNotFound {
+				t.Fatalf("expected ErrNotFound for missing word, got %v", err)
+			}
+		})
+	}
+}
+
+func TestSubmissions(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	for name, st := range testStores(t) {
+		t.Run(name, func(t *testing.T) {
+			if _, err := st.Submission("a"); err != ErrNotFound {
+				t.Fatalf("expected ErrNotFound for new submission, got %v", err)
+			}
+
+			if err := st.SaveSubmission("a", 1, "accepted", now.Add(-time.Hour)); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := st.SaveSubmission("b", 2, "rejected", now); err != nil {
+				t.Fatal(err)
+			}
+
+			status, err := st.Submission("b")
+			if err != nil || status != "rejected" {
+				t.Fatalf("unexpected submission status %q: %v", status, err)
+			}
+
+			if err = st.ForgetSubmissions(now); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err = st.Submission("a"); err != ErrNotFound {
+				t.Fatalf("expected old submission to be forgotten, got %v", err)
+			}
+
+			if _, err = st.Submission("b"); err != nil {
+				t.Fatalf("expected recent submission to be kept, got %v", err)
+			}
+		})
+	}
+}
+
+func TestLists(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	for name, st := range testStores(t) {
+		t.Run(name, func(t *testing.T) {
+			listID, err := st.CreateList("Hard", now)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			wordID, ayahID := 7, 2
+			if _, err = st.AddBookmark(listID, &wordID, nil, now); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err = st.AddBookmark(listID, nil, &ayahID, now); err != nil {
+				t.Fatal(err)
+			}
+
+			list, err := st.List(listID)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if list.WordCount != 1 || list.AyahCount != 1 {
+				t.Fatalf("unexpected list: %+v", list)
+			}
+
+			words, err := st.ListWords(listID)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if ids := wordIDs(words); ids != "[3 4 7]" {
+				t.Fatalf("unexpected words in list: %s", ids)
+			}
+
+			if err = st.DeleteList(listID); err != nil {
+				t.Fatal(err)
+			}
+
+			if err = st.DeleteList(listID); err != ErrNotFound {
+				t.Fatalf("expected ErrNotFound for deleted list, got %v", err)
+			}
+		})
+	}
+}
+
+func TestSuggestions(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	for name, st := range testStores(t) {
+		t.Run(name, func(t *testing.T) {
+			id, err := st.CreateSuggestion(7, "en", "that", "", now)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err = st.AcceptSuggestion(id, ""); err != nil {
+				t.Fatal(err)
+			}
+
+			if err = st.RejectSuggestion(id); !errors.Is(err, database.ErrSuggestionReviewed) {
+				t.Fatalf("expected reviewed suggestion error, got %v", err)
+			}
+
+			word, err := st.Word(7)
+			if err != nil || word.Translation != "that" {
+				t.Fatalf("translation is not overridden: %+v, %v", word, err)
+			}
+
+			if err = st.RemoveOverride(7, "en"); err != nil {
+				t.Fatal(err)
+			}
+
+			word, err = st.Word(7)
+			if err != nil || word.Translation != "this" {
+				t.Fatalf("translation is not restored: %+v, %v", word, err)
+			}
+		})
+	}
+}
+
+func wordIDs(words []Word) string {
+	ids := make([]int, len(words))
+	for i, word := range words {
+		ids[i] = word.ID
+	}
+	return fmt.Sprint(ids)
+}
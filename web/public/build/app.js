@@ -1,7 +1,7 @@
 (()=>{var Gn=Object.create;var ht=Object.defineProperty;var Un=Object.getOwnPropertyDescriptor;var Jn=Object.getOwnPropertyNames;var Qn=Object.getPrototypeOf,Xn=Object.prototype.hasOwnProperty;var Zn=(t,e)=>()=>(e||t((e={exports:{}}).exports,e),e.exports);var ei=(t,e,n,i)=>{if(e&&typeof e=="object"||typeof e=="function")for(let o of Jn(e))!Xn.call(t,o)&&o!==n&&ht(t,o,{get:()=>e[o],enumerable:!(i=Un(e,o))||i.enumerable});return t};var ti=(t,e,n)=>(n=t!=null?Gn(Qn(t)):{},ei(e||!t||!t.__esModule?ht(n,"default",{value:t,enumerable:!0}):n,t));var bn=Zn(Ce=>{"use strict";Object.defineProperty(Ce,"__esModule",{value:!0});function Re(){}function it(t,e){for(let n in e)t[n]=e[n];return t}function fn(t){return t()}function tn(){return Object.create(null)}function He(t){t.forEach(fn)}function Ci(t){return typeof t=="function"}function Oi(t,e){return t!=t?e==e:t!==e||t&&typeof t=="object"||typeof t=="function"}function Ai(t){return Object.keys(t).length===0}function nn(t){let e={};for(let n in t)n[0]!=="$"&&(e[n]=t[n]);return e}var We=!1;function Ii(){We=!0}function Ti(){We=!1}function Di(t,e,n,i){for(;t<e;){let o=t+(e-t>>1);n(o)<=i?t=o+1:e=o}return t}function ji(t){if(t.hydrate_init)return;t.hydrate_init=!0;let e=t.childNodes,n=new Int32Array(e.length+1),i=new Int32Array(e.length);n[0]=-1;let o=0;for(let s=0;s<e.length;s++){let a=e[s].claim_order,d=Di(1,o+1,u=>e[n[u]].claim_order,a)-1;i[s]=n[d]+1;let f=d+1;n[f]=s,o=Math.max(f,o)}let l=[],r=[],c=e.length-1;for(let s=n[o]+1;s!=0;s=i[s-1]){for(l.push(e[s-1]);c>=s;c--)r.push(e[c]);c--}for(;c>=0;c--)r.push(e[c]);l.reverse(),r.sort((s,a)=>s.claim_order-a.claim_order);for(let s=0,a=0;s<r.length;s++){for(;a<l.length&&r[s].claim_order>=l[a].claim_order;)a++;let d=a<l.length?l[a]:null;t.insertBefore(r[s],d)}}function Ni(t,e){We?(ji(t),(t.actual_end_child===void 0||t.actual_end_child!==null&&t.actual_end_child.parentElement!==t)&&(t.actual_end_child=t.firstChild),e!==t.actual_end_child?t.insertBefore(e,t.actual_end_child):t.actual_end_child=e.nextSibling):e.parentNode!==t&&t.appendChild(e)}function dn(t,e,n){We&&!n?Ni(t,e):(e.parentNode!==t||e.nextSibling!=n)&&t.insertBefore(e,n||null)}function st(t){t.parentNode.removeChild(t)}function Li(t){return document.createElementNS("http://www.w3.org/2000/svg",t)}function qi(t){return document.createTextNode(t)}function Pi(){return qi("")}function Bi(t,e,n){n==null?t.removeAttribute(e):t.getAttribute(e)!==n&&t.setAttribute(e,n)}function on(t,e){for(let n in e)Bi(t,n,e[n])}function Ri(t){return Array.from(t.childNodes)}var _n;function $e(t){_n=t}var Ee=[],ln=[],Be=[],rn=[],$i=Promise.resolve(),ot=!1;function Hi(){ot||(ot=!0,$i.then(hn))}function lt(t){Be.push(t)}var et=!1,tt=new Set;function hn(){if(!et){et=!0;do{for(let t=0;t<Ee.length;t+=1){let e=Ee[t];$e(e),Wi(e.$$)}for($e(null),Ee.length=0;ln.length;)ln.pop()();for(let t=0;t<Be.length;t+=1){let e=Be[t];tt.has(e)||(tt.add(e),e())}Be.length=0}while(Ee.length);for(;rn.length;)rn.pop()();ot=!1,et=!1,tt.clear()}}function Wi(t){if(t.fragment!==null){t.update(),He(t.before_update);let e=t.dirty;t.dirty=[-1],t.fragment&&t.fragment.p(t.ctx,e),t.after_update.forEach(lt)}}var zi=new Set;function Vi(t,e){t&&t.i&&(zi.delete(t),t.i(e))}function Yi(t,e){let n={},i={},o={$$scope:1},l=t.length;for(;l--;){let r=t[l],c=e[l];if(c){for(let s in r)s in c||(i[s]=1);for(let s in c)o[s]||(n[s]=c[s],o[s]=1);t[l]=c}else for(let s in r)o[s]=1}for(let r in i)r in n||(n[r]=void 0);return n}function xi(t,e,n,i){let{fragment:o,on_mount:l,on_destroy:r,after_update:c}=t.$$;o&&o.m(e,n),i||lt(()=>{let s=l.map(fn).filter(Ci);r?r.push(...s):He(s),t.$$.on_mount=[]}),c.forEach(lt)}function Ki(t,e){let n=t.$$;n.fragment!==null&&(He(n.on_destroy),n.fragment&&n.fragment.d(e),n.on_destroy=n.fragment=null,n.ctx=[])}function Gi(t,e){t.$$.dirty[0]===-1&&(Ee.push(t),Hi(),t.$$.dirty.fill(0)),t.$$.dirty[e/31|0]|=1<<e%31}function Ui(t,e,n,i,o,l,r=[-1]){let c=_n;$e(t);let s=t.$$={fragment:null,ctx:null,props:l,update:Re,not_equal:o,bound:tn(),on_mount:[],on_destroy:[],on_disconnect:[],before_update:[],after_update:[],context:new Map(c?c.$$.context:e.context||[]),callbacks:tn(),dirty:r,skip_bound:!1},a=!1;if(s.ctx=n?n(t,e.props||{},(d,f,...u)=>{let p=u.length?u[0]:f;return s.ctx&&o(s.ctx[d],s.ctx[d]=p)&&(!s.skip_bound&&s.bound[d]&&s.bound[d](p),a&&Gi(t,d)),f}):[],s.update(),a=!0,He(s.before_update),s.fragment=i?i(s.ctx):!1,e.target){if(e.hydrate){Ii();let d=Ri(e.target);s.fragment&&s.fragment.l(d),d.forEach(st)}else s.fragment&&s.fragment.c();e.intro&&Vi(t.$$.fragment),xi(t,e.target,e.anchor,e.customElement),Ti(),hn()}$e(c)}var mn=class{$destroy(){Ki(this,1),this.$destroy=Re}$on(e,n){let i=this.$$.callbacks[e]||(this.$$.callbacks[e]=[]);return i.push(n),()=>{let o=i.indexOf(n);o!==-1&&i.splice(o,1)}}$set(e){this.$$set&&!Ai(e)&&(this.$$.skip_bound=!0,this.$$set(e),this.$$.skip_bound=!1)}},sn=/^[a-z0-9]+(-[a-z0-9]+)*$/,ne=Object.freeze({left:0,top:0,width:16,height:16,rotate:0,vFlip:!1,hFlip:!1});function ct(t){return{...ne,...t}}function Ji(t,e){let n={...t};for(let i in ne){let o=i;if(e[o]!==void 0){let l=e[o];if(n[o]===void 0){n[o]=l;continue}switch(o){case"rotate":n[o]=(n[o]+l)%4;break;case"hFlip":case"vFlip":n[o]=l!==n[o];break;default:n[o]=l}}}return n}function cn(t,e,n=!1){function i(l,r){if(t.icons[l]!==void 0)return Object.assign({},t.icons[l]);if(r>5)return null;let c=t.aliases;if(c&&c[l]!==void 0){let a=c[l],d=i(a.parent,r+1);return d&&Ji(d,a)}let s=t.chars;return!r&&s&&s[l]!==void 0?i(s[l],r+1):null}let o=i(e,0);if(o)for(let l in ne)o[l]===void 0&&t[l]!==void 0&&(o[l]=t[l]);return o&&n?ct(o):o}function Qi(t){for(let e in ne)if(t[e]!==void 0)return!0;return!1}function Xi(t,e,n){n=n||{};let i=[];if(typeof t!="object"||typeof t.icons!="object")return i;t.not_found instanceof Array&&t.not_found.forEach(r=>{e(r,null),i.push(r)});let o=t.icons;Object.keys(o).forEach(r=>{let c=cn(t,r,!0);c&&(e(r,c),i.push(r))});let l=n.aliases||"all";if(l!=="none"&&typeof t.aliases=="object"){let r=t.aliases;Object.keys(r).forEach(c=>{if(l==="variations"&&Qi(r[c]))return;let s=cn(t,c,!0);s&&(e(c,s),i.push(c))})}return i}var rt={provider:"string",aliases:"object",not_found:"object"};for(let t in ne)rt[t]=typeof ne[t];function Zi(t){if(typeof t!="object"||t===null)return null;let e=t;if(typeof e.prefix!="string"||!t.icons||typeof t.icons!="object")return null;for(let o in rt)if(t[o]!==void 0&&typeof t[o]!==rt[o])return null;let n=e.icons;for(let o in n){let l=n[o];if(!o.match(sn)||typeof l.body!="string")return null;for(let r in ne)if(l[r]!==void 0&&typeof l[r]!=typeof ne[r])return null}let i=e.aliases;if(i)for(let o in i){let l=i[o],r=l.parent;if(!o.match(sn)||typeof r!="string"||!n[r]&&!i[r])return null;for(let c in ne)if(l[c]!==void 0&&typeof l[c]!=typeof ne[c])return null}return e}var an=Object.freeze({inline:!1,width:null,height:null,hAlign:"center",vAlign:"middle",slice:!1,hFlip:!1,vFlip:!1,rotate:0});function eo(t,e){let n={};for(let i in t){let o=i;if(n[o]=t[o],e[o]===void 0)continue;let l=e[o];switch(o){case"inline":case"slice":typeof l=="boolean"&&(n[o]=l);break;case"hFlip":case"vFlip":l===!0&&(n[o]=!n[o]);break;case"hAlign":case"vAlign":typeof l=="string"&&l!==""&&(n[o]=l);break;case"width":case"height":(typeof l=="string"&&l!==""||typeof l=="number"&&l||l===null)&&(n[o]=l);break;case"rotate":typeof l=="number"&&(n[o]+=l);break}}return n}var pn=/[\s,]+/;function to(t,e){e.split(pn).forEach(n=>{switch(n.trim()){case"horizontal":t.hFlip=!0;break;case"vertical":t.vFlip=!0;break}})}function no(t,e){e.split(pn).forEach(n=>{let i=n.trim();switch(i){case"left":case"center":case"right":t.hAlign=i;break;case"top":case"middle":case"bottom":t.vAlign=i;break;case"slice":case"crop":t.slice=!0;break;case"meet":t.slice=!1}})}function io(t,e=0){let n=t.replace(/^-?[0-9.]*/,"");function i(o){for(;o<0;)o+=4;return o%4}if(n===""){let o=parseInt(t);return isNaN(o)?0:i(o)}else if(n!==t){let o=0;switch(n){case"%":o=25;break;case"deg":o=90}if(o){let l=parseFloat(t.slice(0,t.length-n.length));return isNaN(l)?0:(l=l/o,l%1===0?i(l):0)}}return e}var oo=/(-?[0-9.]*[0-9]+[0-9.]*)/g,lo=/^-?[0-9.]*[0-9]+[0-9.]*$/g;function nt(t,e,n){if(e===1)return t;if(n=n===void 0?100:n,typeof t=="number")return Math.ceil(t*e*n)/n;if(typeof t!="string")return t;let i=t.split(oo);if(i===null||!i.length)return t;let o=[],l=i.shift(),r=lo.test(l);for(;;){if(r){let c=parseFloat(l);isNaN(c)?o.push(l):o.push(Math.ceil(c*e*n)/n)}else o.push(l);if(l=i.shift(),l===void 0)return o.join("");r=!r}}function ro(t){let e="";switch(t.hAlign){case"left":e+="xMin";break;case"right":e+="xMax";break;default:e+="xMid"}switch(t.vAlign){case"top":e+="YMin";break;case"bottom":e+="YMax";break;default:e+="YMid"}return e+=t.slice?" slice":" meet",e}function so(t,e){let n={left:t.left,top:t.top,width:t.width,height:t.height},i=t.body;[t,e].forEach(c=>{let s=[],a=c.hFlip,d=c.vFlip,f=c.rotate;a?d?f+=2:(s.push("translate("+(n.width+n.left).toString()+" "+(0-n.top).toString()+")"),s.push("scale(-1 1)"),n.top=n.left=0):d&&(s.push("translate("+(0-n.left).toString()+" "+(n.height+n.top).toString()+")"),s.push("scale(1 -1)"),n.top=n.left=0);let u;switch(f<0&&(f-=Math.floor(f/4)*4),f=f%4,f){case 1:u=n.height/2+n.top,s.unshift("rotate(90 "+u.toString()+" "+u.toString()+")");break;case 2:s.unshift("rotate(180 "+(n.width/2+n.left).toString()+" "+(n.height/2+n.top).toString()+")");break;case 3:u=n.width/2+n.left,s.unshift("rotate(-90 "+u.toString()+" "+u.toString()+")");break}f%2===1&&((n.left!==0||n.top!==0)&&(u=n.left,n.left=n.top,n.top=u),n.width!==n.height&&(u=n.width,n.width=n.height,n.height=u)),s.length&&(i='<g transform="'+s.join(" ")+'">'+i+"</g>")});let o,l;e.width===null&&e.height===null?(l="1em",o=nt(l,n.width/n.height)):e.width!==null&&e.height!==null?(o=e.width,l=e.height):e.height!==null?(l=e.height,o=nt(l,n.width/n.height)):(o=e.width,l=nt(o,n.height/n.width)),o==="auto"&&(o=n.width),l==="auto"&&(l=n.height),o=typeof o=="string"?o:o.toString()+"",l=typeof l=="string"?l:l.toString()+"";let r={attributes:{width:o,height:l,preserveAspectRatio:ro(e),viewBox:n.left.toString()+" "+n.top.toString()+" "+n.width.toString()+" "+n.height.toString()},body:i};return e.inline&&(r.inline=!0),r}var co=/\sid="(\S+)"/g,ao="IconifyId"+Date.now().toString(16)+(Math.random()*16777216|0).toString(16),uo=0;function fo(t,e=ao){let n=[],i;for(;i=co.exec(t);)n.push(i[1]);return n.length&&n.forEach(o=>{let l=typeof e=="function"?e(o):e+(uo++).toString(),r=o.replace(/[.*+?^${}()|[\]\\]/g,"\\$&");t=t.replace(new RegExp('([#;"])('+r+')([")]|\\.[a-z])',"g"),"$1"+l+"$3")}),t}var _o={xmlns:"http://www.w3.org/2000/svg","xmlns:xlink":"http://www.w3.org/1999/xlink","aria-hidden":!0,role:"img"};function ho(t,e){let n=eo(an,e),i={..._o},o=typeof e.style=="string"?e.style:"";for(let s in e){let a=e[s];if(a!==void 0)switch(s){case"icon":case"style":case"onLoad":break;case"inline":case"hFlip":case"vFlip":n[s]=a===!0||a==="true"||a===1;break;case"flip":typeof a=="string"&&to(n,a);break;case"align":typeof a=="string"&&no(n,a);break;case"color":o=o+(o.length>0&&o.trim().slice(-1)!==";"?";":"")+"color: "+a+"; ";break;case"rotate":typeof a=="string"?n[s]=io(a):typeof a=="number"&&(n[s]=a);break;case"ariaHidden":case"aria-hidden":a!==!0&&a!=="true"&&delete i["aria-hidden"];break;default:if(s.slice(0,3)==="on:")break;an[s]===void 0&&(i[s]=a)}}let l=so(t,n);for(let s in l.attributes)i[s]=l.attributes[s];l.inline&&(o="vertical-align: -0.125em; "+o),o!==""&&(i.style=o);let r=0,c=e.id;return typeof c=="string"&&(c=c.replace(/-/g,"_")),{attributes:i,body:fo(l.body,c?()=>c+"ID"+r++:"iconifySvelte")}}var at=Object.create(null);function mo(t){let e=typeof t.icon=="string"?at[t.icon]:typeof t.icon=="object"?ct(t.icon):null;return e===null||typeof e!="object"||typeof e.body!="string"?null:ho(e,t)}function po(t,e){at[t]=ct(e)}function go(t,e){let n=typeof e=="string"?e:e!==!1&&typeof t.prefix=="string"?t.prefix+":":"";Zi(t)&&Xi(t,(i,o)=>{o&&(at[n+i]=o)})}function un(t){let e,n=t[0].body+"",i=[t[0].attributes],o={};for(let l=0;l<i.length;l+=1)o=it(o,i[l]);return{c(){e=Li("svg"),on(e,o)},m(l,r){dn(l,e,r),e.innerHTML=n},p(l,r){r&1&&n!==(n=l[0].body+"")&&(e.innerHTML=n),on(e,o=Yi(i,[r&1&&l[0].attributes]))},d(l){l&&st(e)}}}function bo(t){let e,n=t[0]!==null&&un(t);return{c(){n&&n.c(),e=Pi()},m(i,o){n&&n.m(i,o),dn(i,e,o)},p(i,[o]){i[0]!==null?n?n.p(i,o):(n=un(i),n.c(),n.m(e.parentNode,e)):n&&(n.d(1),n=null)},i:Re,o:Re,d(i){n&&n.d(i),i&&st(e)}}}function yo(t,e,n){let i;return t.$$set=o=>{n(1,e=it(it({},e),nn(o)))},t.$$.update=()=>{n(0,i=mo(e))},e=nn(e),[i]}var gn=class extends mn{constructor(e){super();Ui(this,e,yo,bo,Oi,{})}};Ce.addCollection=go;Ce.addIcon=po;Ce.default=gn});function Z(){}var Le=t=>t;function gt(t,e){for(let n in e)t[n]=e[n];return t}function Je(t){return t()}function mt(){return Object.create(null)}function ee(t){t.forEach(Je)}function ke(t){return typeof t=="function"}function x(t,e){return t!=t?e==e:t!==e||t&&typeof t=="object"||typeof t=="function"}function bt(t){return Object.keys(t).length===0}function we(t,e,n,i){if(t){let o=yt(t,e,n,i);return t[0](o)}}function yt(t,e,n,i){return t[1]&&i?gt(n.ctx.slice(),t[1](i(e))):n.ctx}function ve(t,e,n,i){if(t[2]&&i){let o=t[2](i(n));if(e.dirty===void 0)return o;if(typeof o=="object"){let l=[],r=Math.max(e.dirty.length,o.length);for(let c=0;c<r;c+=1)l[c]=e.dirty[c]|o[c];return l}return e.dirty|o}return e.dirty}function Fe(t,e,n,i,o,l){if(o){let r=yt(e,n,i,l);t.p(r,o)}}function Se(t){if(t.ctx.length>32){let e=[],n=t.ctx.length/32;for(let i=0;i<n;i++)e[i]=-1;return e}return-1}function Qe(t){return t??""}function kt(t){return t&&ke(t.destroy)?t.destroy:Z}var wt=typeof window<"u",ni=wt?()=>window.performance.now():()=>Date.now(),Xe=wt?t=>requestAnimationFrame(t):Z;var fe=new Set;function vt(t){fe.forEach(e=>{e.c(t)||(fe.delete(e),e.f())}),fe.size!==0&&Xe(vt)}function ii(t){let e;return fe.size===0&&Xe(vt),{promise:new Promise(n=>{fe.add(e={c:t,f:n})}),abort(){fe.delete(e)}}}var Ft=!1;function oi(){Ft=!0}function li(){Ft=!1}function k(t,e){t.appendChild(e)}function St(t){if(!t)return document;let e=t.getRootNode?t.getRootNode():t.ownerDocument;return e&&e.host?e:t.ownerDocument}function ri(t){let e=M("style");return si(St(t),e),e.sheet}function si(t,e){k(t.head||t,e)}function A(t,e,n){t.insertBefore(e,n||null)}function O(t){t.parentNode.removeChild(t)}function ue(t,e){for(let n=0;n<t.length;n+=1)t[n]&&t[n].d(e)}function M(t){return document.createElement(t)}function B(t){return document.createTextNode(t)}function I(){return B(" ")}function X(){return B("")}function z(t,e,n,i){return t.addEventListener(e,n,i),()=>t.removeEventListener(e,n,i)}function y(t,e,n){n==null?t.removeAttribute(e):t.getAttribute(e)!==n&&t.setAttribute(e,n)}function ci(t){return Array.from(t.childNodes)}function H(t,e){e=""+e,t.wholeText!==e&&(t.data=e)}function V(t,e,n){t.classList[n?"add":"remove"](e)}function Mt(t,e,n=!1){let i=document.createEvent("CustomEvent");return i.initCustomEvent(t,n,!1,e),i}var je=new Map,Ne=0;function ai(t){let e=5381,n=t.length;for(;n--;)e=(e<<5)-e^t.charCodeAt(n);return e>>>0}function ui(t,e){let n={stylesheet:ri(e),rules:{}};return je.set(t,n),n}function pt(t,e,n,i,o,l,r,c=0){let s=16.666/i,a=`{
 `;for(let m=0;m<=1;m+=s){let F=e+(n-e)*l(m);a+=m*100+`%{${r(F,1-F)}}
 `}let d=a+`100% {${r(n,1-n)}}
-}`,f=`__svelte_${ai(d)}_${c}`,u=St(t),{stylesheet:p,rules:h}=je.get(u)||ui(u,t);h[f]||(h[f]=!0,p.insertRule(`@keyframes ${f} ${d}`,p.cssRules.length));let _=t.style.animation||"";return t.style.animation=`${_?`${_}, `:""}${f} ${i}ms linear ${o}ms 1 both`,Ne+=1,f}function fi(t,e){let n=(t.style.animation||"").split(", "),i=n.filter(e?l=>l.indexOf(e)<0:l=>l.indexOf("__svelte")===-1),o=n.length-i.length;o&&(t.style.animation=i.join(", "),Ne-=o,Ne||di())}function di(){Xe(()=>{Ne||(je.forEach(t=>{let{stylesheet:e}=t,n=e.cssRules.length;for(;n--;)e.deleteRule(n);t.rules={}}),je.clear())})}var ye;function be(t){ye=t}function Et(){if(!ye)throw new Error("Function called outside component initialization");return ye}function oe(t){Et().$$.on_mount.push(t)}function J(){let t=Et();return(e,n)=>{let i=t.$$.callbacks[e];if(i){let o=Mt(e,n);i.slice().forEach(l=>{l.call(t,o)})}}}function le(t,e){let n=t.$$.callbacks[e.type];n&&n.slice().forEach(i=>i.call(this,e))}var ge=[];var te=[],Te=[],Ge=[],Ct=Promise.resolve(),Ue=!1;function Ot(){Ue||(Ue=!0,Ct.then(It))}function Me(){return Ot(),Ct}function de(t){Te.push(t)}function At(t){Ge.push(t)}var xe=new Set,Ie=0;function It(){let t=ye;do{for(;Ie<ge.length;){let e=ge[Ie];Ie++,be(e),_i(e.$$)}for(be(null),ge.length=0,Ie=0;te.length;)te.pop()();for(let e=0;e<Te.length;e+=1){let n=Te[e];xe.has(n)||(xe.add(n),n())}Te.length=0}while(ge.length);for(;Ge.length;)Ge.pop()();Ue=!1,xe.clear(),be(t)}function _i(t){if(t.fragment!==null){t.update(),ee(t.before_update);let e=t.dirty;t.dirty=[-1],t.fragment&&t.fragment.p(t.ctx,e),t.after_update.forEach(de)}}var pe;function hi(){return pe||(pe=Promise.resolve(),pe.then(()=>{pe=null})),pe}function Ke(t,e,n){t.dispatchEvent(Mt(`${e?"intro":"outro"}${n}`))}var De=new Set,ie;function R(){ie={r:0,c:[],p:ie}}function $(){ie.r||ee(ie.c),ie=ie.p}function g(t,e){t&&t.i&&(De.delete(t),t.i(e))}function w(t,e,n,i){if(t&&t.o){if(De.has(t))return;De.add(t),ie.c.push(()=>{De.delete(t),i&&(n&&t.d(1),i())}),t.o(e)}}var mi={duration:0};function Ze(t,e,n,i){let o=e(t,n),l=i?0:1,r=null,c=null,s=null;function a(){s&&fi(t,s)}function d(u,p){let h=u.b-l;return p*=Math.abs(h),{a:l,b:u.b,d:h,duration:p,start:u.start,end:u.start+p,group:u.group}}function f(u){let{delay:p=0,duration:h=300,easing:_=Le,tick:m=Z,css:F}=o||mi,E={start:ni()+p,b:u};u||(E.group=ie,ie.r+=1),r||c?c=E:(F&&(a(),s=pt(t,l,u,h,p,_,F)),u&&m(0,1),r=d(E,h),de(()=>Ke(t,u,"start")),ii(C=>{if(c&&C>c.start&&(r=d(c,h),c=null,Ke(t,r.b,"start"),F&&(a(),s=pt(t,l,r.b,r.duration,0,_,o.css))),r){if(C>=r.end)m(l=r.b,1-l),Ke(t,r.b,"end"),c||(r.b?a():--r.group.r||ee(r.group.c)),r=null;else if(C>=r.start){let q=C-r.start;l=r.a+r.d*_(q/r.duration),m(l,1-l)}}return!!(r||c)}))}return{run(u){ke(o)?hi().then(()=>{o=o(),f(u)}):f(u)},end(){a(),r=c=null}}}var Qo=typeof window<"u"?window:typeof globalThis<"u"?globalThis:global;function qe(t,e){t.d(1),e.delete(t.key)}function Pe(t,e,n,i,o,l,r,c,s,a,d,f){let u=t.length,p=l.length,h=u,_={};for(;h--;)_[t[h].key]=h;let m=[],F=new Map,E=new Map;for(h=p;h--;){let b=f(o,l,h),D=n(b),T=r.get(D);T?i&&T.p(b,e):(T=a(D,b),T.c()),F.set(D,m[h]=T),D in _&&E.set(D,Math.abs(h-_[D]))}let C=new Set,q=new Set;function S(b){g(b,1),b.m(c,d),r.set(b.key,b),d=b.first,p--}for(;u&&p;){let b=m[p-1],D=t[u-1],T=b.key,v=D.key;b===D?(d=b.first,u--,p--):F.has(v)?!r.has(T)||C.has(T)?S(b):q.has(v)?u--:E.get(T)>E.get(v)?(q.add(T),S(b)):(C.add(v),u--):(s(D,r),u--)}for(;u--;){let b=t[u];F.has(b.key)||s(b,r)}for(;p;)S(m[p-1]);return m}function Tt(t,e,n){let i=t.$$.props[e];i!==void 0&&(t.$$.bound[i]=n,n(t.$$.ctx[i]))}function L(t){t&&t.c()}function N(t,e,n,i){let{fragment:o,on_mount:l,on_destroy:r,after_update:c}=t.$$;o&&o.m(e,n),i||de(()=>{let s=l.map(Je).filter(ke);r?r.push(...s):ee(s),t.$$.on_mount=[]}),c.forEach(de)}function j(t,e){let n=t.$$;n.fragment!==null&&(ee(n.on_destroy),n.fragment&&n.fragment.d(e),n.on_destroy=n.fragment=null,n.ctx=[])}function pi(t,e){t.$$.dirty[0]===-1&&(ge.push(t),Ot(),t.$$.dirty.fill(0)),t.$$.dirty[e/31|0]|=1<<e%31}function K(t,e,n,i,o,l,r,c=[-1]){let s=ye;be(t);let a=t.$$={fragment:null,ctx:null,props:l,update:Z,not_equal:o,bound:mt(),on_mount:[],on_destroy:[],on_disconnect:[],before_update:[],after_update:[],context:new Map(e.context||(s?s.$$.context:[])),callbacks:mt(),dirty:c,skip_bound:!1,root:e.target||s.$$.root};r&&r(a.root);let d=!1;if(a.ctx=n?n(t,e.props||{},(f,u,...p)=>{let h=p.length?p[0]:u;return a.ctx&&o(a.ctx[f],a.ctx[f]=h)&&(!a.skip_bound&&a.bound[f]&&a.bound[f](h),d&&pi(t,f)),u}):[],a.update(),d=!0,ee(a.before_update),a.fragment=i?i(a.ctx):!1,e.target){if(e.hydrate){oi();let f=ci(e.target);a.fragment&&a.fragment.l(f),f.forEach(O)}else a.fragment&&a.fragment.c();e.intro&&g(t.$$.fragment),N(t,e.target,e.anchor,e.customElement),li(),It()}be(s)}var gi;typeof HTMLElement=="function"&&(gi=class extends HTMLElement{constructor(){super();this.attachShadow({mode:"open"})}connectedCallback(){let{on_mount:t}=this.$$;this.$$.on_disconnect=t.map(Je).filter(ke);for(let e in this.$$.slotted)this.appendChild(this.$$.slotted[e])}attributeChangedCallback(t,e,n){this[t]=n}disconnectedCallback(){ee(this.$$.on_disconnect)}$destroy(){j(this,1),this.$destroy=Z}$on(t,e){let n=this.$$.callbacks[t]||(this.$$.callbacks[t]=[]);return n.push(e),()=>{let i=n.indexOf(e);i!==-1&&n.splice(i,1)}}$set(t){this.$$set&&!bt(t)&&(this.$$.skip_bound=!0,this.$$set(t),this.$$.skip_bound=!1)}});var Y=class{$destroy(){j(this,1),this.$destroy=Z}$on(e,n){let i=this.$$.callbacks[e]||(this.$$.callbacks[e]=[]);return i.push(n),()=>{let o=i.indexOf(n);o!==-1&&i.splice(o,1)}}$set(e){this.$$set&&!bt(e)&&(this.$$.skip_bound=!0,this.$$set(e),this.$$.skip_bound=!1)}};function bi(t){let e,n;return{c(){e=M("div"),y(e,"style",t[1]),y(e,"class",n="loading-cover "+t[0]+" svelte-1bbqxv3")},m(i,o){A(i,e,o)},p(i,[o]){o&2&&y(e,"style",i[1]),o&1&&n!==(n="loading-cover "+i[0]+" svelte-1bbqxv3")&&y(e,"class",n)},i:Z,o:Z,d(i){i&&O(e)}}}function yi(t,e,n){let{class:i=""}=e,{style:o=""}=e;return t.$$set=l=>{"class"in l&&n(0,i=l.class),"style"in l&&n(1,o=l.style)},[i,o]}var Dt=class extends Y{constructor(e){super();K(this,e,yi,bi,x,{class:0,style:1})}},re=Dt;async function _e(t){let e=await fetch(t);if(!e.ok){let n=await e.text();throw Error(`${n.trim()} (${e.status})`)}return e.headers.get("content-type")==="application/json"?await e.json():await e.text()}async function jt(t,e){let n={method:"post",headers:{}};e!=null&&(n.body=JSON.stringify(e),n.headers["Content-Type"]="application/json; charset=utf-8");let i=await fetch(t,n);if(!i.ok){let o=await i.text();throw Error(`${o.trim()} (${i.status})`)}return i.headers.get("content-type")==="application/json"?await i.json():await i.text()}function Nt(t,e,n){let i=t.slice();return i[9]=e[n],i[11]=n,i}function Lt(t,e){let n,i,o=e[11]+1+"",l,r,c,s=e[9].name+"",a,d,f,u=e[9].translation+"",p,h,_,m,F;function E(){return e[6](e[9])}return{key:t,first:null,c(){var C;n=M("div"),i=M("p"),l=B(o),r=I(),c=M("p"),a=B(s),d=I(),f=M("p"),p=B(u),h=I(),y(i,"class","number svelte-1eug43a"),y(c,"class","name svelte-1eug43a"),y(f,"class","translation svelte-1eug43a"),y(n,"class","item svelte-1eug43a"),y(n,"role","button"),y(n,"tabindex","0"),y(n,"aria-disabled",_=!e[9].translated),V(n,"active",e[9].id===((C=e[1])==null?void 0:C.id)),this.first=n},m(C,q){A(C,n,q),k(n,i),k(i,l),k(n,r),k(n,c),k(c,a),k(n,d),k(n,f),k(f,p),k(n,h),m||(F=z(n,"click",E),m=!0)},p(C,q){var S;e=C,q&8&&o!==(o=e[11]+1+"")&&H(l,o),q&8&&s!==(s=e[9].name+"")&&H(a,s),q&8&&u!==(u=e[9].translation+"")&&H(p,u),q&8&&_!==(_=!e[9].translated)&&y(n,"aria-disabled",_),q&10&&V(n,"active",e[9].id===((S=e[1])==null?void 0:S.id))},d(C){C&&O(n),m=!1,F()}}}function qt(t){let e,n;return e=new re({props:{class:"list-loading"}}),{c(){L(e.$$.fragment)},m(i,o){N(e,i,o),n=!0},i(i){n||(g(e.$$.fragment,i),n=!0)},o(i){w(e.$$.fragment,i),n=!1},d(i){j(e,i)}}}function ki(t){let e,n,i=[],o=new Map,l,r,c,s=t[3],a=f=>f[9].id;for(let f=0;f<s.length;f+=1){let u=Nt(t,s,f),p=a(u);o.set(p,i[f]=Lt(p,u))}let d=t[4]&&qt(t);return{c(){e=M("div"),n=M("div");for(let f=0;f<i.length;f+=1)i[f].c();l=I(),d&&d.c(),y(n,"class","container svelte-1eug43a"),y(e,"class",r="root "+t[0]+" svelte-1eug43a"),y(e,"style",t[2]),y(e,"data-scrollbar","")},m(f,u){A(f,e,u),k(e,n);for(let p=0;p<i.length;p+=1)i[p].m(n,null);k(e,l),d&&d.m(e,null),c=!0},p(f,[u]){u&42&&(s=f[3],i=Pe(i,u,a,1,f,s,o,n,qe,Lt,null,Nt)),f[4]?d?u&16&&g(d,1):(d=qt(f),d.c(),g(d,1),d.m(e,null)):d&&(R(),w(d,1,1,()=>{d=null}),$()),(!c||u&1&&r!==(r="root "+f[0]+" svelte-1eug43a"))&&y(e,"class",r),(!c||u&4)&&y(e,"style",f[2])},i(f){c||(g(d),c=!0)},o(f){w(d),c=!1},d(f){f&&O(e);for(let u=0;u<i.length;u+=1)i[u].d();d&&d.d()}}}function wi(t,e,n){let i=J(),{class:o=""}=e,{active:l}=e,{style:r=""}=e,c=[],s=!1;async function a(){n(4,s=!0);try{n(3,c=await _e("/api/surah")),await Me()}catch(u){i("error",String(u))}n(4,s=!1)}function d(u){i("itemclick",{surah:u})}oe(()=>{a()});let f=u=>d(u);return t.$$set=u=>{"class"in u&&n(0,o=u.class),"active"in u&&n(1,l=u.active),"style"in u&&n(2,r=u.style)},[o,l,r,c,s,d,f]}var Pt=class extends Y{constructor(e){super();K(this,e,wi,ki,x,{class:0,active:1,style:2})}},Bt=Pt;function Rt(t,e,n){let i=t.slice();return i[19]=e[n],i}function $t(t,e,n){let i=t.slice();return i[22]=e[n],i}function Ht(t){let e,n=xt(t[22].ayah)+"",i,o,l,r,c;function s(){return t[13](t[22])}return{c(){e=M("button"),i=B(n),o=I(),y(e,"class","number svelte-1g2o8u6"),e.disabled=l=!t[22].answered},m(a,d){A(a,e,d),k(e,i),k(e,o),r||(c=z(e,"click",s),r=!0)},p(a,d){t=a,d&4&&n!==(n=xt(t[22].ayah)+"")&&H(i,n),d&4&&l!==(l=!t[22].answered)&&(e.disabled=l)},d(a){a&&O(e),r=!1,c()}}}function Wt(t,e){let n,i,o=e[22].arabic+"",l,r,c,s=e[22].translation+"",a,d,f,u,p=e[22].isSeparator&&Ht(e);return{key:t,first:null,c(){var h;n=M("div"),i=M("p"),l=B(o),r=I(),c=M("p"),a=B(s),f=I(),p&&p.c(),u=X(),y(i,"class","arabic svelte-1g2o8u6"),y(c,"class","translation svelte-1g2o8u6"),V(c,"unanswered",!e[22].answered),y(n,"class","item svelte-1g2o8u6"),y(n,"tabindex","0"),y(n,"role","button"),y(n,"aria-disabled",d=!e[22].answered),V(n,"active",e[22].id===((h=e[0])==null?void 0:h.id)),this.first=n},m(h,_){A(h,n,_),k(n,i),k(i,l),k(n,r),k(n,c),k(c,a),A(h,f,_),p&&p.m(h,_),A(h,u,_)},p(h,_){var m;e=h,_&4&&o!==(o=e[22].arabic+"")&&H(l,o),_&4&&s!==(s=e[22].translation+"")&&H(a,s),_&4&&V(c,"unanswered",!e[22].answered),_&4&&d!==(d=!e[22].answered)&&y(n,"aria-disabled",d),_&5&&V(n,"active",e[22].id===((m=e[0])==null?void 0:m.id)),e[22].isSeparator?p?p.p(e,_):(p=Ht(e),p.c(),p.m(u.parentNode,u)):p&&(p.d(1),p=null)},d(h){h&&O(n),h&&O(f),p&&p.d(h),h&&O(u)}}}function zt(t){let e,n=[...Array(t[3]).keys()].map(Kt),i=[];for(let o=0;o<n.length;o+=1)i[o]=Vt(Rt(t,n,o));return{c(){e=M("div");for(let o=0;o<i.length;o+=1)i[o].c();y(e,"class","footer svelte-1g2o8u6")},m(o,l){A(o,e,l);for(let r=0;r<i.length;r+=1)i[r].m(e,null)},p(o,l){if(l&280){n=[...Array(o[3]).keys()].map(Kt);let r;for(r=0;r<n.length;r+=1){let c=Rt(o,n,r);i[r]?i[r].p(c,l):(i[r]=Vt(c),i[r].c(),i[r].m(e,null))}for(;r<i.length;r+=1)i[r].d(1);i.length=n.length}},d(o){o&&O(e),ue(i,o)}}}function Vt(t){let e,n=t[19]+"",i,o,l,r;function c(){return t[15](t[19])}return{c(){e=M("button"),i=B(n),o=I(),y(e,"class","svelte-1g2o8u6"),V(e,"active",t[19]===t[4])},m(s,a){A(s,e,a),k(e,i),k(e,o),l||(r=z(e,"click",c),l=!0)},p(s,a){t=s,a&8&&n!==(n=t[19]+"")&&H(i,n),a&24&&V(e,"active",t[19]===t[4])},d(s){s&&O(e),l=!1,r()}}}function Yt(t){let e,n;return e=new re({props:{class:"surah-loading"}}),{c(){L(e.$$.fragment)},m(i,o){N(e,i,o),n=!0},i(i){n||(g(e.$$.fragment,i),n=!0)},o(i){w(e.$$.fragment,i),n=!1},d(i){j(e,i)}}}function vi(t){let e,n,i=[],o=new Map,l,r,c,s,a,d,f=t[2],u=_=>_[22].id;for(let _=0;_<f.length;_+=1){let m=$t(t,f,_),F=u(m);o.set(F,i[_]=Wt(F,m))}let p=t[3]>1&&zt(t),h=t[5]&&Yt(t);return{c(){e=M("div"),n=M("div");for(let _=0;_<i.length;_+=1)i[_].c();l=I(),p&&p.c(),r=I(),h&&h.c(),y(n,"class","container svelte-1g2o8u6"),y(n,"data-scrollbar",""),y(n,"tabindex","0"),y(e,"class",c="root "+t[1]+" svelte-1g2o8u6")},m(_,m){A(_,e,m),k(e,n);for(let F=0;F<i.length;F+=1)i[F].m(n,null);t[14](n),k(e,l),p&&p.m(e,null),k(e,r),h&&h.m(e,null),s=!0,a||(d=z(n,"keydown",t[7]),a=!0)},p(_,[m]){m&517&&(f=_[2],i=Pe(i,m,u,1,_,f,o,n,qe,Wt,null,$t)),_[3]>1?p?p.p(_,m):(p=zt(_),p.c(),p.m(e,r)):p&&(p.d(1),p=null),_[5]?h?m&32&&g(h,1):(h=Yt(_),h.c(),g(h,1),h.m(e,null)):h&&(R(),w(h,1,1,()=>{h=null}),$()),(!s||m&2&&c!==(c="root "+_[1]+" svelte-1g2o8u6"))&&y(e,"class",c)},i(_){s||(g(h),s=!0)},o(_){w(h),s=!1},d(_){_&&O(e);for(let m=0;m<i.length;m+=1)i[m].d();t[14](null),p&&p.d(),h&&h.d(),a=!1,d()}}}var Fi="\u0660\u0661\u0662\u0663\u0664\u0665\u0666\u0667\u0668\u0669";function xt(t){let e="";for(let n of t.toString()){let i=parseInt(n,10);isNaN(i)||(e+=Fi[i])}return e}var Kt=t=>t+1;function Si(t,e,n){let i=J(),{class:o=""}=e,{surah:l}=e,{activeWord:r=void 0}=e,c=[],s,a,d=!1,f=!1,u;async function p(){n(2,c=[]),n(5,f=!0);try{let b=`/api/words/surah/${l==null?void 0:l.id}/page/${a||0}`,D=await _e(b);n(2,c=D.words),n(3,s=D.maxPage),n(4,a=D.currentPage),n(12,d=D.disabled),await Me(),h()}catch(b){i("error",String(b))}n(5,f=!1)}function h(){let b=u.querySelector(".item.active"),D=b==null?0:b.offsetTop;n(6,u.scrollTop=D-36,u)}function _(b){b.key==="Home"&&!b.ctrlKey?(b.preventDefault(),b.stopPropagation(),h()):b.key==="-"&&a>1?m(a-1):b.key==="+"&&a<s&&m(a+1)}function m(b){n(4,a=b),p()}function F(b){i("ayahclick",{surah:l==null?void 0:l.id,ayah:b})}function E(b){if(b==null)return;let D=c.findIndex(P=>P.id===b.id);D>=0&&n(2,c[D].answered=!0,c);let T=b.id+1;!(c.findIndex(P=>P.id===T)>=0)&&a<s?m(a+1):h()}oe(()=>p());let C=b=>F(b.ayah);function q(b){te[b?"unshift":"push"](()=>{u=b,n(6,u)})}let S=b=>m(b);return t.$$set=b=>{"class"in b&&n(1,o=b.class),"surah"in b&&n(10,l=b.surah),"activeWord"in b&&n(0,r=b.activeWord)},t.$$.update=()=>{if(t.$$.dirty&4100){e:d?n(0,r=void 0):n(0,r=c.find(b=>!b.answered))}},[r,o,c,s,a,f,u,_,m,F,l,E,d,C,q,S]}var Gt=class extends Y{constructor(e){super();K(this,e,Si,vi,x,{class:1,surah:10,activeWord:0,markAnswered:11})}get markAnswered(){return this.$$.ctx[11]}},Ut=Gt;function Jt(t,e,n){let i=t.slice();return i[7]=e[n],i}function Qt(t){let e,n=t[7].text+"",i,o,l,r;function c(){return t[5](t[7])}return{c(){e=M("button"),i=B(n),o=I(),y(e,"class","svelte-1lw8tih"),V(e,"wrong",t[2].includes(t[7].text))},m(s,a){A(s,e,a),k(e,i),k(e,o),l||(r=z(e,"click",c),l=!0)},p(s,a){t=s,a&2&&n!==(n=t[7].text+"")&&H(i,n),a&6&&V(e,"wrong",t[2].includes(t[7].text))},d(s){s&&O(e),l=!1,r()}}}function Xt(t){let e,n;return e=new re({props:{class:"answer-loading"}}),{c(){L(e.$$.fragment)},m(i,o){N(e,i,o),n=!0},i(i){n||(g(e.$$.fragment,i),n=!0)},o(i){w(e.$$.fragment,i),n=!1},d(i){j(e,i)}}}function Mi(t){var p,h;let e,n,i=((p=t[1])==null?void 0:p.arabic)+"",o,l,r,c,s,a,d=((h=t[1])==null?void 0:h.choices)||[],f=[];for(let _=0;_<d.length;_+=1)f[_]=Qt(Jt(t,d,_));let u=t[3]&&Xt(t);return{c(){e=M("div"),n=M("p"),o=B(i),l=I(),r=M("div");for(let _=0;_<f.length;_+=1)f[_].c();c=I(),u&&u.c(),y(n,"class","arabic svelte-1lw8tih"),y(r,"class","container svelte-1lw8tih"),y(e,"class",s="root "+t[0]+" svelte-1lw8tih")},m(_,m){A(_,e,m),k(e,n),k(n,o),k(e,l),k(e,r);for(let F=0;F<f.length;F+=1)f[F].m(r,null);k(e,c),u&&u.m(e,null),a=!0},p(_,[m]){var F,E;if((!a||m&2)&&i!==(i=((F=_[1])==null?void 0:F.arabic)+"")&&H(o,i),m&22){d=((E=_[1])==null?void 0:E.choices)||[];let C;for(C=0;C<d.length;C+=1){let q=Jt(_,d,C);f[C]?f[C].p(q,m):(f[C]=Qt(q),f[C].c(),f[C].m(r,null))}for(;C<f.length;C+=1)f[C].d(1);f.length=d.length}_[3]?u?m&8&&g(u,1):(u=Xt(_),u.c(),g(u,1),u.m(e,null)):u&&(R(),w(u,1,1,()=>{u=null}),$()),(!a||m&1&&s!==(s="root "+_[0]+" svelte-1lw8tih"))&&y(e,"class",s)},i(_){a||(g(u),a=!0)},o(_){w(u),a=!1},d(_){_&&O(e),ue(f,_),u&&u.d()}}}function Ei(t,e,n){let i=J(),{class:o=""}=e,{word:l}=e,r=[],c=!1;async function s(d,f){if(!(f==null||c)){if(!d.isCorrect){n(2,r=[...r,d.text]),r.length>=5&&n(2,r=[]);return}if(f.isSeparator){n(3,c=!0);let u=!1;try{await jt("/api/track",f)}catch(p){console.error(p),u=!0}if(n(3,c=!1),u)return}i("answered")}}let a=d=>s(d,l);return t.$$set=d=>{"class"in d&&n(0,o=d.class),"word"in d&&n(1,l=d.word)},t.$$.update=()=>{if(t.$$.dirty&2){e:document.activeElement.blur()}},[o,l,r,c,s,a]}var Zt=class extends Y{constructor(e){super();K(this,e,Ei,Mi,x,{class:0,word:1})}},en=Zt;var Oe=ti(bn());function yn(t,e,n){let i=t.slice();return i[22]=e[n],i}function kn(t,e,n){let i=t.slice();return i[22]=e[n],i}function wn(t){let e,n,i,o,l,r=[wo,ko],c=[];function s(a,d){return d&128&&(e=null),e==null&&(e=!Array.isArray(a[7])),e?0:1}return n=s(t,-1),i=c[n]=r[n](t),{c(){i.c(),o=X()},m(a,d){c[n].m(a,d),A(a,o,d),l=!0},p(a,d){let f=n;n=s(a,d),n===f?c[n].p(a,d):(R(),w(c[f],1,1,()=>{c[f]=null}),$(),i=c[n],i?i.p(a,d):(i=c[n]=r[n](a),i.c()),g(i,1),i.m(o.parentNode,o))},i(a){l||(g(i),l=!0)},o(a){w(i),l=!1},d(a){c[n].d(a),a&&O(o)}}}function ko(t){let e,n,i=t[7],o=[];for(let r=0;r<i.length;r+=1)o[r]=vn(kn(t,i,r));let l=r=>w(o[r],1,1,()=>{o[r]=null});return{c(){for(let r=0;r<o.length;r+=1)o[r].c();e=X()},m(r,c){for(let s=0;s<o.length;s+=1)o[s].m(r,c);A(r,e,c),n=!0},p(r,c){if(c&128){i=r[7];let s;for(s=0;s<i.length;s+=1){let a=kn(r,i,s);o[s]?(o[s].p(a,c),g(o[s],1)):(o[s]=vn(a),o[s].c(),g(o[s],1),o[s].m(e.parentNode,e))}for(R(),s=i.length;s<o.length;s+=1)l(s);$()}},i(r){if(!n){for(let c=0;c<i.length;c+=1)g(o[c]);n=!0}},o(r){o=o.filter(Boolean);for(let c=0;c<o.length;c+=1)w(o[c]);n=!1},d(r){ue(o,r),r&&O(e)}}}function wo(t){let e,n;return e=new Oe.default({props:{icon:t[7],height:18}}),{c(){L(e.$$.fragment)},m(i,o){N(e,i,o),n=!0},p(i,o){let l={};o&128&&(l.icon=i[7]),e.$set(l)},i(i){n||(g(e.$$.fragment,i),n=!0)},o(i){w(e.$$.fragment,i),n=!1},d(i){j(e,i)}}}function vn(t){let e,n;return e=new Oe.default({props:{icon:t[22],height:18}}),{c(){L(e.$$.fragment)},m(i,o){N(e,i,o),n=!0},p(i,o){let l={};o&128&&(l.icon=i[22]),e.$set(l)},i(i){n||(g(e.$$.fragment,i),n=!0)},o(i){w(e.$$.fragment,i),n=!1},d(i){j(e,i)}}}function Fn(t){let e,n;return{c(){e=M("span"),n=B(t[2]),y(e,"class","text svelte-1aukgre")},m(i,o){A(i,e,o),k(e,n)},p(i,o){o&4&&H(n,i[2])},d(i){i&&O(e)}}}function Sn(t){let e,n,i,o,l,r=[Fo,vo],c=[];function s(a,d){return d&256&&(e=null),e==null&&(e=!Array.isArray(a[8])),e?0:1}return n=s(t,-1),i=c[n]=r[n](t),{c(){i.c(),o=X()},m(a,d){c[n].m(a,d),A(a,o,d),l=!0},p(a,d){let f=n;n=s(a,d),n===f?c[n].p(a,d):(R(),w(c[f],1,1,()=>{c[f]=null}),$(),i=c[n],i?i.p(a,d):(i=c[n]=r[n](a),i.c()),g(i,1),i.m(o.parentNode,o))},i(a){l||(g(i),l=!0)},o(a){w(i),l=!1},d(a){c[n].d(a),a&&O(o)}}}function vo(t){let e,n,i=t[8],o=[];for(let r=0;r<i.length;r+=1)o[r]=Mn(yn(t,i,r));let l=r=>w(o[r],1,1,()=>{o[r]=null});return{c(){for(let r=0;r<o.length;r+=1)o[r].c();e=X()},m(r,c){for(let s=0;s<o.length;s+=1)o[s].m(r,c);A(r,e,c),n=!0},p(r,c){if(c&256){i=r[8];let s;for(s=0;s<i.length;s+=1){let a=yn(r,i,s);o[s]?(o[s].p(a,c),g(o[s],1)):(o[s]=Mn(a),o[s].c(),g(o[s],1),o[s].m(e.parentNode,e))}for(R(),s=i.length;s<o.length;s+=1)l(s);$()}},i(r){if(!n){for(let c=0;c<i.length;c+=1)g(o[c]);n=!0}},o(r){o=o.filter(Boolean);for(let c=0;c<o.length;c+=1)w(o[c]);n=!1},d(r){ue(o,r),r&&O(e)}}}function Fo(t){let e,n;return e=new Oe.default({props:{icon:t[8],height:18}}),{c(){L(e.$$.fragment)},m(i,o){N(e,i,o),n=!0},p(i,o){let l={};o&256&&(l.icon=i[8]),e.$set(l)},i(i){n||(g(e.$$.fragment,i),n=!0)},o(i){w(e.$$.fragment,i),n=!1},d(i){j(e,i)}}}function Mn(t){let e,n;return e=new Oe.default({props:{icon:t[22],height:18}}),{c(){L(e.$$.fragment)},m(i,o){N(e,i,o),n=!0},p(i,o){let l={};o&256&&(l.icon=i[22]),e.$set(l)},i(i){n||(g(e.$$.fragment,i),n=!0)},o(i){w(e.$$.fragment,i),n=!1},d(i){j(e,i)}}}function So(t){let e,n,i,o,l=t[7]!=null&&wn(t),r=t[2]!==""&&Fn(t),c=t[8]!=null&&Sn(t);return{c(){l&&l.c(),e=I(),r&&r.c(),n=I(),c&&c.c(),i=X()},m(s,a){l&&l.m(s,a),A(s,e,a),r&&r.m(s,a),A(s,n,a),c&&c.m(s,a),A(s,i,a),o=!0},p(s,a){s[7]!=null?l?(l.p(s,a),a&128&&g(l,1)):(l=wn(s),l.c(),g(l,1),l.m(e.parentNode,e)):l&&(R(),w(l,1,1,()=>{l=null}),$()),s[2]!==""?r?r.p(s,a):(r=Fn(s),r.c(),r.m(n.parentNode,n)):r&&(r.d(1),r=null),s[8]!=null?c?(c.p(s,a),a&256&&g(c,1)):(c=Sn(s),c.c(),g(c,1),c.m(i.parentNode,i)):c&&(R(),w(c,1,1,()=>{c=null}),$())},i(s){o||(g(l),g(c),o=!0)},o(s){w(l),w(c),o=!1},d(s){l&&l.d(s),s&&O(e),r&&r.d(s),s&&O(n),c&&c.d(s),s&&O(i)}}}function En(t){let e,n;return{c(){e=M("span"),n=B(t[6]),y(e,"class","tooltip svelte-1aukgre")},m(i,o){A(i,e,o),k(e,n),t[19](e)},p(i,o){o&64&&H(n,i[6])},d(i){i&&O(e),t[19](null)}}}function Mo(t){let e,n,i,o,l,r,c=t[15].default,s=we(c,t,t[14],null),a=s||So(t),d=t[6]!==""&&En(t);return{c(){e=M("button"),a&&a.c(),n=I(),d&&d.c(),y(e,"id",t[1]),y(e,"style",t[3]),e.disabled=t[5],y(e,"class",i=Qe(t[0])+" svelte-1aukgre"),V(e,"active",t[4])},m(f,u){A(f,e,u),a&&a.m(e,null),k(e,n),d&&d.m(e,null),t[20](e),o=!0,l||(r=[z(e,"click",t[16]),z(e,"dblclick",t[17]),z(e,"keydown",t[18]),z(e,"mouseenter",t[11]),z(e,"mouseleave",t[12])],l=!0)},p(f,[u]){s?s.p&&(!o||u&16384)&&Fe(s,c,f,f[14],o?ve(c,f[14],u,null):Se(f[14]),null):a&&a.p&&(!o||u&388)&&a.p(f,o?u:-1),f[6]!==""?d?d.p(f,u):(d=En(f),d.c(),d.m(e,null)):d&&(d.d(1),d=null),(!o||u&2)&&y(e,"id",f[1]),(!o||u&8)&&y(e,"style",f[3]),(!o||u&32)&&(e.disabled=f[5]),(!o||u&1&&i!==(i=Qe(f[0])+" svelte-1aukgre"))&&y(e,"class",i),u&17&&V(e,"active",f[4])},i(f){o||(g(a,f),o=!0)},o(f){w(a,f),o=!1},d(f){f&&O(e),a&&a.d(f),d&&d.d(),t[20](null),l=!1,ee(r)}}}function Eo(t,e,n){let{$$slots:i={},$$scope:o}=e,{class:l=""}=e,{id:r=""}=e,{text:c=""}=e,{style:s=""}=e,{active:a=!1}=e,{disabled:d=!1}=e,{tooltip:f=""}=e,{tooltipPlacement:u="right"}=e,{icon:p=void 0}=e,{rightIcon:h=void 0}=e,_,m;function F(v){if(m==null)return[0,0];let P=window.innerWidth,W=window.innerHeight,Ae=_.getBoundingClientRect(),he=Ae.y,me=Ae.x,Ve=Ae.width,Ye=Ae.height,dt=me+Ve/2,_t=he+Ye/2,ce=m.offsetWidth,ae=m.offsetHeight,Q=8,G=0,U=0;switch(v){case"top":G=he-Q-ae,U=dt-ce/2,G<0&&(G=he+Ye+Q);break;case"left":G=_t-ae/2,U=me-Q-ce,U<0&&(U=me+Ve+Q);break;case"right":G=_t-ae/2,U=me+Ve+Q,U+ce>P&&(U=me-Q-ce);break;case"bottom":G=he+Ye+Q,U=dt-ce/2,G+ae>W&&(G=he-Q-ae);break}return v==="top"||v==="bottom"?U<0?U=Q:U+ce>P&&(U=P-ce-Q):(v==="left"||v==="right")&&(G<0?G=Q:G+ae>W&&(G=W-ae-Q)),[U,G]}function E(){if(m==null)return;let[v,P]=F(u);n(10,m.style.top=`${P}px`,m),n(10,m.style.left=`${v}px`,m),n(10,m.style.opacity="1",m)}function C(){m!=null&&m.removeAttribute("style")}function q(v){le.call(this,t,v)}function S(v){le.call(this,t,v)}function b(v){le.call(this,t,v)}function D(v){te[v?"unshift":"push"](()=>{m=v,n(10,m)})}function T(v){te[v?"unshift":"push"](()=>{_=v,n(9,_)})}return t.$$set=v=>{"class"in v&&n(0,l=v.class),"id"in v&&n(1,r=v.id),"text"in v&&n(2,c=v.text),"style"in v&&n(3,s=v.style),"active"in v&&n(4,a=v.active),"disabled"in v&&n(5,d=v.disabled),"tooltip"in v&&n(6,f=v.tooltip),"tooltipPlacement"in v&&n(13,u=v.tooltipPlacement),"icon"in v&&n(7,p=v.icon),"rightIcon"in v&&n(8,h=v.rightIcon),"$$scope"in v&&n(14,o=v.$$scope)},[l,r,c,s,a,d,f,p,h,_,m,E,C,u,o,i,q,S,b,D,T]}var Cn=class extends Y{constructor(e){super();K(this,e,Eo,Mo,x,{class:0,id:1,text:2,style:3,active:4,disabled:5,tooltip:6,tooltipPlacement:13,icon:7,rightIcon:8})}},se=Cn;var Co={body:'<path fill="currentColor" d="M20 11H7.83l5.59-5.59L12 4l-8 8l8 8l1.41-1.41L7.83 13H20v-2z"/>',width:24,height:24},On=Co;var Oo={body:'<path fill="currentColor" d="m6.76 4.84l-1.8-1.79l-1.41 1.41l1.79 1.79zM1 10.5h3v2H1zM11 .55h2V3.5h-2zm8.04 2.495l1.408 1.407l-1.79 1.79l-1.407-1.408zm-1.8 15.115l1.79 1.8l1.41-1.41l-1.8-1.79zM20 10.5h3v2h-3zm-8-5c-3.31 0-6 2.69-6 6s2.69 6 6 6s6-2.69 6-6s-2.69-6-6-6zm0 10c-2.21 0-4-1.79-4-4s1.79-4 4-4s4 1.79 4 4s-1.79 4-4 4zm-1 4h2v2.95h-2zm-7.45-.96l1.41 1.41l1.79-1.8l-1.41-1.41z"/>',width:24,height:24},An=Oo;var Ao={body:'<path fill="currentColor" d="M17.65 6.35A7.958 7.958 0 0 0 12 4c-4.42 0-7.99 3.58-7.99 8s3.57 8 7.99 8c3.73 0 6.84-2.55 7.73-6h-2.08A5.99 5.99 0 0 1 12 18c-3.31 0-6-2.69-6-6s2.69-6 6-6c1.66 0 3.14.69 4.22 1.78L13 11h7V4l-2.35 2.35z"/>',width:24,height:24},In=Ao;function Tn(t){let e,n;return e=new se({props:{icon:On}}),e.$on("click",t[3]),{c(){L(e.$$.fragment)},m(i,o){N(e,i,o),n=!0},p:Z,i(i){n||(g(e.$$.fragment,i),n=!0)},o(i){w(e.$$.fragment,i),n=!1},d(i){j(e,i)}}}function Io(t){let e,n,i,o,l,r,c,s,a,d,f=t[2]&&Tn(t);return r=new se({props:{icon:In}}),r.$on("click",To),s=new se({props:{icon:An}}),s.$on("click",Do),{c(){e=M("div"),f&&f.c(),n=I(),i=M("p"),o=B(t[1]),l=I(),L(r.$$.fragment),c=I(),L(s.$$.fragment),y(i,"class","svelte-1wil3la"),y(e,"class",a="header "+t[0]+" svelte-1wil3la")},m(u,p){A(u,e,p),f&&f.m(e,null),k(e,n),k(e,i),k(i,o),k(e,l),N(r,e,null),k(e,c),N(s,e,null),d=!0},p(u,[p]){u[2]?f?(f.p(u,p),p&4&&g(f,1)):(f=Tn(u),f.c(),g(f,1),f.m(e,n)):f&&(R(),w(f,1,1,()=>{f=null}),$()),(!d||p&2)&&H(o,u[1]),(!d||p&1&&a!==(a="header "+u[0]+" svelte-1wil3la"))&&y(e,"class",a)},i(u){d||(g(f),g(r.$$.fragment,u),g(s.$$.fragment,u),d=!0)},o(u){w(f),w(r.$$.fragment,u),w(s.$$.fragment,u),d=!1},d(u){u&&O(e),f&&f.d(),j(r),j(s)}}}function To(){window.location.reload()}function Do(){let t=document.documentElement;localStorage.getItem("dark-mode")==="1"?(localStorage.removeItem("dark-mode"),t.classList.remove("dark")):(localStorage.setItem("dark-mode","1"),t.classList.add("dark"))}function jo(t,e,n){let i=J(),{class:o=""}=e,{title:l=""}=e,{backVisible:r=!1}=e;function c(){i("back")}return t.$$set=s=>{"class"in s&&n(0,o=s.class),"title"in s&&n(1,l=s.title),"backVisible"in s&&n(2,r=s.backVisible)},[o,l,r,c]}var Dn=class extends Y{constructor(e){super();K(this,e,jo,Io,x,{class:0,title:1,backVisible:2})}},jn=Dn;var No={body:'<path fill="currentColor" d="M19 6.41L17.59 5L12 10.59L6.41 5L5 6.41L10.59 12L5 17.59L6.41 19L12 13.41L17.59 19L19 17.59L13.41 12L19 6.41z"/>',width:24,height:24},Nn=No;function ut(t){return--t*t*t*t*t+1}function ft(t,{delay:e=0,duration:n=400,easing:i=Le}={}){let o=+getComputedStyle(t).opacity;return{delay:e,duration:n,easing:i,css:l=>`opacity: ${l*o}`}}var Lo=t=>({}),Ln=t=>({}),qo=t=>({}),qn=t=>({});function Pn(t){let e,n;return e=new se({props:{icon:Nn,disabled:t[5]}}),e.$on("click",t[9]),{c(){L(e.$$.fragment)},m(i,o){N(e,i,o),n=!0},p(i,o){let l={};o&32&&(l.disabled=i[5]),e.$set(l)},i(i){n||(g(e.$$.fragment,i),n=!0)},o(i){w(e.$$.fragment,i),n=!1},d(i){j(e,i)}}}function Po(t){let e;return{c(){e=M("p"),e.textContent="Dialog is empty"},m(n,i){A(n,e,i)},d(n){n&&O(e)}}}function Bn(t){let e,n;return e=new re({props:{class:"loading-cover"}}),{c(){L(e.$$.fragment)},m(i,o){N(e,i,o),n=!0},i(i){n||(g(e.$$.fragment,i),n=!0)},o(i){w(e.$$.fragment,i),n=!1},d(i){j(e,i)}}}function Rn(t){let e,n;return e=new se({props:{text:t[7],disabled:t[5]}}),e.$on("click",t[11]),{c(){L(e.$$.fragment)},m(i,o){N(e,i,o),n=!0},p(i,o){let l={};o&128&&(l.text=i[7]),o&32&&(l.disabled=i[5]),e.$set(l)},i(i){n||(g(e.$$.fragment,i),n=!0)},o(i){w(e.$$.fragment,i),n=!1},d(i){j(e,i)}}}function Bo(t){let e,n,i,o;e=new se({props:{text:t[6],disabled:t[5]}}),e.$on("click",t[10]);let l=t[7]&&Rn(t);return{c(){L(e.$$.fragment),n=I(),l&&l.c(),i=X()},m(r,c){N(e,r,c),A(r,n,c),l&&l.m(r,c),A(r,i,c),o=!0},p(r,c){let s={};c&64&&(s.text=r[6]),c&32&&(s.disabled=r[5]),e.$set(s),r[7]?l?(l.p(r,c),c&128&&g(l,1)):(l=Rn(r),l.c(),g(l,1),l.m(i.parentNode,i)):l&&(R(),w(l,1,1,()=>{l=null}),$())},i(r){o||(g(e.$$.fragment,r),g(l),o=!0)},o(r){w(e.$$.fragment,r),w(l),o=!1},d(r){j(e,r),r&&O(n),l&&l.d(r),r&&O(i)}}}function Ro(t){let e,n,i,o,l,r,c,s,a,d,f,u,p,h,_,m,F,E=t[3]&&Pn(t),C=t[15].content,q=we(C,t,t[14],qn),S=q||Po(t),b=t[5]&&Bn(t),D=t[15].footer,T=we(D,t,t[14],Ln),v=T||Bo(t);return{c(){e=M("div"),n=M("div"),i=M("div"),o=M("p"),l=B(t[1]),r=I(),E&&E.c(),c=I(),s=M("div"),S&&S.c(),a=I(),b&&b.c(),d=I(),f=M("div"),v&&v.c(),y(o,"class","title svelte-1jsr7ie"),y(i,"class","header svelte-1jsr7ie"),y(s,"class","content svelte-1jsr7ie"),y(s,"data-scrollbar",""),y(s,"tabindex","-1"),y(f,"class","footer svelte-1jsr7ie"),y(n,"style",t[2]),y(n,"class",u="dialog "+t[0]+" svelte-1jsr7ie"),V(n,"error",t[4]),y(e,"class","dialog-overlay svelte-1jsr7ie"),y(e,"tabindex","-1")},m(P,W){A(P,e,W),k(e,n),k(n,i),k(i,o),k(o,l),k(i,r),E&&E.m(i,null),k(n,c),k(n,s),S&&S.m(s,null),k(s,a),b&&b.m(s,null),k(n,d),k(n,f),v&&v.m(f,null),_=!0,m||(F=[kt(p=t[8].call(null,n)),z(e,"keydown",t[12]),z(e,"keydown",t[16],!0)],m=!0)},p(P,[W]){t=P,(!_||W&2)&&H(l,t[1]),t[3]?E?(E.p(t,W),W&8&&g(E,1)):(E=Pn(t),E.c(),g(E,1),E.m(i,null)):E&&(R(),w(E,1,1,()=>{E=null}),$()),q&&q.p&&(!_||W&16384)&&Fe(q,C,t,t[14],_?ve(C,t[14],W,qo):Se(t[14]),qn),t[5]?b?W&32&&g(b,1):(b=Bn(t),b.c(),g(b,1),b.m(s,null)):b&&(R(),w(b,1,1,()=>{b=null}),$()),T?T.p&&(!_||W&16384)&&Fe(T,D,t,t[14],_?ve(D,t[14],W,Lo):Se(t[14]),Ln):v&&v.p&&(!_||W&224)&&v.p(t,_?W:-1),(!_||W&4)&&y(n,"style",t[2]),(!_||W&1&&u!==(u="dialog "+t[0]+" svelte-1jsr7ie"))&&y(n,"class",u),W&17&&V(n,"error",t[4])},i(P){_||(g(E),g(S,P),g(b),g(v,P),de(()=>{h||(h=Ze(e,ft,{easing:ut},!0)),h.run(1)}),_=!0)},o(P){w(E),w(S,P),w(b),w(v,P),h||(h=Ze(e,ft,{easing:ut},!1)),h.run(0),_=!1},d(P){P&&O(e),E&&E.d(),S&&S.d(P),b&&b.d(),v&&v.d(P),P&&h&&h.end(),m=!1,ee(F)}}}function $o(t,e,n){let{$$slots:i={},$$scope:o}=e,l=J(),{class:r=""}=e,{title:c}=e,{style:s=""}=e,{closable:a=!0}=e,{isError:d=!1}=e,{loading:f=!1}=e,{mainButtonText:u="OK"}=e,{secondaryButtonText:p=void 0}=e,{defaultFocusTargets:h=[]}=e;function _(S){if(Array.isArray(h))for(let T=0;T<h.length;T++){let v=h[T],P=S.querySelector(v);if(P){P.focus();return}}let b=[":scope .content button:enabled",":scope .content input:read-write",":scope .content textarea:read-write",":scope .content label.checkbox-input",":scope .footer button:enabled",":scope .footer input:read-write",":scope .footer textarea:read-write"].join(", "),D=null;D=S.querySelector(b),D!=null?D.focus():S.focus()}function m(){l("close")}function F(){l("mainclick")}function E(){l("secondaryclick")}function C(S){a&&(S.key==="Esc"||S.key==="Escape")&&(S.preventDefault(),S.stopPropagation(),l("close"))}function q(S){le.call(this,t,S)}return t.$$set=S=>{"class"in S&&n(0,r=S.class),"title"in S&&n(1,c=S.title),"style"in S&&n(2,s=S.style),"closable"in S&&n(3,a=S.closable),"isError"in S&&n(4,d=S.isError),"loading"in S&&n(5,f=S.loading),"mainButtonText"in S&&n(6,u=S.mainButtonText),"secondaryButtonText"in S&&n(7,p=S.secondaryButtonText),"defaultFocusTargets"in S&&n(13,h=S.defaultFocusTargets),"$$scope"in S&&n(14,o=S.$$scope)},[r,c,s,a,d,f,u,p,_,m,F,E,C,h,o,i,q]}var $n=class extends Y{constructor(e){super();K(this,e,$o,Ro,x,{class:0,title:1,style:2,closable:3,isError:4,loading:5,mainButtonText:6,secondaryButtonText:7,defaultFocusTargets:13})}},ze=$n;function Ho(t){var f,u,p;let e,n,i=(((f=t[1])==null?void 0:f.arabic)||"")+"",o,l,r,c=(((u=t[1])==null?void 0:u.translation)||"")+"",s,a,d=(((p=t[1])==null?void 0:p.tafsir)||"")+"";return{c(){e=M("div"),n=M("p"),o=B(i),l=I(),r=M("div"),s=I(),a=M("div"),y(n,"class","arabic svelte-1vgcngs"),y(r,"class","trans svelte-1vgcngs"),y(a,"class","trans svelte-1vgcngs"),y(e,"slot","content"),y(e,"class","tafsir-content svelte-1vgcngs")},m(h,_){A(h,e,_),k(e,n),k(n,o),k(e,l),k(e,r),r.innerHTML=c,k(e,s),k(e,a),a.innerHTML=d},p(h,_){var m,F,E;_&2&&i!==(i=(((m=h[1])==null?void 0:m.arabic)||"")+"")&&H(o,i),_&2&&c!==(c=(((F=h[1])==null?void 0:F.translation)||"")+"")&&(r.innerHTML=c),_&2&&d!==(d=(((E=h[1])==null?void 0:E.tafsir)||"")+"")&&(a.innerHTML=d)},d(h){h&&O(e)}}}function Wo(t){let e,n;return e=new ze({props:{title:t[0],loading:t[2],class:"dialog-translation",$$slots:{content:[Ho]},$$scope:{ctx:t}}}),e.$on("close",t[5]),e.$on("mainclick",t[6]),{c(){L(e.$$.fragment)},m(i,o){N(e,i,o),n=!0},p(i,[o]){let l={};o&1&&(l.title=i[0]),o&4&&(l.loading=i[2]),o&514&&(l.$$scope={dirty:o,ctx:i}),e.$set(l)},i(i){n||(g(e.$$.fragment,i),n=!0)},o(i){w(e.$$.fragment,i),n=!1},d(i){j(e,i)}}}function zo(t,e,n){let i=J(),{title:o="Tafsir dan Terjemah"}=e,{ayah:l}=e,{surah:r}=e,c,s=!1;async function a(){n(2,s=!0);try{n(1,c=await _e(`/api/tafsir/surah/${r}/ayah/${l}`))}catch(u){i("error",String(u))}n(2,s=!1)}oe(()=>a());function d(u){le.call(this,t,u)}function f(u){le.call(this,t,u)}return t.$$set=u=>{"title"in u&&n(0,o=u.title),"ayah"in u&&n(3,l=u.ayah),"surah"in u&&n(4,r=u.surah)},[o,c,s,l,r,d,f]}var Hn=class extends Y{constructor(e){super();K(this,e,zo,Wo,x,{title:0,ayah:3,surah:4})}},Wn=Hn;function Vo(t){let e,n,i,o,l;function r(a){t[17](a)}let c={class:"surah",surah:t[0]};t[2]!==void 0&&(c.activeWord=t[2]),e=new Ut({props:c}),t[16](e),te.push(()=>Tt(e,"activeWord",r)),e.$on("ayahclick",t[10]),e.$on("error",t[13]);let s=t[2]!=null&&zn(t);return{c(){L(e.$$.fragment),i=I(),s&&s.c(),o=X()},m(a,d){N(e,a,d),A(a,i,d),s&&s.m(a,d),A(a,o,d),l=!0},p(a,d){let f={};d&1&&(f.surah=a[0]),!n&&d&4&&(n=!0,f.activeWord=a[2],At(()=>n=!1)),e.$set(f),a[2]!=null?s?(s.p(a,d),d&4&&g(s,1)):(s=zn(a),s.c(),g(s,1),s.m(o.parentNode,o)):s&&(R(),w(s,1,1,()=>{s=null}),$())},i(a){l||(g(e.$$.fragment,a),g(s),l=!0)},o(a){w(e.$$.fragment,a),w(s),l=!1},d(a){t[16](null),j(e,a),a&&O(i),s&&s.d(a),a&&O(o)}}}function Yo(t){let e,n;return e=new Bt({props:{class:"list-surah",active:t[0]}}),e.$on("itemclick",t[9]),e.$on("error",t[13]),{c(){L(e.$$.fragment)},m(i,o){N(e,i,o),n=!0},p(i,o){let l={};o&1&&(l.active=i[0]),e.$set(l)},i(i){n||(g(e.$$.fragment,i),n=!0)},o(i){w(e.$$.fragment,i),n=!1},d(i){j(e,i)}}}function zn(t){let e,n;return e=new en({props:{class:"answer",word:t[2]}}),e.$on("answered",t[11]),e.$on("error",t[13]),{c(){L(e.$$.fragment)},m(i,o){N(e,i,o),n=!0},p(i,o){let l={};o&4&&(l.word=i[2]),e.$set(l)},i(i){n||(g(e.$$.fragment,i),n=!0)},o(i){w(e.$$.fragment,i),n=!1},d(i){j(e,i)}}}function Vn(t){let e,n;return e=new ze({props:{title:"Error",isError:!0,closable:!1,$$slots:{content:[xo]},$$scope:{ctx:t}}}),e.$on("mainclick",t[18]),{c(){L(e.$$.fragment)},m(i,o){N(e,i,o),n=!0},p(i,o){let l={};o&2097280&&(l.$$scope={dirty:o,ctx:i}),e.$set(l)},i(i){n||(g(e.$$.fragment,i),n=!0)},o(i){w(e.$$.fragment,i),n=!1},d(i){j(e,i)}}}function xo(t){let e,n;return{c(){e=M("p"),n=B(t[7]),y(e,"slot","content")},m(i,o){A(i,e,o),k(e,n)},p(i,o){o&128&&H(n,i[7])},d(i){i&&O(e)}}}function Yn(t){var i;let e,n;return e=new Wn({props:{ayah:t[3],title:t[4],surah:((i=t[0])==null?void 0:i.id)||1}}),e.$on("error",t[12]),e.$on("close",t[19]),e.$on("mainclick",t[20]),{c(){L(e.$$.fragment)},m(o,l){N(e,o,l),n=!0},p(o,l){var c;let r={};l&8&&(r.ayah=o[3]),l&16&&(r.title=o[4]),l&1&&(r.surah=((c=o[0])==null?void 0:c.id)||1),e.$set(r)},i(o){n||(g(e.$$.fragment,o),n=!0)},o(o){w(e.$$.fragment,o),n=!1},d(o){j(e,o)}}}function Ko(t){let e,n,i,o,l,r,c,s,a,d;n=new jn({props:{title:t[8],backVisible:t[0]!=null}}),n.$on("back",t[15]);let f=[Yo,Vo],u=[];function p(m,F){return m[0]==null?0:1}o=p(t,-1),l=u[o]=f[o](t);let h=t[6]&&Vn(t),_=t[5]&&Yn(t);return{c(){e=M("div"),L(n.$$.fragment),i=I(),l.c(),r=I(),h&&h.c(),c=I(),_&&_.c(),y(e,"class","app svelte-19ciix5")},m(m,F){A(m,e,F),N(n,e,null),k(e,i),u[o].m(e,null),k(e,r),h&&h.m(e,null),k(e,c),_&&_.m(e,null),s=!0,a||(d=z(window,"popstate",t[14]),a=!0)},p(m,[F]){let E={};F&256&&(E.title=m[8]),F&1&&(E.backVisible=m[0]!=null),n.$set(E);let C=o;o=p(m,F),o===C?u[o].p(m,F):(R(),w(u[C],1,1,()=>{u[C]=null}),$(),l=u[o],l?l.p(m,F):(l=u[o]=f[o](m),l.c()),g(l,1),l.m(e,r)),m[6]?h?(h.p(m,F),F&64&&g(h,1)):(h=Vn(m),h.c(),g(h,1),h.m(e,c)):h&&(R(),w(h,1,1,()=>{h=null}),$()),m[5]?_?(_.p(m,F),F&32&&g(_,1)):(_=Yn(m),_.c(),g(_,1),_.m(e,null)):_&&(R(),w(_,1,1,()=>{_=null}),$())},i(m){s||(g(n.$$.fragment,m),g(l),g(h),g(_),s=!0)},o(m){w(n.$$.fragment,m),w(l),w(h),w(_),s=!1},d(m){m&&O(e),j(n),u[o].d(),h&&h.d(),_&&_.d(),a=!1,d()}}}function Go(t,e,n){let i,o,l,r,c=0,s,a=!1,d=!1,f="";oe(()=>{history.pushState(null,document.title,location.href)});function u(T){n(2,r=void 0),n(0,l=T.detail.surah)}function p(T){n(3,c=T.detail.ayah),n(4,s=`${l==null?void 0:l.name} ${c}`),n(5,a=!0)}function h(){o==null||o.markAnswered(r)}function _(T){n(5,a=!1),n(6,d=!0),n(7,f=T.detail)}function m(T){n(6,d=!0),n(7,f=T.detail)}let F=()=>history.go(1),E=()=>{n(0,l=void 0)};function C(T){te[T?"unshift":"push"](()=>{o=T,n(1,o)})}function q(T){r=T,n(2,r)}let S=()=>n(6,d=!1),b=()=>n(5,a=!1),D=()=>n(5,a=!1);return t.$$.update=()=>{if(t.$$.dirty&1){e:n(8,i=l?l.name:"Daftar Surah")}},[l,o,r,c,s,a,d,f,i,u,p,h,_,m,F,E,C,q,S,b,D]}var xn=class extends Y{constructor(e){super();K(this,e,Go,Ko,x,{})}},Kn=xn;var Uo=new Kn({target:document.body}),Yr=Uo;})();
+}`,f=`__svelte_${ai(d)}_${c}`,u=St(t),{stylesheet:p,rules:h}=je.get(u)||ui(u,t);h[f]||(h[f]=!0,p.insertRule(`@keyframes ${f} ${d}`,p.cssRules.length));let _=t.style.animation||"";return t.style.animation=`${_?`${_}, `:""}${f} ${i}ms linear ${o}ms 1 both`,Ne+=1,f}function fi(t,e){let n=(t.style.animation||"").split(", "),i=n.filter(e?l=>l.indexOf(e)<0:l=>l.indexOf("__svelte")===-1),o=n.length-i.length;o&&(t.style.animation=i.join(", "),Ne-=o,Ne||di())}function di(){Xe(()=>{Ne||(je.forEach(t=>{let{stylesheet:e}=t,n=e.cssRules.length;for(;n--;)e.deleteRule(n);t.rules={}}),je.clear())})}var ye;function be(t){ye=t}function Et(){if(!ye)throw new Error("Function called outside component initialization");return ye}function oe(t){Et().$$.on_mount.push(t)}function Vp(t){Et().$$.on_destroy.push(t)}function J(){let t=Et();return(e,n)=>{let i=t.$$.callbacks[e];if(i){let o=Mt(e,n);i.slice().forEach(l=>{l.call(t,o)})}}}function le(t,e){let n=t.$$.callbacks[e.type];n&&n.slice().forEach(i=>i.call(this,e))}var ge=[];var te=[],Te=[],Ge=[],Ct=Promise.resolve(),Ue=!1;function Ot(){Ue||(Ue=!0,Ct.then(It))}function Me(){return Ot(),Ct}function de(t){Te.push(t)}function At(t){Ge.push(t)}var xe=new Set,Ie=0;function It(){let t=ye;do{for(;Ie<ge.length;){let e=ge[Ie];Ie++,be(e),_i(e.$$)}for(be(null),ge.length=0,Ie=0;te.length;)te.pop()();for(let e=0;e<Te.length;e+=1){let n=Te[e];xe.has(n)||(xe.add(n),n())}Te.length=0}while(ge.length);for(;Ge.length;)Ge.pop()();Ue=!1,xe.clear(),be(t)}function _i(t){if(t.fragment!==null){t.update(),ee(t.before_update);let e=t.dirty;t.dirty=[-1],t.fragment&&t.fragment.p(t.ctx,e),t.after_update.forEach(de)}}var pe;function hi(){return pe||(pe=Promise.resolve(),pe.then(()=>{pe=null})),pe}function Ke(t,e,n){t.dispatchEvent(Mt(`${e?"intro":"outro"}${n}`))}var De=new Set,ie;function R(){ie={r:0,c:[],p:ie}}function $(){ie.r||ee(ie.c),ie=ie.p}function g(t,e){t&&t.i&&(De.delete(t),t.i(e))}function w(t,e,n,i){if(t&&t.o){if(De.has(t))return;De.add(t),ie.c.push(()=>{De.delete(t),i&&(n&&t.d(1),i())}),t.o(e)}}var mi={duration:0};function Ze(t,e,n,i){let o=e(t,n),l=i?0:1,r=null,c=null,s=null;function a(){s&&fi(t,s)}function d(u,p){let h=u.b-l;return p*=Math.abs(h),{a:l,b:u.b,d:h,duration:p,start:u.start,end:u.start+p,group:u.group}}function f(u){let{delay:p=0,duration:h=300,easing:_=Le,tick:m=Z,css:F}=o||mi,E={start:ni()+p,b:u};u||(E.group=ie,ie.r+=1),r||c?c=E:(F&&(a(),s=pt(t,l,u,h,p,_,F)),u&&m(0,1),r=d(E,h),de(()=>Ke(t,u,"start")),ii(C=>{if(c&&C>c.start&&(r=d(c,h),c=null,Ke(t,r.b,"start"),F&&(a(),s=pt(t,l,r.b,r.duration,0,_,o.css))),r){if(C>=r.end)m(l=r.b,1-l),Ke(t,r.b,"end"),c||(r.b?a():--r.group.r||ee(r.group.c)),r=null;else if(C>=r.start){let q=C-r.start;l=r.a+r.d*_(q/r.duration),m(l,1-l)}}return!!(r||c)}))}return{run(u){ke(o)?hi().then(()=>{o=o(),f(u)}):f(u)},end(){a(),r=c=null}}}var Qo=typeof window<"u"?window:typeof globalThis<"u"?globalThis:global;function qe(t,e){t.d(1),e.delete(t.key)}function Pe(t,e,n,i,o,l,r,c,s,a,d,f){let u=t.length,p=l.length,h=u,_={};for(;h--;)_[t[h].key]=h;let m=[],F=new Map,E=new Map;for(h=p;h--;){let b=f(o,l,h),D=n(b),T=r.get(D);T?i&&T.p(b,e):(T=a(D,b),T.c()),F.set(D,m[h]=T),D in _&&E.set(D,Math.abs(h-_[D]))}let C=new Set,q=new Set;function S(b){g(b,1),b.m(c,d),r.set(b.key,b),d=b.first,p--}for(;u&&p;){let b=m[p-1],D=t[u-1],T=b.key,v=D.key;b===D?(d=b.first,u--,p--):F.has(v)?!r.has(T)||C.has(T)?S(b):q.has(v)?u--:E.get(T)>E.get(v)?(q.add(T),S(b)):(C.add(v),u--):(s(D,r),u--)}for(;u--;){let b=t[u];F.has(b.key)||s(b,r)}for(;p;)S(m[p-1]);return m}function Tt(t,e,n){let i=t.$$.props[e];i!==void 0&&(t.$$.bound[i]=n,n(t.$$.ctx[i]))}function L(t){t&&t.c()}function N(t,e,n,i){let{fragment:o,on_mount:l,on_destroy:r,after_update:c}=t.$$;o&&o.m(e,n),i||de(()=>{let s=l.map(Je).filter(ke);r?r.push(...s):ee(s),t.$$.on_mount=[]}),c.forEach(de)}function j(t,e){let n=t.$$;n.fragment!==null&&(ee(n.on_destroy),n.fragment&&n.fragment.d(e),n.on_destroy=n.fragment=null,n.ctx=[])}function pi(t,e){t.$$.dirty[0]===-1&&(ge.push(t),Ot(),t.$$.dirty.fill(0)),t.$$.dirty[e/31|0]|=1<<e%31}function K(t,e,n,i,o,l,r,c=[-1]){let s=ye;be(t);let a=t.$$={fragment:null,ctx:null,props:l,update:Z,not_equal:o,bound:mt(),on_mount:[],on_destroy:[],on_disconnect:[],before_update:[],after_update:[],context:new Map(e.context||(s?s.$$.context:[])),callbacks:mt(),dirty:c,skip_bound:!1,root:e.target||s.$$.root};r&&r(a.root);let d=!1;if(a.ctx=n?n(t,e.props||{},(f,u,...p)=>{let h=p.length?p[0]:u;return a.ctx&&o(a.ctx[f],a.ctx[f]=h)&&(!a.skip_bound&&a.bound[f]&&a.bound[f](h),d&&pi(t,f)),u}):[],a.update(),d=!0,ee(a.before_update),a.fragment=i?i(a.ctx):!1,e.target){if(e.hydrate){oi();let f=ci(e.target);a.fragment&&a.fragment.l(f),f.forEach(O)}else a.fragment&&a.fragment.c();e.intro&&g(t.$$.fragment),N(t,e.target,e.anchor,e.customElement),li(),It()}be(s)}var gi;typeof HTMLElement=="function"&&(gi=class extends HTMLElement{constructor(){super();this.attachShadow({mode:"open"})}connectedCallback(){let{on_mount:t}=this.$$;this.$$.on_disconnect=t.map(Je).filter(ke);for(let e in this.$$.slotted)this.appendChild(this.$$.slotted[e])}attributeChangedCallback(t,e,n){this[t]=n}disconnectedCallback(){ee(this.$$.on_disconnect)}$destroy(){j(this,1),this.$destroy=Z}$on(t,e){let n=this.$$.callbacks[t]||(this.$$.callbacks[t]=[]);return n.push(e),()=>{let i=n.indexOf(e);i!==-1&&n.splice(i,1)}}$set(t){this.$$set&&!bt(t)&&(this.$$.skip_bound=!0,this.$$set(t),this.$$.skip_bound=!1)}});var Y=class{$destroy(){j(this,1),this.$destroy=Z}$on(e,n){let i=this.$$.callbacks[e]||(this.$$.callbacks[e]=[]);return i.push(n),()=>{let o=i.indexOf(n);o!==-1&&i.splice(o,1)}}$set(e){this.$$set&&!bt(e)&&(this.$$.skip_bound=!0,this.$$set(e),this.$$.skip_bound=!1)}};function bi(t){let e,n;return{c(){e=M("div"),y(e,"style",t[1]),y(e,"class",n="loading-cover "+t[0]+" svelte-1bbqxv3")},m(i,o){A(i,e,o)},p(i,[o]){o&2&&y(e,"style",i[1]),o&1&&n!==(n="loading-cover "+i[0]+" svelte-1bbqxv3")&&y(e,"class",n)},i:Z,o:Z,d(i){i&&O(e)}}}function yi(t,e,n){let{class:i=""}=e,{style:o=""}=e;return t.$$set=l=>{"class"in l&&n(0,i=l.class),"style"in l&&n(1,o=l.style)},[i,o]}var Dt=class extends Y{constructor(e){super();K(this,e,yi,bi,x,{class:0,style:1})}},re=Dt;function Bp(t){let e=document.querySelector('meta[name="base-path"]');return t.startsWith("/")&&e?e.content+t:t}async function _e(t){let e=await fetch(Bp(t));if(!e.ok){let n=await e.text();throw Error(`${n.trim()} (${e.status})`)}return e.headers.get("content-type")==="application/json"?await e.json():await e.text()}async function jt(t,e){let n={method:"post",headers:{}};e!=null&&(n.body=JSON.stringify(e),n.headers["Content-Type"]="application/json; charset=utf-8");let i=await fetch(Bp(t),n);if(!i.ok){let o=await i.text();throw Error(`${o.trim()} (${i.status})`)}return i.headers.get("content-type")==="application/json"?await i.json():await i.text()}function Nt(t,e,n){let i=t.slice();return i[9]=e[n],i[11]=n,i}function Lt(t,e){let n,i,o=e[11]+1+"",l,r,c,s=e[9].name+"",a,d,f,u=e[9].translation+"",p,h,_,m,F;function E(){return e[6](e[9])}return{key:t,first:null,c(){var C;n=M("div"),i=M("p"),l=B(o),r=I(),c=M("p"),a=B(s),d=I(),f=M("p"),p=B(u),h=I(),y(i,"class","number svelte-1eug43a"),y(c,"class","name svelte-1eug43a"),y(f,"class","translation svelte-1eug43a"),y(n,"class","item svelte-1eug43a"),y(n,"role","button"),y(n,"tabindex","0"),y(n,"aria-disabled",_=!e[9].translated),V(n,"active",e[9].id===((C=e[1])==null?void 0:C.id)),this.first=n},m(C,q){A(C,n,q),k(n,i),k(i,l),k(n,r),k(n,c),k(c,a),k(n,d),k(n,f),k(f,p),k(n,h),m||(F=z(n,"click",E),m=!0)},p(C,q){var S;e=C,q&8&&o!==(o=e[11]+1+"")&&H(l,o),q&8&&s!==(s=e[9].name+"")&&H(a,s),q&8&&u!==(u=e[9].translation+"")&&H(p,u),q&8&&_!==(_=!e[9].translated)&&y(n,"aria-disabled",_),q&10&&V(n,"active",e[9].id===((S=e[1])==null?void 0:S.id))},d(C){C&&O(n),m=!1,F()}}}function qt(t){let e,n;return e=new re({props:{class:"list-loading"}}),{c(){L(e.$$.fragment)},m(i,o){N(e,i,o),n=!0},i(i){n||(g(e.$$.fragment,i),n=!0)},o(i){w(e.$$.fragment,i),n=!1},d(i){j(e,i)}}}function ki(t){let e,n,i=[],o=new Map,l,r,c,s=t[3],a=f=>f[9].id;for(let f=0;f<s.length;f+=1){let u=Nt(t,s,f),p=a(u);o.set(p,i[f]=Lt(p,u))}let d=t[4]&&qt(t);return{c(){e=M("div"),n=M("div");for(let f=0;f<i.length;f+=1)i[f].c();l=I(),d&&d.c(),y(n,"class","container svelte-1eug43a"),y(e,"class",r="root "+t[0]+" svelte-1eug43a"),y(e,"style",t[2]),y(e,"data-scrollbar","")},m(f,u){A(f,e,u),k(e,n);for(let p=0;p<i.length;p+=1)i[p].m(n,null);k(e,l),d&&d.m(e,null),c=!0},p(f,[u]){u&42&&(s=f[3],i=Pe(i,u,a,1,f,s,o,n,qe,Lt,null,Nt)),f[4]?d?u&16&&g(d,1):(d=qt(f),d.c(),g(d,1),d.m(e,null)):d&&(R(),w(d,1,1,()=>{d=null}),$()),(!c||u&1&&r!==(r="root "+f[0]+" svelte-1eug43a"))&&y(e,"class",r),(!c||u&4)&&y(e,"style",f[2])},i(f){c||(g(d),c=!0)},o(f){w(d),c=!1},d(f){f&&O(e);for(let u=0;u<i.length;u+=1)i[u].d();d&&d.d()}}}function wi(t,e,n){let i=J(),{class:o=""}=e,{active:l}=e,{style:r=""}=e,c=[],s=!1;async function a(){n(4,s=!0);try{n(3,c=await _e("/api/surah")),await Me()}catch(u){i("error",String(u))}n(4,s=!1)}function d(u){i("itemclick",{surah:u})}oe(()=>{a()});let f=u=>d(u);return t.$$set=u=>{"class"in u&&n(0,o=u.class),"active"in u&&n(1,l=u.active),"style"in u&&n(2,r=u.style)},[o,l,r,c,s,d,f]}var Pt=class extends Y{constructor(e){super();K(this,e,wi,ki,x,{class:0,active:1,style:2})}},Bt=Pt;function Rt(t,e,n){let i=t.slice();return i[19]=e[n],i}function $t(t,e,n){let i=t.slice();return i[22]=e[n],i}function Ht(t){let e,n=xt(t[22].ayah)+"",i,o,l,r,c;function s(){return t[13](t[22])}return{c(){e=M("button"),i=B(n),o=I(),y(e,"class","number svelte-1g2o8u6"),e.disabled=l=!t[22].answered},m(a,d){A(a,e,d),k(e,i),k(e,o),r||(c=z(e,"click",s),r=!0)},p(a,d){t=a,d&4&&n!==(n=xt(t[22].ayah)+"")&&H(i,n),d&4&&l!==(l=!t[22].answered)&&(e.disabled=l)},d(a){a&&O(e),r=!1,c()}}}function Wt(t,e){let n,i,o=e[22].arabic+"",l,r,c,s=e[22].translation+"",a,d,f,u,p=e[22].isSeparator&&Ht(e);return{key:t,first:null,c(){var h;n=M("div"),i=M("p"),l=B(o),r=I(),c=M("p"),a=B(s),f=I(),p&&p.c(),u=X(),y(i,"class","arabic svelte-1g2o8u6"),y(c,"class","translation svelte-1g2o8u6"),V(c,"unanswered",!e[22].answered),y(n,"class","item svelte-1g2o8u6"),y(n,"tabindex","0"),y(n,"role","button"),y(n,"aria-disabled",d=!e[22].answered),V(n,"active",e[22].id===((h=e[0])==null?void 0:h.id)),this.first=n},m(h,_){A(h,n,_),k(n,i),k(i,l),k(n,r),k(n,c),k(c,a),A(h,f,_),p&&p.m(h,_),A(h,u,_)},p(h,_){var m;e=h,_&4&&o!==(o=e[22].arabic+"")&&H(l,o),_&4&&s!==(s=e[22].translation+"")&&H(a,s),_&4&&V(c,"unanswered",!e[22].answered),_&4&&d!==(d=!e[22].answered)&&y(n,"aria-disabled",d),_&5&&V(n,"active",e[22].id===((m=e[0])==null?void 0:m.id)),e[22].isSeparator?p?p.p(e,_):(p=Ht(e),p.c(),p.m(u.parentNode,u)):p&&(p.d(1),p=null)},d(h){h&&O(n),h&&O(f),p&&p.d(h),h&&O(u)}}}function zt(t){let e,n=[...Array(t[3]).keys()].map(Kt),i=[];for(let o=0;o<n.length;o+=1)i[o]=Vt(Rt(t,n,o));return{c(){e=M("div");for(let o=0;o<i.length;o+=1)i[o].c();y(e,"class","footer svelte-1g2o8u6")},m(o,l){A(o,e,l);for(let r=0;r<i.length;r+=1)i[r].m(e,null)},p(o,l){if(l&280){n=[...Array(o[3]).keys()].map(Kt);let r;for(r=0;r<n.length;r+=1){let c=Rt(o,n,r);i[r]?i[r].p(c,l):(i[r]=Vt(c),i[r].c(),i[r].m(e,null))}for(;r<i.length;r+=1)i[r].d(1);i.length=n.length}},d(o){o&&O(e),ue(i,o)}}}function Vt(t){let e,n=t[19]+"",i,o,l,r;function c(){return t[15](t[19])}return{c(){e=M("button"),i=B(n),o=I(),y(e,"class","svelte-1g2o8u6"),V(e,"active",t[19]===t[4])},m(s,a){A(s,e,a),k(e,i),k(e,o),l||(r=z(e,"click",c),l=!0)},p(s,a){t=s,a&8&&n!==(n=t[19]+"")&&H(i,n),a&24&&V(e,"active",t[19]===t[4])},d(s){s&&O(e),l=!1,r()}}}function Yt(t){let e,n;return e=new re({props:{class:"surah-loading"}}),{c(){L(e.$$.fragment)},m(i,o){N(e,i,o),n=!0},i(i){n||(g(e.$$.fragment,i),n=!0)},o(i){w(e.$$.fragment,i),n=!1},d(i){j(e,i)}}}function vi(t){let e,n,i=[],o=new Map,l,r,c,s,a,d,f=t[2],u=_=>_[22].id;for(let _=0;_<f.length;_+=1){let m=$t(t,f,_),F=u(m);o.set(F,i[_]=Wt(F,m))}let p=t[3]>1&&zt(t),h=t[5]&&Yt(t);return{c(){e=M("div"),n=M("div");for(let _=0;_<i.length;_+=1)i[_].c();l=I(),p&&p.c(),r=I(),h&&h.c(),y(n,"class","container svelte-1g2o8u6"),y(n,"data-scrollbar",""),y(n,"tabindex","0"),y(e,"class",c="root "+t[1]+" svelte-1g2o8u6")},m(_,m){A(_,e,m),k(e,n);for(let F=0;F<i.length;F+=1)i[F].m(n,null);t[14](n),k(e,l),p&&p.m(e,null),k(e,r),h&&h.m(e,null),s=!0,a||(d=z(n,"keydown",t[7]),a=!0)},p(_,[m]){m&517&&(f=_[2],i=Pe(i,m,u,1,_,f,o,n,qe,Wt,null,$t)),_[3]>1?p?p.p(_,m):(p=zt(_),p.c(),p.m(e,r)):p&&(p.d(1),p=null),_[5]?h?m&32&&g(h,1):(h=Yt(_),h.c(),g(h,1),h.m(e,null)):h&&(R(),w(h,1,1,()=>{h=null}),$()),(!s||m&2&&c!==(c="root "+_[1]+" svelte-1g2o8u6"))&&y(e,"class",c)},i(_){s||(g(h),s=!0)},o(_){w(h),s=!1},d(_){_&&O(e);for(let m=0;m<i.length;m+=1)i[m].d();t[14](null),p&&p.d(),h&&h.d(),a=!1,d()}}}var Fi="\u0660\u0661\u0662\u0663\u0664\u0665\u0666\u0667\u0668\u0669";function xt(t){let e="";for(let n of t.toString()){let i=parseInt(n,10);isNaN(i)||(e+=Fi[i])}return e}var Kt=t=>t+1;function Si(t,e,n){let i=J(),{class:o=""}=e,{surah:l}=e,{activeWord:r=void 0}=e,c=[],s,a,d=!1,f=!1,u,v;async function p(){n(2,c=[]),n(5,f=!0);try{let b=`/api/words/surah/${l==null?void 0:l.id}/page/${a||0}`,D=await _e(b);n(2,c=D.words),n(3,s=D.maxPage),n(4,a=D.currentPage),n(12,d=D.disabled),await Me(),h()}catch(b){i("error",String(b))}n(5,f=!1)}function h(){let b=u.querySelector(".item.active"),D=b==null?0:b.offsetTop;n(6,u.scrollTop=D-36,u)}function _(b){b.key==="Home"&&!b.ctrlKey?(b.preventDefault(),b.stopPropagation(),h()):b.key==="-"&&a>1?m(a-1):b.key==="+"&&a<s&&m(a+1)}function m(b){n(4,a=b),p()}function F(b){i("ayahclick",{surah:l==null?void 0:l.id,ayah:b})}function E(b){if(b==null)return;let D=c.findIndex(P=>P.id===b.id);D>=0&&n(2,c[D].answered=!0,c);let T=b.id+1;!(c.findIndex(P=>P.id===T)>=0)&&a<s?m(a+1):h()}function U(b){if(c.length===0||f)return;let D=JSON.parse(b.data),T=c[0].id,P=c[c.length-1].id;if(D.lastWord<T-1||D.lastWord>P)return;let G=T-1;for(let Q of c)Q.answered&&Q.isSeparator&&(G=Q.id);D.lastWord!==G&&p()}oe(()=>{p(),v=new EventSource(Bp("/api/v1/progress/events")),v.addEventListener("progress",U)}),Vp(()=>v==null?void 0:v.close());let C=b=>F(b.ayah);function q(b){te[b?"unshift":"push"](()=>{u=b,n(6,u)})}let S=b=>m(b);return t.$$set=b=>{"class"in b&&n(1,o=b.class),"surah"in b&&n(10,l=b.surah),"activeWord"in b&&n(0,r=b.activeWord)},t.$$.update=()=>{if(t.$$.dirty&4100){e:d?n(0,r=void 0):n(0,r=c.find(b=>!b.answered))}},[r,o,c,s,a,f,u,_,m,F,l,E,d,C,q,S]}var Gt=class extends Y{constructor(e){super();K(this,e,Si,vi,x,{class:1,surah:10,activeWord:0,markAnswered:11})}get markAnswered(){return this.$$.ctx[11]}},Ut=Gt;function Jt(t,e,n){let i=t.slice();return i[7]=e[n],i}function Qt(t){let e,n=t[7].text+"",i,o,l,r;function c(){return t[5](t[7])}return{c(){e=M("button"),i=B(n),o=I(),y(e,"class","svelte-1lw8tih"),V(e,"wrong",t[2].includes(t[7].text))},m(s,a){A(s,e,a),k(e,i),k(e,o),l||(r=z(e,"click",c),l=!0)},p(s,a){t=s,a&2&&n!==(n=t[7].text+"")&&H(i,n),a&6&&V(e,"wrong",t[2].includes(t[7].text))},d(s){s&&O(e),l=!1,r()}}}function Xt(t){let e,n;return e=new re({props:{class:"answer-loading"}}),{c(){L(e.$$.fragment)},m(i,o){N(e,i,o),n=!0},i(i){n||(g(e.$$.fragment,i),n=!0)},o(i){w(e.$$.fragment,i),n=!1},d(i){j(e,i)}}}function Mi(t){var p,h;let e,n,i=((p=t[1])==null?void 0:p.arabic)+"",o,l,r,c,s,a,d=((h=t[1])==null?void 0:h.choices)||[],f=[];for(let _=0;_<d.length;_+=1)f[_]=Qt(Jt(t,d,_));let u=t[3]&&Xt(t);return{c(){e=M("div"),n=M("p"),o=B(i),l=I(),r=M("div");for(let _=0;_<f.length;_+=1)f[_].c();c=I(),u&&u.c(),y(n,"class","arabic svelte-1lw8tih"),y(r,"class","container svelte-1lw8tih"),y(e,"class",s="root "+t[0]+" svelte-1lw8tih")},m(_,m){A(_,e,m),k(e,n),k(n,o),k(e,l),k(e,r);for(let F=0;F<f.length;F+=1)f[F].m(r,null);k(e,c),u&&u.m(e,null),a=!0},p(_,[m]){var F,E;if((!a||m&2)&&i!==(i=((F=_[1])==null?void 0:F.arabic)+"")&&H(o,i),m&22){d=((E=_[1])==null?void 0:E.choices)||[];let C;for(C=0;C<d.length;C+=1){let q=Jt(_,d,C);f[C]?f[C].p(q,m):(f[C]=Qt(q),f[C].c(),f[C].m(r,null))}for(;C<f.length;C+=1)f[C].d(1);f.length=d.length}_[3]?u?m&8&&g(u,1):(u=Xt(_),u.c(),g(u,1),u.m(e,null)):u&&(R(),w(u,1,1,()=>{u=null}),$()),(!a||m&1&&s!==(s="root "+_[0]+" svelte-1lw8tih"))&&y(e,"class",s)},i(_){a||(g(u),a=!0)},o(_){w(u),a=!1},d(_){_&&O(e),ue(f,_),u&&u.d()}}}function Ei(t,e,n){let i=J(),{class:o=""}=e,{word:l}=e,r=[],c=!1;async function s(d,f){if(!(f==null||c)){if(!d.isCorrect){h(f,!1),n(2,r=[...r,d.text]),r.length>=5&&n(2,r=[]);return}if(!f.isSeparator)h(f,!0);else{n(3,c=!0);let u=!1;try{await jt("/api/track",f)}catch(p){console.error(p),u=!0}if(n(3,c=!1),u)return}i("answered")}}function h(d,f){jt(`/api/v1/words/${d.id}/attempts`,{correct:f}).catch(u=>console.error(u))}let a=d=>s(d,l);return t.$$set=d=>{"class"in d&&n(0,o=d.class),"word"in d&&n(1,l=d.word)},t.$$.update=()=>{if(t.$$.dirty&2){e:document.activeElement.blur()}},[o,l,r,c,s,a]}var Zt=class extends Y{constructor(e){super();K(this,e,Ei,Mi,x,{class:0,word:1})}},en=Zt;var Oe=ti(bn());function yn(t,e,n){let i=t.slice();return i[22]=e[n],i}function kn(t,e,n){let i=t.slice();return i[22]=e[n],i}function wn(t){let e,n,i,o,l,r=[wo,ko],c=[];function s(a,d){return d&128&&(e=null),e==null&&(e=!Array.isArray(a[7])),e?0:1}return n=s(t,-1),i=c[n]=r[n](t),{c(){i.c(),o=X()},m(a,d){c[n].m(a,d),A(a,o,d),l=!0},p(a,d){let f=n;n=s(a,d),n===f?c[n].p(a,d):(R(),w(c[f],1,1,()=>{c[f]=null}),$(),i=c[n],i?i.p(a,d):(i=c[n]=r[n](a),i.c()),g(i,1),i.m(o.parentNode,o))},i(a){l||(g(i),l=!0)},o(a){w(i),l=!1},d(a){c[n].d(a),a&&O(o)}}}function ko(t){let e,n,i=t[7],o=[];for(let r=0;r<i.length;r+=1)o[r]=vn(kn(t,i,r));let l=r=>w(o[r],1,1,()=>{o[r]=null});return{c(){for(let r=0;r<o.length;r+=1)o[r].c();e=X()},m(r,c){for(let s=0;s<o.length;s+=1)o[s].m(r,c);A(r,e,c),n=!0},p(r,c){if(c&128){i=r[7];let s;for(s=0;s<i.length;s+=1){let a=kn(r,i,s);o[s]?(o[s].p(a,c),g(o[s],1)):(o[s]=vn(a),o[s].c(),g(o[s],1),o[s].m(e.parentNode,e))}for(R(),s=i.length;s<o.length;s+=1)l(s);$()}},i(r){if(!n){for(let c=0;c<i.length;c+=1)g(o[c]);n=!0}},o(r){o=o.filter(Boolean);for(let c=0;c<o.length;c+=1)w(o[c]);n=!1},d(r){ue(o,r),r&&O(e)}}}function wo(t){let e,n;return e=new Oe.default({props:{icon:t[7],height:18}}),{c(){L(e.$$.fragment)},m(i,o){N(e,i,o),n=!0},p(i,o){let l={};o&128&&(l.icon=i[7]),e.$set(l)},i(i){n||(g(e.$$.fragment,i),n=!0)},o(i){w(e.$$.fragment,i),n=!1},d(i){j(e,i)}}}function vn(t){let e,n;return e=new Oe.default({props:{icon:t[22],height:18}}),{c(){L(e.$$.fragment)},m(i,o){N(e,i,o),n=!0},p(i,o){let l={};o&128&&(l.icon=i[22]),e.$set(l)},i(i){n||(g(e.$$.fragment,i),n=!0)},o(i){w(e.$$.fragment,i),n=!1},d(i){j(e,i)}}}function Fn(t){let e,n;return{c(){e=M("span"),n=B(t[2]),y(e,"class","text svelte-1aukgre")},m(i,o){A(i,e,o),k(e,n)},p(i,o){o&4&&H(n,i[2])},d(i){i&&O(e)}}}function Sn(t){let e,n,i,o,l,r=[Fo,vo],c=[];function s(a,d){return d&256&&(e=null),e==null&&(e=!Array.isArray(a[8])),e?0:1}return n=s(t,-1),i=c[n]=r[n](t),{c(){i.c(),o=X()},m(a,d){c[n].m(a,d),A(a,o,d),l=!0},p(a,d){let f=n;n=s(a,d),n===f?c[n].p(a,d):(R(),w(c[f],1,1,()=>{c[f]=null}),$(),i=c[n],i?i.p(a,d):(i=c[n]=r[n](a),i.c()),g(i,1),i.m(o.parentNode,o))},i(a){l||(g(i),l=!0)},o(a){w(i),l=!1},d(a){c[n].d(a),a&&O(o)}}}function vo(t){let e,n,i=t[8],o=[];for(let r=0;r<i.length;r+=1)o[r]=Mn(yn(t,i,r));let l=r=>w(o[r],1,1,()=>{o[r]=null});return{c(){for(let r=0;r<o.length;r+=1)o[r].c();e=X()},m(r,c){for(let s=0;s<o.length;s+=1)o[s].m(r,c);A(r,e,c),n=!0},p(r,c){if(c&256){i=r[8];let s;for(s=0;s<i.length;s+=1){let a=yn(r,i,s);o[s]?(o[s].p(a,c),g(o[s],1)):(o[s]=Mn(a),o[s].c(),g(o[s],1),o[s].m(e.parentNode,e))}for(R(),s=i.length;s<o.length;s+=1)l(s);$()}},i(r){if(!n){for(let c=0;c<i.length;c+=1)g(o[c]);n=!0}},o(r){o=o.filter(Boolean);for(let c=0;c<o.length;c+=1)w(o[c]);n=!1},d(r){ue(o,r),r&&O(e)}}}function Fo(t){let e,n;return e=new Oe.default({props:{icon:t[8],height:18}}),{c(){L(e.$$.fragment)},m(i,o){N(e,i,o),n=!0},p(i,o){let l={};o&256&&(l.icon=i[8]),e.$set(l)},i(i){n||(g(e.$$.fragment,i),n=!0)},o(i){w(e.$$.fragment,i),n=!1},d(i){j(e,i)}}}function Mn(t){let e,n;return e=new Oe.default({props:{icon:t[22],height:18}}),{c(){L(e.$$.fragment)},m(i,o){N(e,i,o),n=!0},p(i,o){let l={};o&256&&(l.icon=i[22]),e.$set(l)},i(i){n||(g(e.$$.fragment,i),n=!0)},o(i){w(e.$$.fragment,i),n=!1},d(i){j(e,i)}}}function So(t){let e,n,i,o,l=t[7]!=null&&wn(t),r=t[2]!==""&&Fn(t),c=t[8]!=null&&Sn(t);return{c(){l&&l.c(),e=I(),r&&r.c(),n=I(),c&&c.c(),i=X()},m(s,a){l&&l.m(s,a),A(s,e,a),r&&r.m(s,a),A(s,n,a),c&&c.m(s,a),A(s,i,a),o=!0},p(s,a){s[7]!=null?l?(l.p(s,a),a&128&&g(l,1)):(l=wn(s),l.c(),g(l,1),l.m(e.parentNode,e)):l&&(R(),w(l,1,1,()=>{l=null}),$()),s[2]!==""?r?r.p(s,a):(r=Fn(s),r.c(),r.m(n.parentNode,n)):r&&(r.d(1),r=null),s[8]!=null?c?(c.p(s,a),a&256&&g(c,1)):(c=Sn(s),c.c(),g(c,1),c.m(i.parentNode,i)):c&&(R(),w(c,1,1,()=>{c=null}),$())},i(s){o||(g(l),g(c),o=!0)},o(s){w(l),w(c),o=!1},d(s){l&&l.d(s),s&&O(e),r&&r.d(s),s&&O(n),c&&c.d(s),s&&O(i)}}}function En(t){let e,n;return{c(){e=M("span"),n=B(t[6]),y(e,"class","tooltip svelte-1aukgre")},m(i,o){A(i,e,o),k(e,n),t[19](e)},p(i,o){o&64&&H(n,i[6])},d(i){i&&O(e),t[19](null)}}}function Mo(t){let e,n,i,o,l,r,c=t[15].default,s=we(c,t,t[14],null),a=s||So(t),d=t[6]!==""&&En(t);return{c(){e=M("button"),a&&a.c(),n=I(),d&&d.c(),y(e,"id",t[1]),y(e,"style",t[3]),e.disabled=t[5],y(e,"class",i=Qe(t[0])+" svelte-1aukgre"),V(e,"active",t[4])},m(f,u){A(f,e,u),a&&a.m(e,null),k(e,n),d&&d.m(e,null),t[20](e),o=!0,l||(r=[z(e,"click",t[16]),z(e,"dblclick",t[17]),z(e,"keydown",t[18]),z(e,"mouseenter",t[11]),z(e,"mouseleave",t[12])],l=!0)},p(f,[u]){s?s.p&&(!o||u&16384)&&Fe(s,c,f,f[14],o?ve(c,f[14],u,null):Se(f[14]),null):a&&a.p&&(!o||u&388)&&a.p(f,o?u:-1),f[6]!==""?d?d.p(f,u):(d=En(f),d.c(),d.m(e,null)):d&&(d.d(1),d=null),(!o||u&2)&&y(e,"id",f[1]),(!o||u&8)&&y(e,"style",f[3]),(!o||u&32)&&(e.disabled=f[5]),(!o||u&1&&i!==(i=Qe(f[0])+" svelte-1aukgre"))&&y(e,"class",i),u&17&&V(e,"active",f[4])},i(f){o||(g(a,f),o=!0)},o(f){w(a,f),o=!1},d(f){f&&O(e),a&&a.d(f),d&&d.d(),t[20](null),l=!1,ee(r)}}}function Eo(t,e,n){let{$$slots:i={},$$scope:o}=e,{class:l=""}=e,{id:r=""}=e,{text:c=""}=e,{style:s=""}=e,{active:a=!1}=e,{disabled:d=!1}=e,{tooltip:f=""}=e,{tooltipPlacement:u="right"}=e,{icon:p=void 0}=e,{rightIcon:h=void 0}=e,_,m;function F(v){if(m==null)return[0,0];let P=window.innerWidth,W=window.innerHeight,Ae=_.getBoundingClientRect(),he=Ae.y,me=Ae.x,Ve=Ae.width,Ye=Ae.height,dt=me+Ve/2,_t=he+Ye/2,ce=m.offsetWidth,ae=m.offsetHeight,Q=8,G=0,U=0;switch(v){case"top":G=he-Q-ae,U=dt-ce/2,G<0&&(G=he+Ye+Q);break;case"left":G=_t-ae/2,U=me-Q-ce,U<0&&(U=me+Ve+Q);break;case"right":G=_t-ae/2,U=me+Ve+Q,U+ce>P&&(U=me-Q-ce);break;case"bottom":G=he+Ye+Q,U=dt-ce/2,G+ae>W&&(G=he-Q-ae);break}return v==="top"||v==="bottom"?U<0?U=Q:U+ce>P&&(U=P-ce-Q):(v==="left"||v==="right")&&(G<0?G=Q:G+ae>W&&(G=W-ae-Q)),[U,G]}function E(){if(m==null)return;let[v,P]=F(u);n(10,m.style.top=`${P}px`,m),n(10,m.style.left=`${v}px`,m),n(10,m.style.opacity="1",m)}function C(){m!=null&&m.removeAttribute("style")}function q(v){le.call(this,t,v)}function S(v){le.call(this,t,v)}function b(v){le.call(this,t,v)}function D(v){te[v?"unshift":"push"](()=>{m=v,n(10,m)})}function T(v){te[v?"unshift":"push"](()=>{_=v,n(9,_)})}return t.$$set=v=>{"class"in v&&n(0,l=v.class),"id"in v&&n(1,r=v.id),"text"in v&&n(2,c=v.text),"style"in v&&n(3,s=v.style),"active"in v&&n(4,a=v.active),"disabled"in v&&n(5,d=v.disabled),"tooltip"in v&&n(6,f=v.tooltip),"tooltipPlacement"in v&&n(13,u=v.tooltipPlacement),"icon"in v&&n(7,p=v.icon),"rightIcon"in v&&n(8,h=v.rightIcon),"$$scope"in v&&n(14,o=v.$$scope)},[l,r,c,s,a,d,f,p,h,_,m,E,C,u,o,i,q,S,b,D,T]}var Cn=class extends Y{constructor(e){super();K(this,e,Eo,Mo,x,{class:0,id:1,text:2,style:3,active:4,disabled:5,tooltip:6,tooltipPlacement:13,icon:7,rightIcon:8})}},se=Cn;var Co={body:'<path fill="currentColor" d="M20 11H7.83l5.59-5.59L12 4l-8 8l8 8l1.41-1.41L7.83 13H20v-2z"/>',width:24,height:24},On=Co;var Oo={body:'<path fill="currentColor" d="m6.76 4.84l-1.8-1.79l-1.41 1.41l1.79 1.79zM1 10.5h3v2H1zM11 .55h2V3.5h-2zm8.04 2.495l1.408 1.407l-1.79 1.79l-1.407-1.408zm-1.8 15.115l1.79 1.8l1.41-1.41l-1.8-1.79zM20 10.5h3v2h-3zm-8-5c-3.31 0-6 2.69-6 6s2.69 6 6 6s6-2.69 6-6s-2.69-6-6-6zm0 10c-2.21 0-4-1.79-4-4s1.79-4 4-4s4 1.79 4 4s-1.79 4-4 4zm-1 4h2v2.95h-2zm-7.45-.96l1.41 1.41l1.79-1.8l-1.41-1.41z"/>',width:24,height:24},An=Oo;var Ao={body:'<path fill="currentColor" d="M17.65 6.35A7.958 7.958 0 0 0 12 4c-4.42 0-7.99 3.58-7.99 8s3.57 8 7.99 8c3.73 0 6.84-2.55 7.73-6h-2.08A5.99 5.99 0 0 1 12 18c-3.31 0-6-2.69-6-6s2.69-6 6-6c1.66 0 3.14.69 4.22 1.78L13 11h7V4l-2.35 2.35z"/>',width:24,height:24},In=Ao;function Tn(t){let e,n;return e=new se({props:{icon:On}}),e.$on("click",t[3]),{c(){L(e.$$.fragment)},m(i,o){N(e,i,o),n=!0},p:Z,i(i){n||(g(e.$$.fragment,i),n=!0)},o(i){w(e.$$.fragment,i),n=!1},d(i){j(e,i)}}}function Io(t){let e,n,i,o,l,r,c,s,a,d,f=t[2]&&Tn(t);return r=new se({props:{icon:In}}),r.$on("click",To),s=new se({props:{icon:An}}),s.$on("click",Do),{c(){e=M("div"),f&&f.c(),n=I(),i=M("p"),o=B(t[1]),l=I(),L(r.$$.fragment),c=I(),L(s.$$.fragment),y(i,"class","svelte-1wil3la"),y(e,"class",a="header "+t[0]+" svelte-1wil3la")},m(u,p){A(u,e,p),f&&f.m(e,null),k(e,n),k(e,i),k(i,o),k(e,l),N(r,e,null),k(e,c),N(s,e,null),d=!0},p(u,[p]){u[2]?f?(f.p(u,p),p&4&&g(f,1)):(f=Tn(u),f.c(),g(f,1),f.m(e,n)):f&&(R(),w(f,1,1,()=>{f=null}),$()),(!d||p&2)&&H(o,u[1]),(!d||p&1&&a!==(a="header "+u[0]+" svelte-1wil3la"))&&y(e,"class",a)},i(u){d||(g(f),g(r.$$.fragment,u),g(s.$$.fragment,u),d=!0)},o(u){w(f),w(r.$$.fragment,u),w(s.$$.fragment,u),d=!1},d(u){u&&O(e),f&&f.d(),j(r),j(s)}}}function To(){window.location.reload()}function Do(){let t=document.documentElement;localStorage.getItem("dark-mode")==="1"?(localStorage.removeItem("dark-mode"),t.classList.remove("dark")):(localStorage.setItem("dark-mode","1"),t.classList.add("dark"))}function jo(t,e,n){let i=J(),{class:o=""}=e,{title:l=""}=e,{backVisible:r=!1}=e;function c(){i("back")}return t.$$set=s=>{"class"in s&&n(0,o=s.class),"title"in s&&n(1,l=s.title),"backVisible"in s&&n(2,r=s.backVisible)},[o,l,r,c]}var Dn=class extends Y{constructor(e){super();K(this,e,jo,Io,x,{class:0,title:1,backVisible:2})}},jn=Dn;var No={body:'<path fill="currentColor" d="M19 6.41L17.59 5L12 10.59L6.41 5L5 6.41L10.59 12L5 17.59L6.41 19L12 13.41L17.59 19L19 17.59L13.41 12L19 6.41z"/>',width:24,height:24},Nn=No;function ut(t){return--t*t*t*t*t+1}function ft(t,{delay:e=0,duration:n=400,easing:i=Le}={}){let o=+getComputedStyle(t).opacity;return{delay:e,duration:n,easing:i,css:l=>`opacity: ${l*o}`}}var Lo=t=>({}),Ln=t=>({}),qo=t=>({}),qn=t=>({});function Pn(t){let e,n;return e=new se({props:{icon:Nn,disabled:t[5]}}),e.$on("click",t[9]),{c(){L(e.$$.fragment)},m(i,o){N(e,i,o),n=!0},p(i,o){let l={};o&32&&(l.disabled=i[5]),e.$set(l)},i(i){n||(g(e.$$.fragment,i),n=!0)},o(i){w(e.$$.fragment,i),n=!1},d(i){j(e,i)}}}function Po(t){let e;return{c(){e=M("p"),e.textContent="Dialog is empty"},m(n,i){A(n,e,i)},d(n){n&&O(e)}}}function Bn(t){let e,n;return e=new re({props:{class:"loading-cover"}}),{c(){L(e.$$.fragment)},m(i,o){N(e,i,o),n=!0},i(i){n||(g(e.$$.fragment,i),n=!0)},o(i){w(e.$$.fragment,i),n=!1},d(i){j(e,i)}}}function Rn(t){let e,n;return e=new se({props:{text:t[7],disabled:t[5]}}),e.$on("click",t[11]),{c(){L(e.$$.fragment)},m(i,o){N(e,i,o),n=!0},p(i,o){let l={};o&128&&(l.text=i[7]),o&32&&(l.disabled=i[5]),e.$set(l)},i(i){n||(g(e.$$.fragment,i),n=!0)},o(i){w(e.$$.fragment,i),n=!1},d(i){j(e,i)}}}function Bo(t){let e,n,i,o;e=new se({props:{text:t[6],disabled:t[5]}}),e.$on("click",t[10]);let l=t[7]&&Rn(t);return{c(){L(e.$$.fragment),n=I(),l&&l.c(),i=X()},m(r,c){N(e,r,c),A(r,n,c),l&&l.m(r,c),A(r,i,c),o=!0},p(r,c){let s={};c&64&&(s.text=r[6]),c&32&&(s.disabled=r[5]),e.$set(s),r[7]?l?(l.p(r,c),c&128&&g(l,1)):(l=Rn(r),l.c(),g(l,1),l.m(i.parentNode,i)):l&&(R(),w(l,1,1,()=>{l=null}),$())},i(r){o||(g(e.$$.fragment,r),g(l),o=!0)},o(r){w(e.$$.fragment,r),w(l),o=!1},d(r){j(e,r),r&&O(n),l&&l.d(r),r&&O(i)}}}function Ro(t){let e,n,i,o,l,r,c,s,a,d,f,u,p,h,_,m,F,E=t[3]&&Pn(t),C=t[15].content,q=we(C,t,t[14],qn),S=q||Po(t),b=t[5]&&Bn(t),D=t[15].footer,T=we(D,t,t[14],Ln),v=T||Bo(t);return{c(){e=M("div"),n=M("div"),i=M("div"),o=M("p"),l=B(t[1]),r=I(),E&&E.c(),c=I(),s=M("div"),S&&S.c(),a=I(),b&&b.c(),d=I(),f=M("div"),v&&v.c(),y(o,"class","title svelte-1jsr7ie"),y(i,"class","header svelte-1jsr7ie"),y(s,"class","content svelte-1jsr7ie"),y(s,"data-scrollbar",""),y(s,"tabindex","-1"),y(f,"class","footer svelte-1jsr7ie"),y(n,"style",t[2]),y(n,"class",u="dialog "+t[0]+" svelte-1jsr7ie"),V(n,"error",t[4]),y(e,"class","dialog-overlay svelte-1jsr7ie"),y(e,"tabindex","-1")},m(P,W){A(P,e,W),k(e,n),k(n,i),k(i,o),k(o,l),k(i,r),E&&E.m(i,null),k(n,c),k(n,s),S&&S.m(s,null),k(s,a),b&&b.m(s,null),k(n,d),k(n,f),v&&v.m(f,null),_=!0,m||(F=[kt(p=t[8].call(null,n)),z(e,"keydown",t[12]),z(e,"keydown",t[16],!0)],m=!0)},p(P,[W]){t=P,(!_||W&2)&&H(l,t[1]),t[3]?E?(E.p(t,W),W&8&&g(E,1)):(E=Pn(t),E.c(),g(E,1),E.m(i,null)):E&&(R(),w(E,1,1,()=>{E=null}),$()),q&&q.p&&(!_||W&16384)&&Fe(q,C,t,t[14],_?ve(C,t[14],W,qo):Se(t[14]),qn),t[5]?b?W&32&&g(b,1):(b=Bn(t),b.c(),g(b,1),b.m(s,null)):b&&(R(),w(b,1,1,()=>{b=null}),$()),T?T.p&&(!_||W&16384)&&Fe(T,D,t,t[14],_?ve(D,t[14],W,Lo):Se(t[14]),Ln):v&&v.p&&(!_||W&224)&&v.p(t,_?W:-1),(!_||W&4)&&y(n,"style",t[2]),(!_||W&1&&u!==(u="dialog "+t[0]+" svelte-1jsr7ie"))&&y(n,"class",u),W&17&&V(n,"error",t[4])},i(P){_||(g(E),g(S,P),g(b),g(v,P),de(()=>{h||(h=Ze(e,ft,{easing:ut},!0)),h.run(1)}),_=!0)},o(P){w(E),w(S,P),w(b),w(v,P),h||(h=Ze(e,ft,{easing:ut},!1)),h.run(0),_=!1},d(P){P&&O(e),E&&E.d(),S&&S.d(P),b&&b.d(),v&&v.d(P),P&&h&&h.end(),m=!1,ee(F)}}}function $o(t,e,n){let{$$slots:i={},$$scope:o}=e,l=J(),{class:r=""}=e,{title:c}=e,{style:s=""}=e,{closable:a=!0}=e,{isError:d=!1}=e,{loading:f=!1}=e,{mainButtonText:u="OK"}=e,{secondaryButtonText:p=void 0}=e,{defaultFocusTargets:h=[]}=e;function _(S){if(Array.isArray(h))for(let T=0;T<h.length;T++){let v=h[T],P=S.querySelector(v);if(P){P.focus();return}}let b=[":scope .content button:enabled",":scope .content input:read-write",":scope .content textarea:read-write",":scope .content label.checkbox-input",":scope .footer button:enabled",":scope .footer input:read-write",":scope .footer textarea:read-write"].join(", "),D=null;D=S.querySelector(b),D!=null?D.focus():S.focus()}function m(){l("close")}function F(){l("mainclick")}function E(){l("secondaryclick")}function C(S){a&&(S.key==="Esc"||S.key==="Escape")&&(S.preventDefault(),S.stopPropagation(),l("close"))}function q(S){le.call(this,t,S)}return t.$$set=S=>{"class"in S&&n(0,r=S.class),"title"in S&&n(1,c=S.title),"style"in S&&n(2,s=S.style),"closable"in S&&n(3,a=S.closable),"isError"in S&&n(4,d=S.isError),"loading"in S&&n(5,f=S.loading),"mainButtonText"in S&&n(6,u=S.mainButtonText),"secondaryButtonText"in S&&n(7,p=S.secondaryButtonText),"defaultFocusTargets"in S&&n(13,h=S.defaultFocusTargets),"$$scope"in S&&n(14,o=S.$$scope)},[r,c,s,a,d,f,u,p,_,m,F,E,C,h,o,i,q]}var $n=class extends Y{constructor(e){super();K(this,e,$o,Ro,x,{class:0,title:1,style:2,closable:3,isError:4,loading:5,mainButtonText:6,secondaryButtonText:7,defaultFocusTargets:13})}},ze=$n;function Ho(t){var f,u,p;let e,n,i=(((f=t[1])==null?void 0:f.arabic)||"")+"",o,l,r,c=(((u=t[1])==null?void 0:u.translation)||"")+"",s,a,d=(((p=t[1])==null?void 0:p.tafsir)||"")+"";return{c(){e=M("div"),n=M("p"),o=B(i),l=I(),r=M("div"),s=I(),a=M("div"),y(n,"class","arabic svelte-1vgcngs"),y(r,"class","trans svelte-1vgcngs"),y(a,"class","trans svelte-1vgcngs"),y(e,"slot","content"),y(e,"class","tafsir-content svelte-1vgcngs")},m(h,_){A(h,e,_),k(e,n),k(n,o),k(e,l),k(e,r),r.innerHTML=c,k(e,s),k(e,a),a.innerHTML=d},p(h,_){var m,F,E;_&2&&i!==(i=(((m=h[1])==null?void 0:m.arabic)||"")+"")&&H(o,i),_&2&&c!==(c=(((F=h[1])==null?void 0:F.translation)||"")+"")&&(r.innerHTML=c),_&2&&d!==(d=(((E=h[1])==null?void 0:E.tafsir)||"")+"")&&(a.innerHTML=d)},d(h){h&&O(e)}}}function Wo(t){let e,n;return e=new ze({props:{title:t[0],loading:t[2],class:"dialog-translation",$$slots:{content:[Ho]},$$scope:{ctx:t}}}),e.$on("close",t[5]),e.$on("mainclick",t[6]),{c(){L(e.$$.fragment)},m(i,o){N(e,i,o),n=!0},p(i,[o]){let l={};o&1&&(l.title=i[0]),o&4&&(l.loading=i[2]),o&514&&(l.$$scope={dirty:o,ctx:i}),e.$set(l)},i(i){n||(g(e.$$.fragment,i),n=!0)},o(i){w(e.$$.fragment,i),n=!1},d(i){j(e,i)}}}function zo(t,e,n){let i=J(),{title:o="Tafsir dan Terjemah"}=e,{ayah:l}=e,{surah:r}=e,c,s=!1;async function a(){n(2,s=!0);try{n(1,c=await _e(`/api/tafsir/surah/${r}/ayah/${l}`))}catch(u){i("error",String(u))}n(2,s=!1)}oe(()=>a());function d(u){le.call(this,t,u)}function f(u){le.call(this,t,u)}return t.$$set=u=>{"title"in u&&n(0,o=u.title),"ayah"in u&&n(3,l=u.ayah),"surah"in u&&n(4,r=u.surah)},[o,c,s,l,r,d,f]}var Hn=class extends Y{constructor(e){super();K(this,e,zo,Wo,x,{title:0,ayah:3,surah:4})}},Wn=Hn;function Vo(t){let e,n,i,o,l;function r(a){t[17](a)}let c={class:"surah",surah:t[0]};t[2]!==void 0&&(c.activeWord=t[2]),e=new Ut({props:c}),t[16](e),te.push(()=>Tt(e,"activeWord",r)),e.$on("ayahclick",t[10]),e.$on("error",t[13]);let s=t[2]!=null&&zn(t);return{c(){L(e.$$.fragment),i=I(),s&&s.c(),o=X()},m(a,d){N(e,a,d),A(a,i,d),s&&s.m(a,d),A(a,o,d),l=!0},p(a,d){let f={};d&1&&(f.surah=a[0]),!n&&d&4&&(n=!0,f.activeWord=a[2],At(()=>n=!1)),e.$set(f),a[2]!=null?s?(s.p(a,d),d&4&&g(s,1)):(s=zn(a),s.c(),g(s,1),s.m(o.parentNode,o)):s&&(R(),w(s,1,1,()=>{s=null}),$())},i(a){l||(g(e.$$.fragment,a),g(s),l=!0)},o(a){w(e.$$.fragment,a),w(s),l=!1},d(a){t[16](null),j(e,a),a&&O(i),s&&s.d(a),a&&O(o)}}}function Yo(t){let e,n;return e=new Bt({props:{class:"list-surah",active:t[0]}}),e.$on("itemclick",t[9]),e.$on("error",t[13]),{c(){L(e.$$.fragment)},m(i,o){N(e,i,o),n=!0},p(i,o){let l={};o&1&&(l.active=i[0]),e.$set(l)},i(i){n||(g(e.$$.fragment,i),n=!0)},o(i){w(e.$$.fragment,i),n=!1},d(i){j(e,i)}}}function zn(t){let e,n;return e=new en({props:{class:"answer",word:t[2]}}),e.$on("answered",t[11]),e.$on("error",t[13]),{c(){L(e.$$.fragment)},m(i,o){N(e,i,o),n=!0},p(i,o){let l={};o&4&&(l.word=i[2]),e.$set(l)},i(i){n||(g(e.$$.fragment,i),n=!0)},o(i){w(e.$$.fragment,i),n=!1},d(i){j(e,i)}}}function Vn(t){let e,n;return e=new ze({props:{title:"Error",isError:!0,closable:!1,$$slots:{content:[xo]},$$scope:{ctx:t}}}),e.$on("mainclick",t[18]),{c(){L(e.$$.fragment)},m(i,o){N(e,i,o),n=!0},p(i,o){let l={};o&2097280&&(l.$$scope={dirty:o,ctx:i}),e.$set(l)},i(i){n||(g(e.$$.fragment,i),n=!0)},o(i){w(e.$$.fragment,i),n=!1},d(i){j(e,i)}}}function xo(t){let e,n;return{c(){e=M("p"),n=B(t[7]),y(e,"slot","content")},m(i,o){A(i,e,o),k(e,n)},p(i,o){o&128&&H(n,i[7])},d(i){i&&O(e)}}}function Yn(t){var i;let e,n;return e=new Wn({props:{ayah:t[3],title:t[4],surah:((i=t[0])==null?void 0:i.id)||1}}),e.$on("error",t[12]),e.$on("close",t[19]),e.$on("mainclick",t[20]),{c(){L(e.$$.fragment)},m(o,l){N(e,o,l),n=!0},p(o,l){var c;let r={};l&8&&(r.ayah=o[3]),l&16&&(r.title=o[4]),l&1&&(r.surah=((c=o[0])==null?void 0:c.id)||1),e.$set(r)},i(o){n||(g(e.$$.fragment,o),n=!0)},o(o){w(e.$$.fragment,o),n=!1},d(o){j(e,o)}}}function Ko(t){let e,n,i,o,l,r,c,s,a,d;n=new jn({props:{title:t[8],backVisible:t[0]!=null}}),n.$on("back",t[15]);let f=[Yo,Vo],u=[];function p(m,F){return m[0]==null?0:1}o=p(t,-1),l=u[o]=f[o](t);let h=t[6]&&Vn(t),_=t[5]&&Yn(t);return{c(){e=M("div"),L(n.$$.fragment),i=I(),l.c(),r=I(),h&&h.c(),c=I(),_&&_.c(),y(e,"class","app svelte-19ciix5")},m(m,F){A(m,e,F),N(n,e,null),k(e,i),u[o].m(e,null),k(e,r),h&&h.m(e,null),k(e,c),_&&_.m(e,null),s=!0,a||(d=z(window,"popstate",t[14]),a=!0)},p(m,[F]){let E={};F&256&&(E.title=m[8]),F&1&&(E.backVisible=m[0]!=null),n.$set(E);let C=o;o=p(m,F),o===C?u[o].p(m,F):(R(),w(u[C],1,1,()=>{u[C]=null}),$(),l=u[o],l?l.p(m,F):(l=u[o]=f[o](m),l.c()),g(l,1),l.m(e,r)),m[6]?h?(h.p(m,F),F&64&&g(h,1)):(h=Vn(m),h.c(),g(h,1),h.m(e,c)):h&&(R(),w(h,1,1,()=>{h=null}),$()),m[5]?_?(_.p(m,F),F&32&&g(_,1)):(_=Yn(m),_.c(),g(_,1),_.m(e,null)):_&&(R(),w(_,1,1,()=>{_=null}),$())},i(m){s||(g(n.$$.fragment,m),g(l),g(h),g(_),s=!0)},o(m){w(n.$$.fragment,m),w(l),w(h),w(_),s=!1},d(m){m&&O(e),j(n),u[o].d(),h&&h.d(),_&&_.d(),a=!1,d()}}}function Go(t,e,n){let i,o,l,r,c=0,s,a=!1,d=!1,f="";oe(()=>{history.pushState(null,document.title,location.href)});function u(T){n(2,r=void 0),n(0,l=T.detail.surah)}function p(T){n(3,c=T.detail.ayah),n(4,s=`${l==null?void 0:l.name} ${c}`),n(5,a=!0)}function h(){o==null||o.markAnswered(r)}function _(T){n(5,a=!1),n(6,d=!0),n(7,f=T.detail)}function m(T){n(6,d=!0),n(7,f=T.detail)}let F=()=>history.go(1),E=()=>{n(0,l=void 0)};function C(T){te[T?"unshift":"push"](()=>{o=T,n(1,o)})}function q(T){r=T,n(2,r)}let S=()=>n(6,d=!1),b=()=>n(5,a=!1),D=()=>n(5,a=!1);return t.$$.update=()=>{if(t.$$.dirty&1){e:n(8,i=l?l.name:"Daftar Surah")}},[l,o,r,c,s,a,d,f,i,u,p,h,_,m,F,E,C,q,S,b,D]}var xn=class extends Y{constructor(e){super();K(this,e,Go,Ko,x,{})}},Kn=xn;var Uo=new Kn({target:document.body}),Yr=Uo;})();
 /*! *****************************************************************************
 Copyright (c) Microsoft Corporation.
 
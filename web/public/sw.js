@@ -0,0 +1,270 @@
+// Service worker for Kalimah. It makes the app usable while offline by caching
+// the app shell and the fetched words, then queueing the tracked words in
+// IndexedDB until the connection is back. Queued words are sent to the batch
+// endpoint, which deduplicates them by client ID so replaying is always safe.
+
+const CACHE_VERSION = 'v1';
+const SHELL_CACHE = `kalimah-shell-${CACHE_VERSION}`;
+const DATA_CACHE = `kalimah-data-${CACHE_VERSION}`;
+const QUEUE_DB = 'kalimah-queue';
+const QUEUE_STORE = 'track';
+const SYNC_TAG = 'kalimah-track';
+
+// Queued submissions older than this are dropped instead of replayed, since the
+// server only remembers the processed submissions for a while.
+const QUEUE_MAX_AGE = 7 * 24 * 60 * 60 * 1000;
+
+const SHELL_FILES = [
+	'./',
+	'manifest.webmanifest',
+	'res/global.css',
+	'res/fonts/Inter-VariableFont_slnt,wght.ttf',
+	'res/fonts/KFGQPC-HAFS.woff2',
+	'res/favicon/favicon-16x16.png',
+	'res/favicon/favicon-32x32.png',
+	'res/favicon/icon-192x192.png',
+	'build/app.css',
+	'build/app.js',
+];
+
+const rxLegacyWords = /^\/api\/words\/surah\/(\d+)\/page\/(\d+)$/;
+const rxWords = /^\/api\/v1\/surahs\/(\d+)\/words$/;
+
+// Helper for URL within the service worker scope
+const scopePath = new URL(self.registration.scope).pathname.replace(/\/$/, '');
+
+function scopeURL(path) {
+	return new URL(path, self.registration.scope).href;
+}
+
+function relativePath(url) {
+	return url.pathname.startsWith(scopePath) ? url.pathname.slice(scopePath.length) : url.pathname;
+}
+
+// Lifecycle
+self.addEventListener('install', (e) => {
+	e.waitUntil(
+		caches
+			.open(SHELL_CACHE)
+			.then((cache) => cache.addAll(SHELL_FILES.map(scopeURL)))
+			.then(() => self.skipWaiting()),
+	);
+});
+
+self.addEventListener('activate', (e) => {
+	e.waitUntil(
+		caches
+			.keys()
+			.then((keys) => keys.filter((key) => key !== SHELL_CACHE && key !== DATA_CACHE))
+			.then((keys) => Promise.all(keys.map((key) => caches.delete(key))))
+			.then(() => self.clients.claim())
+			.then(() => replayQueue()),
+	);
+});
+
+self.addEventListener('sync', (e) => {
+	if (e.tag === SYNC_TAG) e.waitUntil(replayQueue());
+});
+
+self.addEventListener('message', (e) => {
+	if (e.data === 'replay') e.waitUntil(replayQueue());
+});
+
+// Request routing
+self.addEventListener('fetch', (e) => {
+	const request = e.request;
+	const url = new URL(request.url);
+	if (url.origin !== self.location.origin) return;
+
+	const path = relativePath(url);
+	const isTrack =
+		(request.method === 'POST' && path === '/api/track') ||
+		(request.method === 'PUT' && path === '/api/v1/progress');
+
+	if (isTrack) {
+		e.respondWith(trackOrQueue(request));
+	} else if (request.method !== 'GET') {
+		return;
+	} else if (rxLegacyWords.test(path) || rxWords.test(path)) {
+		e.respondWith(networkFirst(request).then((resp) => prefetchNextPage(url, path, resp)));
+	} else if (path === '/api/v1/progress/events' || path === '/metrics' || path === '/healthz' || path.startsWith('/print/')) {
+		return;
+	} else if (path.startsWith('/api/')) {
+		e.respondWith(networkFirst(request));
+	} else {
+		e.respondWith(staleWhileRevalidate(request));
+	}
+});
+
+// Caching strategies
+async function networkFirst(request) {
+	const cache = await caches.open(DATA_CACHE);
+	try {
+		const resp = await fetch(request);
+		if (resp.ok) {
+			cache.put(request, resp.clone());
+			replayQueue();
+		}
+		return resp;
+	} catch (err) {
+		const cached = await cache.match(request);
+		if (cached != null) return cached;
+		throw err;
+	}
+}
+
+async function staleWhileRevalidate(request) {
+	const cache = await caches.open(SHELL_CACHE);
+	const cached = await cache.match(request);
+	const fetching = fetch(request)
+		.then((resp) => {
+			if (resp.ok) cache.put(request, resp.clone());
+			return resp;
+		})
+		.catch((err) => {
+			if (cached == null) throw err;
+			return cached;
+		});
+
+	return cached || fetching;
+}
+
+// Fetch the next page of words in background, so it's available while offline
+async function prefetchNextPage(url, path, resp) {
+	if (!resp.ok) return resp;
+
+	try {
+		const data = await resp.clone().json();
+		if (data.currentPage >= data.maxPage) return resp;
+
+		const nextPage = data.currentPage + 1;
+		const nextURL = new URL(url.href);
+		const legacy = path.match(rxLegacyWords);
+		if (legacy != null) {
+			nextURL.pathname = `${scopePath}/api/words/surah/${legacy[1]}/page/${nextPage}`;
+		} else {
+			nextURL.searchParams.set('page', String(nextPage));
+		}
+
+		const cache = await caches.open(DATA_CACHE);
+		const nextResp = await fetch(nextURL.href);
+		if (nextResp.ok) await cache.put(nextURL.href, nextResp);
+	} catch (err) {
+		// Prefetch is only a bonus, so failure is ignored
+	}
+
+	return resp;
+}
+
+// Tracking and offline queue
+async function trackOrQueue(request) {
+	const url = new URL(request.url);
+	const word = await request.clone().json();
+	const submission = {
+		clientId: newClientId(),
+		wordId: word.id,
+		rewind: url.searchParams.get('rewind') === 'true',
+		createdAt: Date.now(),
+	};
+
+	// If there are queued submissions, keep the order by queueing this one as well
+	if ((await queueCount()) === 0) {
+		try {
+			return await fetch(request);
+		} catch (err) {
+			// Network is unavailable, fallback to queue
+		}
+	}
+
+	await enqueue(submission);
+	requestSync();
+	replayQueue();
+	return new Response('', { status: 202 });
+}
+
+let replaying = null;
+
+function replayQueue() {
+	if (replaying == null) {
+		replaying = doReplayQueue().finally(() => (replaying = null));
+	}
+	return replaying;
+}
+
+async function doReplayQueue() {
+	const queued = await queueAll();
+	const minCreatedAt = Date.now() - QUEUE_MAX_AGE;
+	const expired = queued.filter((s) => s.createdAt < minCreatedAt);
+	if (expired.length > 0) await dequeue(expired.map((s) => s.clientId));
+
+	const submissions = queued.filter((s) => s.createdAt >= minCreatedAt);
+	if (submissions.length === 0) return;
+
+	let resp;
+	try {
+		resp = await fetch(scopeURL('api/v1/progress/batch'), {
+			method: 'POST',
+			headers: { 'Content-Type': 'application/json; charset=utf-8' },
+			body: JSON.stringify(submissions.map(({ clientId, wordId, rewind }) => ({ clientId, wordId, rewind }))),
+		});
+	} catch (err) {
+		return;
+	}
+
+	if (!resp.ok) return;
+
+	const results = await resp.json();
+	await dequeue(results.map((r) => r.clientId));
+}
+
+function requestSync() {
+	if (self.registration.sync != null) {
+		self.registration.sync.register(SYNC_TAG).catch(() => {});
+	}
+}
+
+function newClientId() {
+	if (self.crypto && self.crypto.randomUUID) return self.crypto.randomUUID();
+	return `${Date.now().toString(36)}-${Math.random().toString(36).slice(2)}`;
+}
+
+// IndexedDB helper
+function openQueue() {
+	return new Promise((resolve, reject) => {
+		const req = indexedDB.open(QUEUE_DB, 1);
+		req.onupgradeneeded = () => {
+			req.result.createObjectStore(QUEUE_STORE, { keyPath: 'clientId' }).createIndex('createdAt', 'createdAt');
+		};
+		req.onsuccess = () => resolve(req.result);
+		req.onerror = () => reject(req.error);
+	});
+}
+
+async function queueTransaction(mode, fn) {
+	const db = await openQueue();
+	return new Promise((resolve, reject) => {
+		const tx = db.transaction(QUEUE_STORE, mode);
+		const result = fn(tx.objectStore(QUEUE_STORE));
+		tx.oncomplete = () => resolve(result.result !== undefined ? result.result : result);
+		tx.onerror = () => reject(tx.error);
+	}).finally(() => db.close());
+}
+
+function enqueue(submission) {
+	return queueTransaction('readwrite', (store) => store.put(submission));
+}
+
+function dequeue(clientIds) {
+	return queueTransaction('readwrite', (store) => {
+		clientIds.forEach((id) => store.delete(id));
+		return {};
+	});
+}
+
+function queueCount() {
+	return queueTransaction('readonly', (store) => store.count());
+}
+
+function queueAll() {
+	return queueTransaction('readonly', (store) => store.index('createdAt').getAll());
+}
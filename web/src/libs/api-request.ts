@@ -1,6 +1,13 @@
+// Base path where the app is served, exposed by server in the index page
+const basePath = document.querySelector<HTMLMetaElement>('meta[name="base-path"]')?.content ?? '';
+
+export function withBasePath(url: string): string {
+	return url.startsWith('/') ? basePath + url : url;
+}
+
 export async function getRequest(url: string): Promise<any> {
 	// Send GET request
-	const resp = await fetch(url);
+	const resp = await fetch(withBasePath(url));
 
 	// Check for error message
 	if (!resp.ok) {
@@ -29,7 +36,7 @@ export async function postRequest(url: string, body?: any): Promise<any> {
 	}
 
 	// Send POST request
-	const resp = await fetch(url, request);
+	const resp = await fetch(withBasePath(url), request);
 
 	// Check for error message
 	if (!resp.ok) {